@@ -0,0 +1,161 @@
+package pagerduty
+
+import (
+	"context"
+	"log"
+	"net/http"
+
+	"github.com/PagerDuty/terraform-provider-pagerduty/util/apiutil"
+	"github.com/heimweh/go-pagerduty/pagerduty"
+)
+
+// snapshotObject returns the object `id` of the snapshot of `collection`
+// when `bulk_refresh` is enabled, listing the collection with `list` on its
+// first read.
+func (c *Config) snapshotObject(collection, id string, list func(*pagerduty.Client) (map[string]interface{}, error)) (interface{}, bool) {
+	if !c.BulkRefresh {
+		return nil, false
+	}
+	return c.snapshots.Get(collection).Take(id, func() (map[string]interface{}, error) {
+		client, err := c.Client()
+		if err != nil {
+			return nil, err
+		}
+		log.Printf("[INFO] Listing every PagerDuty %s for bulk refresh", collection)
+		objects, err := list(client)
+		if err == nil {
+			log.Printf("[INFO] Listed %d PagerDuty %s for bulk refresh", len(objects), collection)
+		}
+		return objects, err
+	})
+}
+
+// forgetSnapshotObject drops the object `id` from the snapshot of
+// `collection` before it's modified, so that reading it afterwards isn't
+// served from the snapshot.
+func (c *Config) forgetSnapshotObject(collection, id string) {
+	if c.BulkRefresh {
+		c.snapshots.Get(collection).Forget(id)
+	}
+}
+
+// snapshotService returns the service `id` as read by `fetchService`.
+func (c *Config) snapshotService(id string) (*pagerduty.Service, bool) {
+	o, ok := c.snapshotObject("services", id, listServicesSnapshot)
+	if !ok {
+		return nil, false
+	}
+	return o.(*pagerduty.Service), true
+}
+
+// snapshotEscalationPolicy returns the escalation policy `id` as read by
+// `fetchEscalationPolicy`.
+func (c *Config) snapshotEscalationPolicy(id string) (*pagerduty.EscalationPolicy, bool) {
+	o, ok := c.snapshotObject("escalation_policies", id, listEscalationPoliciesSnapshot)
+	if !ok {
+		return nil, false
+	}
+	return o.(*pagerduty.EscalationPolicy), true
+}
+
+// snapshotUser returns the user `id` along with its license, as read by
+// `resourcePagerDutyUserRead`.
+func (c *Config) snapshotUser(id string) (*pagerduty.User, bool) {
+	o, ok := c.snapshotObject("users", id, listUsersSnapshot)
+	if !ok {
+		return nil, false
+	}
+	return o.(*pagerduty.User), true
+}
+
+func listServicesSnapshot(client *pagerduty.Client) (map[string]interface{}, error) {
+	services := map[string]interface{}{}
+	err := apiutil.All(context.Background(), func(offset int) (bool, error) {
+		resp, _, err := client.Services.List(&pagerduty.ListServicesOptions{
+			Limit:    apiutil.Limit,
+			Offset:   offset,
+			Includes: []string{"auto_pause_notifications_parameters"},
+		})
+		if err != nil {
+			return false, err
+		}
+		for _, s := range resp.Services {
+			services[s.ID] = s
+		}
+		return resp.More, nil
+	})
+	return services, err
+}
+
+func listEscalationPoliciesSnapshot(client *pagerduty.Client) (map[string]interface{}, error) {
+	includes := []string{"escalation_rule_assignment_strategies"}
+	policies := map[string]interface{}{}
+	err := apiutil.All(context.Background(), func(offset int) (bool, error) {
+		resp, _, err := client.EscalationPolicies.List(&pagerduty.ListEscalationPoliciesOptions{
+			Limit:    apiutil.Limit,
+			Offset:   offset,
+			Includes: includes,
+		})
+		if err != nil && offset == 0 && (isErrCode(err, http.StatusForbidden) || isMalformedForbiddenError(err)) {
+			// Removing the inclusion of escalation_rule_assignment_strategies
+			// for accounts wihtout the required entitlements.
+			includes = nil
+			resp, _, err = client.EscalationPolicies.List(&pagerduty.ListEscalationPoliciesOptions{
+				Limit:  apiutil.Limit,
+				Offset: offset,
+			})
+		}
+		if err != nil {
+			return false, err
+		}
+		for _, ep := range resp.EscalationPolicies {
+			policies[ep.ID] = ep
+		}
+		return resp.More, nil
+	})
+	return policies, err
+}
+
+// listUsersSnapshot lists the users with their licenses, leaving out the ones
+// without license allocation, which are read on their own.
+func listUsersSnapshot(client *pagerduty.Client) (map[string]interface{}, error) {
+	users := map[string]*pagerduty.User{}
+	err := apiutil.All(context.Background(), func(offset int) (bool, error) {
+		resp, _, err := client.Users.List(&pagerduty.ListUsersOptions{
+			Limit:  apiutil.Limit,
+			Offset: offset,
+		})
+		if err != nil {
+			return false, err
+		}
+		for _, u := range resp.Users {
+			users[u.ID] = u
+		}
+		return resp.More, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	licensed := map[string]interface{}{}
+	err = apiutil.All(context.Background(), func(offset int) (bool, error) {
+		resp, _, err := client.Licenses.ListAllocations(&pagerduty.ListLicenseAllocationsOptions{
+			Limit:  apiutil.Limit,
+			Offset: offset,
+		})
+		if err != nil {
+			return false, err
+		}
+		for _, la := range resp.LicenseAllocations {
+			if la.User == nil || la.License == nil {
+				continue
+			}
+			if u, ok := users[la.User.ID]; ok {
+				u.License = &pagerduty.LicenseReference{ID: la.License.ID, Type: "license_reference"}
+				licensed[u.ID] = u
+			}
+		}
+		return resp.More, nil
+	})
+	return licensed, err
+}
@@ -0,0 +1,98 @@
+package pagerduty
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/PagerDuty/terraform-provider-pagerduty/util/mockapi"
+	"github.com/heimweh/go-pagerduty/pagerduty"
+)
+
+// testBulkRefreshAPI counts the listings and reads of every collection made
+// through the fake API served by `newTestBulkRefreshAPI`.
+type testBulkRefreshAPI struct {
+	mu           sync.Mutex
+	lists, reads map[string]int
+}
+
+func (a *testBulkRefreshAPI) counts(collection string) (lists, reads int) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.lists[collection], a.reads[collection]
+}
+
+func newTestBulkRefreshAPI(t *testing.T, server *mockapi.Server) (*testBulkRefreshAPI, string) {
+	a := &testBulkRefreshAPI{lists: map[string]int{}, reads: map[string]int{}}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			segments := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+			a.mu.Lock()
+			if len(segments) == 1 {
+				a.lists[segments[0]]++
+			} else {
+				a.reads[segments[0]]++
+			}
+			a.mu.Unlock()
+		}
+		server.Config.Handler.ServeHTTP(w, r)
+	}))
+	t.Cleanup(ts.Close)
+	return a, ts.URL
+}
+
+func TestBulkRefresh(t *testing.T) {
+	server := mockapi.NewServer()
+	defer server.Close()
+	client, err := (&Config{ApiUrl: server.URL, Token: "foo", SkipCredsValidation: true}).Client()
+	if err != nil {
+		t.Fatal(err)
+	}
+	createService := func(name string) string {
+		service, _, err := client.Services.Create(&pagerduty.Service{
+			Name:             name,
+			EscalationPolicy: &pagerduty.EscalationPolicyReference{ID: "PEP", Type: "escalation_policy_reference"},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return service.ID
+	}
+	foo, bar := createService("foo"), createService("bar")
+
+	api, url := newTestBulkRefreshAPI(t, server)
+	meta := &Config{ApiUrl: url, Token: "foo", SkipCredsValidation: true, BulkRefresh: true}
+
+	read := func(id string) string {
+		d := resourcePagerDutyService().TestResourceData()
+		d.SetId(id)
+		if err := resourcePagerDutyServiceRead(d, meta); err != nil {
+			t.Fatal(err)
+		}
+		return d.Get("name").(string)
+	}
+
+	if name := read(foo); name != "foo" {
+		t.Errorf("expected the listed service, got %q", name)
+	}
+	if name := read(bar); name != "bar" {
+		t.Errorf("expected the listed service, got %q", name)
+	}
+	if lists, reads := api.counts("services"); lists != 1 || reads != 0 {
+		t.Errorf("expected the services to be listed once, got %d list and %d get requests", lists, reads)
+	}
+
+	// Services missing from the snapshot, or already read, are requested.
+	baz := createService("baz")
+	if name := read(baz); name != "baz" {
+		t.Errorf("expected the requested service, got %q", name)
+	}
+	if name := read(foo); name != "foo" {
+		t.Errorf("expected the requested service, got %q", name)
+	}
+	if lists, reads := api.counts("services"); lists != 1 || reads != 2 {
+		t.Errorf("expected 2 get requests, got %d list and %d get requests", lists, reads)
+	}
+}
@@ -1,7 +1,7 @@
 package pagerduty
 
 import (
-	"crypto/tls"
+	"context"
 	"fmt"
 	"log"
 	"net/http"
@@ -9,9 +9,14 @@ import (
 	"time"
 
 	"github.com/PagerDuty/terraform-provider-pagerduty/util"
+	"github.com/PagerDuty/terraform-provider-pagerduty/util/apiutil"
+	"github.com/PagerDuty/terraform-provider-pagerduty/util/cache"
+	"github.com/PagerDuty/terraform-provider-pagerduty/util/httpclient"
+	"github.com/PagerDuty/terraform-provider-pagerduty/util/oauthtoken"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/logging"
 	"github.com/heimweh/go-pagerduty/pagerduty"
 	"github.com/heimweh/go-pagerduty/persistentconfig"
+	"golang.org/x/oauth2"
 )
 
 // Config defines the configuration options for the PagerDuty client
@@ -46,10 +51,44 @@ type Config struct {
 
 	AppOauthScopedTokenParams *persistentconfig.AppOauthScopedTokenParams
 
+	// Scopes the App OAuth token is requested with, every scope when empty
+	AppOauthScopes []string
+
+	// Directory the App OAuth token is cached in, oauthtoken.DefaultCacheDir
+	// when empty
+	AppOauthTokenCacheDir string
+
 	ServiceRegion string
 
-	client      *pagerduty.Client
-	slackClient *pagerduty.Client
+	// Amount of times a request is retried when rate limited or failing
+	MaxRetries int
+
+	// Timeout of every attempt of a request, defaults to
+	// httpclient.DefaultRequestTimeout seconds when zero
+	RequestTimeout time.Duration
+
+	// Limit of requests sent to the API per minute
+	MaxRequestsPerMinute int
+
+	// Teams assigned to the objects which don't configure their teams
+	DefaultTeams []string
+
+	// Labels of the tags assigned to the objects supporting tags
+	DefaultTags []string
+
+	// Cache of the reads of the objects, nil when disabled
+	Cache *cache.Cache
+
+	// Fill the cache when the client is initialized
+	CachePrefill bool
+
+	// Read objects from a listing of their whole collection
+	BulkRefresh bool
+
+	snapshots        apiutil.Snapshots
+	oauthTokenSource oauth2.TokenSource
+	client           *pagerduty.Client
+	slackClient      *pagerduty.Client
 }
 
 const invalidCreds = `
@@ -74,15 +113,19 @@ func (c *Config) Client() (*pagerduty.Client, error) {
 		return nil, fmt.Errorf(invalidCreds)
 	}
 
-	var httpClient *http.Client
-	httpClient = http.DefaultClient
-	httpClient.Timeout = 30 * time.Second
+	httpClient := httpclient.Shared(c.httpClientOptions())
 
-	transport := http.DefaultTransport.(*http.Transport).Clone()
-	if c.InsecureTls {
-		transport.TLSClientConfig = &tls.Config{InsecureSkipVerify: true}
+	// The App OAuth scoped token is requested by the provider, and renewed
+	// whenever it's rejected, by the transport of the client.
+	var oauthToken *oauth2.Token
+	if source := c.appOauthTokenSource(); source != nil {
+		token, err := source.Token()
+		if err != nil {
+			return nil, fmt.Errorf("failed to obtain an App OAuth scoped token: %w", err)
+		}
+		oauthToken = token
+		httpClient = &http.Client{Transport: oauthtoken.Transport(source, httpClient.Transport)}
 	}
-	httpClient.Transport = logging.NewTransport("PagerDuty", transport)
 
 	apiUrl := c.ApiUrl
 	if c.ApiUrlOverride != "" {
@@ -119,6 +162,23 @@ func (c *Config) Client() (*pagerduty.Client, error) {
 		}
 	}
 
+	if c.CachePrefill {
+		authorization := "Token token=" + c.Token
+		if oauthToken != nil {
+			authorization = "Bearer " + oauthToken.AccessToken
+		} else if c.AppOauthScopedTokenParams != nil && *c.APITokenType == pagerduty.AuthTokenTypeScopedOauthToken {
+			authorization = "Bearer " + c.AppOauthScopedTokenParams.Token
+		}
+		header := http.Header{
+			"Accept":        []string{"application/vnd.pagerduty+json;version=2"},
+			"Authorization": []string{authorization},
+			"User-Agent":    []string{config.UserAgent},
+		}
+		if err := c.Cache.Prefill(context.Background(), httpClient, apiUrl, header); err != nil {
+			log.Printf("[WARN] %s", err)
+		}
+	}
+
 	c.client = client
 
 	log.Printf("[INFO] PagerDuty client configured")
@@ -126,6 +186,43 @@ func (c *Config) Client() (*pagerduty.Client, error) {
 	return c.client, nil
 }
 
+// appOauthTokenSource returns the source of the App OAuth scoped tokens
+// requested by the provider, nil when it doesn't request any. It must be
+// called with `c.mu` held.
+func (c *Config) appOauthTokenSource() oauth2.TokenSource {
+	params := c.AppOauthScopedTokenParams
+	if params == nil || c.APITokenType == nil || *c.APITokenType != pagerduty.AuthTokenTypeScopedOauthToken || params.Token != "" {
+		return nil
+	}
+
+	if c.oauthTokenSource == nil {
+		c.oauthTokenSource = oauthtoken.NewTokenSource(oauthtoken.Options{
+			ClientID:     params.ClientID,
+			ClientSecret: params.ClientSecret,
+			Region:       params.Region,
+			Subdomain:    params.PDSubDomain,
+			Scopes:       c.AppOauthScopes,
+			CacheDir:     c.AppOauthTokenCacheDir,
+		})
+	}
+	return c.oauthTokenSource
+}
+
+func (c *Config) httpClientOptions() httpclient.Options {
+	requestTimeout := c.RequestTimeout
+	if requestTimeout == 0 {
+		requestTimeout = httpclient.DefaultRequestTimeout * time.Second
+	}
+
+	return httpclient.Options{
+		MaxRetries:           c.MaxRetries,
+		RequestTimeout:       requestTimeout,
+		MaxRequestsPerMinute: c.MaxRequestsPerMinute,
+		InsecureTLS:          c.InsecureTls,
+		Cache:                c.Cache,
+	}
+}
+
 func (c *Config) SlackClient() (*pagerduty.Client, error) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
@@ -140,15 +237,7 @@ func (c *Config) SlackClient() (*pagerduty.Client, error) {
 		return nil, fmt.Errorf(invalidCreds)
 	}
 
-	var httpClient *http.Client
-	httpClient = http.DefaultClient
-	httpClient.Timeout = 30 * time.Second
-
-	transport := http.DefaultTransport.(*http.Transport).Clone()
-	if c.InsecureTls {
-		transport.TLSClientConfig = &tls.Config{InsecureSkipVerify: true}
-	}
-	httpClient.Transport = logging.NewTransport("PagerDuty", transport)
+	httpClient := httpclient.Shared(c.httpClientOptions())
 
 	config := &pagerduty.Config{
 		BaseURL:    c.AppUrl,
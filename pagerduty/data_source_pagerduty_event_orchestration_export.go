@@ -0,0 +1,286 @@
+package pagerduty
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/heimweh/go-pagerduty/pagerduty"
+)
+
+func dataSourcePagerDutyEventOrchestrationExport() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourcePagerDutyEventOrchestrationExportRead,
+
+		Schema: map[string]*schema.Schema{
+			"event_orchestration": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"services": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"resource_name": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringMatch(hclIdentifier, "must be a valid Terraform resource name"),
+			},
+			"hcl": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"resources": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"address": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"import_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourcePagerDutyEventOrchestrationExportRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, err := meta.(*Config).Client()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	oid := d.Get("event_orchestration").(string)
+	log.Printf("[INFO] Exporting PagerDuty Event Orchestration '%s'", oid)
+
+	var orchestration *pagerduty.EventOrchestration
+	var integrations []*pagerduty.EventOrchestrationIntegration
+	err = retry.RetryContext(ctx, 2*time.Minute, func() *retry.RetryError {
+		var err error
+		orchestration, _, err = client.EventOrchestrations.Get(oid)
+		if err == nil {
+			var resp *pagerduty.ListEventOrchestrationIntegrationsResponse
+			resp, _, err = client.EventOrchestrationIntegrations.ListContext(ctx, oid)
+			if err == nil {
+				integrations = resp.Integrations
+			}
+		}
+		if err != nil {
+			if isErrCode(err, http.StatusBadRequest) || isErrCode(err, http.StatusNotFound) {
+				return retry.NonRetryableError(err)
+			}
+			return retry.RetryableError(err)
+		}
+		return nil
+	})
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("Unable to read Event Orchestration %s: %w", oid, err))
+	}
+
+	names := exportResourceNames{}
+	name := d.Get("resource_name").(string)
+	if name == "" {
+		name = names.name("pagerduty_event_orchestration", orchestration.Name)
+	}
+	orchestrationRef := map[string]string{"event_orchestration": fmt.Sprintf("pagerduty_event_orchestration.%s.id", name)}
+
+	var resources []*exportedResource
+	add := func(resourceType, name, importID string, res *schema.Resource, references map[string]string, set func(*schema.ResourceData)) {
+		data := res.Data(nil)
+		set(data)
+		resources = append(resources, &exportedResource{
+			resourceType: resourceType,
+			name:         name,
+			importID:     importID,
+			resource:     res,
+			data:         data,
+			references:   references,
+		})
+	}
+
+	add("pagerduty_event_orchestration", name, oid, resourcePagerDutyEventOrchestration(), nil, func(data *schema.ResourceData) {
+		setEventOrchestrationProps(data, orchestration)
+	})
+
+	for _, i := range integrations {
+		i := i
+		add("pagerduty_event_orchestration_integration", names.name("pagerduty_event_orchestration_integration", name+"_"+i.Label),
+			fmt.Sprintf("%s:%s", oid, i.ID), resourcePagerDutyEventOrchestrationIntegration(), orchestrationRef, func(data *schema.ResourceData) {
+				setEventOrchestrationIntegrationProps(data, i)
+			})
+	}
+
+	global, err := fetchEventOrchestrationPath(ctx, client, oid, pagerduty.PathTypeGlobal)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	add("pagerduty_event_orchestration_global", name, oid, resourcePagerDutyEventOrchestrationPathGlobal(), orchestrationRef, func(data *schema.ResourceData) {
+		setEventOrchestrationPathGlobalProps(data, global)
+	})
+
+	router, err := fetchEventOrchestrationPath(ctx, client, oid, pagerduty.PathTypeRouter)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	add("pagerduty_event_orchestration_router", name, oid, resourcePagerDutyEventOrchestrationPathRouter(), orchestrationRef, func(data *schema.ResourceData) {
+		data.Set("set", flattenSets(router.Sets))
+		data.Set("catch_all", flattenCatchAll(router.CatchAll))
+	})
+
+	unrouted, err := fetchEventOrchestrationPath(ctx, client, oid, pagerduty.PathTypeUnrouted)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	add("pagerduty_event_orchestration_unrouted", name, oid, resourcePagerDutyEventOrchestrationPathUnrouted(), orchestrationRef, func(data *schema.ResourceData) {
+		data.Set("set", flattenUnroutedSets(unrouted.Sets))
+		data.Set("catch_all", flattenUnroutedCatchAll(unrouted.CatchAll))
+	})
+
+	cacheVariables, err := listEventOrchestrationExportCacheVariables(ctx, client, pagerduty.CacheVariableTypeGlobal, oid)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	for _, cv := range cacheVariables {
+		cv := cv
+		add("pagerduty_event_orchestration_global_cache_variable", names.name("pagerduty_event_orchestration_global_cache_variable", name+"_"+cv.Name),
+			fmt.Sprintf("%s:%s", oid, cv.ID), resourcePagerDutyEventOrchestrationGlobalCacheVariable(), orchestrationRef, func(data *schema.ResourceData) {
+				setEventOrchestrationCacheVariableProps(data, cv)
+			})
+	}
+
+	for _, serviceID := range eventOrchestrationExportServices(router, d.Get("services").([]interface{})) {
+		servicePath, err := fetchEventOrchestrationPath(ctx, client, serviceID, pagerduty.PathTypeService)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		active, err := getEventOrchestrationExportServiceActiveStatus(ctx, client, serviceID)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		add("pagerduty_event_orchestration_service", names.name("pagerduty_event_orchestration_service", name+"_"+serviceID),
+			serviceID, resourcePagerDutyEventOrchestrationPathService(), nil, func(data *schema.ResourceData) {
+				setEventOrchestrationPathServiceProps(data, servicePath)
+				data.Set("enable_event_orchestration_for_service", active)
+			})
+
+		cacheVariables, err := listEventOrchestrationExportCacheVariables(ctx, client, pagerduty.CacheVariableTypeService, serviceID)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		for _, cv := range cacheVariables {
+			cv := cv
+			add("pagerduty_event_orchestration_service_cache_variable", names.name("pagerduty_event_orchestration_service_cache_variable", name+"_"+serviceID+"_"+cv.Name),
+				fmt.Sprintf("%s:%s", serviceID, cv.ID), resourcePagerDutyEventOrchestrationServiceCacheVariable(), nil, func(data *schema.ResourceData) {
+					data.Set("service", serviceID)
+					setEventOrchestrationCacheVariableProps(data, cv)
+				})
+		}
+	}
+
+	var flattenedResources []map[string]interface{}
+	for _, r := range resources {
+		flattenedResources = append(flattenedResources, map[string]interface{}{
+			"address":   r.address(),
+			"import_id": r.importID,
+		})
+	}
+
+	d.SetId(oid)
+	d.Set("hcl", renderExportedResourcesHCL(resources))
+	d.Set("resources", flattenedResources)
+
+	return nil
+}
+
+// eventOrchestrationExportServices returns the IDs of the services the
+// Router routes events to, along with the `extra` ones, sorted.
+func eventOrchestrationExportServices(router *pagerduty.EventOrchestrationPath, extra []interface{}) []string {
+	seen := map[string]bool{}
+	var ids []string
+	add := func(id string) {
+		if id == "" || id == "unrouted" || seen[id] {
+			return
+		}
+		seen[id] = true
+		ids = append(ids, id)
+	}
+
+	for _, set := range router.Sets {
+		for _, rule := range set.Rules {
+			if rule.Actions != nil {
+				add(rule.Actions.RouteTo)
+			}
+		}
+	}
+	if router.CatchAll != nil && router.CatchAll.Actions != nil {
+		add(router.CatchAll.Actions.RouteTo)
+	}
+	for _, id := range extra {
+		if id != nil {
+			add(id.(string))
+		}
+	}
+
+	sort.Strings(ids)
+	return ids
+}
+
+func listEventOrchestrationExportCacheVariables(ctx context.Context, client *pagerduty.Client, cacheVariableType, oid string) ([]*pagerduty.EventOrchestrationCacheVariable, error) {
+	var cacheVariables []*pagerduty.EventOrchestrationCacheVariable
+	err := retry.RetryContext(ctx, 2*time.Minute, func() *retry.RetryError {
+		resp, _, err := client.EventOrchestrationCacheVariables.List(ctx, cacheVariableType, oid)
+		if err != nil {
+			if isErrCode(err, http.StatusBadRequest) || isErrCode(err, http.StatusNotFound) {
+				return retry.NonRetryableError(err)
+			}
+			return retry.RetryableError(err)
+		}
+		cacheVariables = resp.CacheVariables
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Unable to list the Cache Variables of %s: %w", oid, err)
+	}
+	return cacheVariables, nil
+}
+
+func getEventOrchestrationExportServiceActiveStatus(ctx context.Context, client *pagerduty.Client, serviceID string) (bool, error) {
+	var active bool
+	err := retry.RetryContext(ctx, 2*time.Minute, func() *retry.RetryError {
+		status, _, err := client.EventOrchestrationPaths.GetServiceActiveStatusContext(ctx, serviceID)
+		if err != nil {
+			// The status endpoint returns 410 (Gone) once Service
+			// Orchestrations are always active.
+			if isErrCode(err, http.StatusGone) {
+				active = true
+				return nil
+			}
+			if isErrCode(err, http.StatusBadRequest) || isErrCode(err, http.StatusNotFound) {
+				return retry.NonRetryableError(err)
+			}
+			return retry.RetryableError(err)
+		}
+		active = status.Active
+		return nil
+	})
+	if err != nil {
+		return false, fmt.Errorf("Unable to read whether the Service Orchestration of %s is active: %w", serviceID, err)
+	}
+	return active, nil
+}
@@ -0,0 +1,84 @@
+package pagerduty
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccDataSourcePagerDutyEventOrchestrationExport_Basic(t *testing.T) {
+	team := fmt.Sprintf("tf-name-%s", acctest.RandString(5))
+	escalationPolicy := fmt.Sprintf("tf-%s", acctest.RandString(5))
+	service := fmt.Sprintf("tf-%s", acctest.RandString(5))
+	orchestration := fmt.Sprintf("tf-orchestration-%s", acctest.RandString(5))
+	n := "data.pagerduty_event_orchestration_export.export"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourcePagerDutyEventOrchestrationExportConfig(team, escalationPolicy, service, orchestration),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(n, "id", "pagerduty_event_orchestration.orch", "id"),
+					resource.TestCheckResourceAttr(n, "resources.0.address", "pagerduty_event_orchestration.exported"),
+					resource.TestCheckResourceAttrPair(n, "resources.0.import_id", "pagerduty_event_orchestration.orch", "id"),
+					resource.TestMatchResourceAttr(n, "hcl", regexp.MustCompile(`resource "pagerduty_event_orchestration_router" "exported" \{`)),
+					resource.TestMatchResourceAttr(n, "hcl", regexp.MustCompile(`resource "pagerduty_event_orchestration_service" "exported_p\w+" \{`)),
+					resource.TestMatchResourceAttr(n, "hcl", regexp.MustCompile(`resource "pagerduty_event_orchestration_global_cache_variable" "exported_tf_count" \{`)),
+					resource.TestMatchResourceAttr(n, "hcl", regexp.MustCompile(`expression = "event.summary matches part 'database'"`)),
+					resource.TestMatchResourceAttr(n, "hcl", regexp.MustCompile(`import \{\n  to = pagerduty_event_orchestration_unrouted.exported\n`)),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourcePagerDutyEventOrchestrationExportConfig(t, ep, s, o string) string {
+	return fmt.Sprintf("%s%s", createBaseConfig(t, ep, s, o), `
+resource "pagerduty_event_orchestration_router" "router" {
+  event_orchestration = pagerduty_event_orchestration.orch.id
+
+  set {
+    id = "start"
+    rule {
+      label = "database"
+      condition {
+        expression = "event.summary matches part 'database'"
+      }
+      actions {
+        route_to = pagerduty_service.bar.id
+      }
+    }
+  }
+  catch_all {
+    actions {
+      route_to = "unrouted"
+    }
+  }
+}
+
+resource "pagerduty_event_orchestration_global_cache_variable" "count" {
+  event_orchestration = pagerduty_event_orchestration.orch.id
+  name = "tf_count"
+
+  configuration {
+    type = "trigger_event_count"
+    ttl_seconds = 60
+  }
+}
+
+data "pagerduty_event_orchestration_export" "export" {
+  event_orchestration = pagerduty_event_orchestration.orch.id
+  resource_name       = "exported"
+
+  depends_on = [
+    pagerduty_event_orchestration_router.router,
+    pagerduty_event_orchestration_global_cache_variable.count,
+  ]
+}
+`)
+}
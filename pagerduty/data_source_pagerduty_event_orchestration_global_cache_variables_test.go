@@ -0,0 +1,84 @@
+package pagerduty
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccDataSourcePagerDutyEventOrchestrationGlobalCacheVariables_Basic(t *testing.T) {
+	on := fmt.Sprintf("tf-orchestration-%s", acctest.RandString(5))
+	name := fmt.Sprintf("tf_global_cache_variable_%s", acctest.RandString(5))
+	n := "data.pagerduty_event_orchestration_global_cache_variables.all"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourcePagerDutyEventOrchestrationGlobalCacheVariablesConfig(on, name),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(n, "id", "pagerduty_event_orchestration.orch", "id"),
+					resource.TestCheckResourceAttr(n, "cache_variables.#", "2"),
+					resource.TestCheckResourceAttr(n, "cache_variables.0.name", name+"_a"),
+					resource.TestCheckResourceAttrPair(n, "cache_variables.0.id", "pagerduty_event_orchestration_global_cache_variable.a", "id"),
+					resource.TestCheckResourceAttr(n, "cache_variables.0.configuration.0.type", "trigger_event_count"),
+					resource.TestCheckResourceAttr(n, "cache_variables.0.configuration.0.ttl_seconds", "60"),
+					resource.TestCheckResourceAttr(n, "cache_variables.0.disabled", "false"),
+					resource.TestCheckResourceAttr(n, "cache_variables.1.name", name+"_b"),
+					resource.TestCheckResourceAttr(n, "cache_variables.1.condition.0.expression", "event.source exists"),
+					resource.TestCheckResourceAttr(n, "cache_variables.1.configuration.0.type", "recent_value"),
+					resource.TestCheckResourceAttr(n, "cache_variables.1.configuration.0.source", "event.source"),
+					resource.TestCheckResourceAttr(n, "cache_variables.1.disabled", "true"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourcePagerDutyEventOrchestrationGlobalCacheVariablesConfig(on, name string) string {
+	return fmt.Sprintf(`
+    resource "pagerduty_event_orchestration" "orch" {
+      name = "%[1]s"
+    }
+
+    resource "pagerduty_event_orchestration_global_cache_variable" "a" {
+      event_orchestration = pagerduty_event_orchestration.orch.id
+      name = "%[2]s_a"
+
+      configuration {
+        type = "trigger_event_count"
+        ttl_seconds = 60
+      }
+    }
+
+    resource "pagerduty_event_orchestration_global_cache_variable" "b" {
+      event_orchestration = pagerduty_event_orchestration.orch.id
+      name = "%[2]s_b"
+      disabled = true
+
+      condition {
+        expression = "event.source exists"
+      }
+
+      configuration {
+        type = "recent_value"
+        source = "event.source"
+        regex = ".*"
+      }
+
+      depends_on = [pagerduty_event_orchestration_global_cache_variable.a]
+    }
+
+    data "pagerduty_event_orchestration_global_cache_variables" "all" {
+      event_orchestration = pagerduty_event_orchestration.orch.id
+
+      depends_on = [
+        pagerduty_event_orchestration_global_cache_variable.a,
+        pagerduty_event_orchestration_global_cache_variable.b,
+      ]
+    }
+    `, on, name)
+}
@@ -0,0 +1,32 @@
+package pagerduty
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/heimweh/go-pagerduty/pagerduty"
+)
+
+func dataSourcePagerDutyEventOrchestrationServiceCacheVariables() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourcePagerDutyEventOrchestrationServiceCacheVariablesRead,
+		Schema: map[string]*schema.Schema{
+			"service": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"cache_variables": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: dataSourceEventOrchestrationCacheVariablesSchema,
+				},
+			},
+		},
+	}
+}
+
+func dataSourcePagerDutyEventOrchestrationServiceCacheVariablesRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return dataSourceEventOrchestrationCacheVariablesRead(ctx, d, meta, pagerduty.CacheVariableTypeService)
+}
@@ -0,0 +1,60 @@
+package pagerduty
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccDataSourcePagerDutyEventOrchestrationServiceCacheVariables_Basic(t *testing.T) {
+	svc := fmt.Sprintf("tf-service-%s", acctest.RandString(5))
+	name := fmt.Sprintf("tf_service_cache_variable_%s", acctest.RandString(5))
+	n := "data.pagerduty_event_orchestration_service_cache_variables.all"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourcePagerDutyEventOrchestrationServiceCacheVariablesConfig(svc, name),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(n, "id", "pagerduty_service.svc", "id"),
+					resource.TestCheckResourceAttr(n, "cache_variables.#", "1"),
+					resource.TestCheckResourceAttrPair(n, "cache_variables.0.id", "pagerduty_event_orchestration_service_cache_variable.orch_cv", "id"),
+					resource.TestCheckResourceAttr(n, "cache_variables.0.name", name),
+					resource.TestCheckResourceAttr(n, "cache_variables.0.configuration.0.type", "trigger_event_count"),
+					resource.TestCheckResourceAttr(n, "cache_variables.0.configuration.0.ttl_seconds", "60"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourcePagerDutyEventOrchestrationServiceCacheVariablesConfig(svc, name string) string {
+	return fmt.Sprintf(`
+		%s
+
+		resource "pagerduty_service" "svc" {
+		  name = "%s"
+		  escalation_policy = pagerduty_escalation_policy.ep.id
+		}
+
+    resource "pagerduty_event_orchestration_service_cache_variable" "orch_cv" {
+      service = pagerduty_service.svc.id
+      name = "%s"
+
+      configuration {
+        type = "trigger_event_count"
+        ttl_seconds = 60
+      }
+    }
+
+    data "pagerduty_event_orchestration_service_cache_variables" "all" {
+      service = pagerduty_service.svc.id
+
+      depends_on = [pagerduty_event_orchestration_service_cache_variable.orch_cv]
+    }
+    `, EPResources, svc, name)
+}
@@ -0,0 +1,329 @@
+package pagerduty
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/heimweh/go-pagerduty/pagerduty"
+)
+
+func dataSourcePagerDutyEventOrchestrationSimulation() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourcePagerDutyEventOrchestrationSimulationRead,
+
+		Schema: map[string]*schema.Schema{
+			"event_orchestration": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"event": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsJSON,
+			},
+			"now": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateRFC3339,
+			},
+			"global_path": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsJSON,
+			},
+			"router_path": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsJSON,
+			},
+			"unrouted_path": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsJSON,
+			},
+			"service_paths": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"matched_rules": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"path": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"set": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"label": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+			"route": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"severity": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"priority": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"event_action": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"suppressed": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"dropped": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"variables": {
+				Type:     schema.TypeMap,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"resulting_event": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"warnings": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+	}
+}
+
+func dataSourcePagerDutyEventOrchestrationSimulationRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, err := meta.(*Config).Client()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	oid := d.Get("event_orchestration").(string)
+	now := time.Now()
+	if v, ok := d.GetOk("now"); ok {
+		now, err = time.Parse(time.RFC3339, v.(string))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	s, err := newEventOrchestrationSimulation(d.Get("event").(string), now)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	s.lookupService = func(lookupBy, value string) (string, error) {
+		if lookupBy != "service_id" && lookupBy != "service_name" {
+			s.warn("dynamic routing by %s to %q can't be simulated, so the rule was skipped", lookupBy, value)
+			return "", nil
+		}
+		return lookupEventOrchestrationSimulationService(ctx, client, lookupBy, value)
+	}
+
+	log.Printf("[INFO] Simulating an event against PagerDuty Event Orchestration '%s'", oid)
+
+	global, err := eventOrchestrationSimulationPath(ctx, client, d.Get("global_path").(string), oid, pagerduty.PathTypeGlobal)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	s.runPath(pagerduty.PathTypeGlobal, global)
+
+	if !s.dropped {
+		router, err := eventOrchestrationSimulationPath(ctx, client, d.Get("router_path").(string), oid, pagerduty.PathTypeRouter)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		if err := s.runRouter(router); err != nil {
+			return diag.FromErr(err)
+		}
+
+		if s.route == "unrouted" {
+			unrouted, err := eventOrchestrationSimulationPath(ctx, client, d.Get("unrouted_path").(string), oid, pagerduty.PathTypeUnrouted)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			s.runPath(pagerduty.PathTypeUnrouted, unrouted)
+		} else if raw, ok := d.Get("service_paths").(map[string]interface{})[s.route]; ok {
+			path, err := decodeEventOrchestrationSimulationPath(fmt.Sprintf("service_paths[%q]", s.route), raw.(string))
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			s.runPath(pagerduty.PathTypeService, path)
+		} else if diags := runEventOrchestrationSimulationServicePath(ctx, client, s); diags != nil {
+			return diags
+		}
+	}
+
+	return setEventOrchestrationSimulationProps(d, oid, s)
+}
+
+// eventOrchestrationSimulationPath returns the path configured as `raw`, or
+// reads the one of PagerDuty if there is none, so that changes not applied
+// yet can be simulated.
+func eventOrchestrationSimulationPath(ctx context.Context, client *pagerduty.Client, raw, id, pathType string) (*pagerduty.EventOrchestrationPath, error) {
+	if raw != "" {
+		return decodeEventOrchestrationSimulationPath(pathType+"_path", raw)
+	}
+	return fetchEventOrchestrationPath(ctx, client, id, pathType)
+}
+
+// decodeEventOrchestrationSimulationPath decodes the path configured as the
+// attribute `attr`, in the JSON shape of the paths of PagerDuty's API.
+func decodeEventOrchestrationSimulationPath(attr, raw string) (*pagerduty.EventOrchestrationPath, error) {
+	path := &pagerduty.EventOrchestrationPath{}
+	if err := json.Unmarshal([]byte(raw), path); err != nil {
+		return nil, fmt.Errorf("%s must be an Event Orchestration path as a JSON object: %w", attr, err)
+	}
+	return path, nil
+}
+
+func runEventOrchestrationSimulationServicePath(ctx context.Context, client *pagerduty.Client, s *eventOrchestrationSimulation) diag.Diagnostics {
+	var status *pagerduty.EventOrchestrationPathServiceActiveStatus
+	retryErr := retry.RetryContext(ctx, 2*time.Minute, func() *retry.RetryError {
+		var err error
+		status, _, err = client.EventOrchestrationPaths.GetServiceActiveStatusContext(ctx, s.route)
+		if err != nil {
+			if isErrCode(err, http.StatusBadRequest) || isErrCode(err, http.StatusNotFound) {
+				return retry.NonRetryableError(err)
+			}
+			return retry.RetryableError(err)
+		}
+		return nil
+	})
+	if retryErr != nil {
+		if isErrCode(retryErr, http.StatusNotFound) {
+			s.warn("the event is routed to service %s, which doesn't exist", s.route)
+			return nil
+		}
+		return diag.FromErr(retryErr)
+	}
+
+	if !status.Active {
+		s.warn("the Service Orchestration of service %s is not active, so its rules were not evaluated", s.route)
+		return nil
+	}
+
+	path, err := fetchEventOrchestrationPath(ctx, client, s.route, pagerduty.PathTypeService)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	s.runPath(pagerduty.PathTypeService, path)
+	return nil
+}
+
+// lookupEventOrchestrationSimulationService returns the ID of the service
+// dynamic routing finds for `value` when looking up by `lookupBy`, either
+// `service_id` or `service_name`, or an empty string if there is none.
+func lookupEventOrchestrationSimulationService(ctx context.Context, client *pagerduty.Client, lookupBy, value string) (string, error) {
+	var id string
+	err := retry.RetryContext(ctx, 2*time.Minute, func() *retry.RetryError {
+		switch lookupBy {
+		case "service_id":
+			service, _, err := client.Services.Get(value, &pagerduty.GetServiceOptions{})
+			if err != nil {
+				if isErrCode(err, http.StatusNotFound) {
+					return nil
+				}
+				if isErrCode(err, http.StatusBadRequest) {
+					return retry.NonRetryableError(err)
+				}
+				return retry.RetryableError(err)
+			}
+			id = service.ID
+
+		default:
+			resp, _, err := client.Services.List(&pagerduty.ListServicesOptions{Query: value})
+			if err != nil {
+				if isErrCode(err, http.StatusBadRequest) {
+					return retry.NonRetryableError(err)
+				}
+				return retry.RetryableError(err)
+			}
+			for _, service := range resp.Services {
+				if service.Name == value {
+					id = service.ID
+					break
+				}
+			}
+		}
+		return nil
+	})
+	return id, err
+}
+
+func setEventOrchestrationSimulationProps(d *schema.ResourceData, oid string, s *eventOrchestrationSimulation) diag.Diagnostics {
+	var matchedRules []map[string]interface{}
+	for _, r := range s.matchedRules {
+		matchedRules = append(matchedRules, map[string]interface{}{
+			"path":  r.path,
+			"set":   r.setID,
+			"id":    r.id,
+			"label": r.label,
+		})
+	}
+
+	event, err := json.Marshal(s.event)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	route := s.route
+	if s.dropped {
+		route = ""
+	}
+
+	d.SetId(oid)
+	d.Set("matched_rules", matchedRules)
+	d.Set("route", route)
+	d.Set("severity", s.severity)
+	d.Set("priority", s.priority)
+	d.Set("event_action", s.eventAction)
+	d.Set("suppressed", s.suppressed)
+	d.Set("dropped", s.dropped)
+	d.Set("variables", s.variables)
+	d.Set("resulting_event", string(event))
+	d.Set("warnings", s.warnings)
+
+	var diags diag.Diagnostics
+	for _, w := range s.warnings {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  "Incomplete Event Orchestration simulation",
+			Detail:   w,
+		})
+	}
+	return diags
+}
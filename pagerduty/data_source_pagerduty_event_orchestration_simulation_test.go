@@ -0,0 +1,158 @@
+package pagerduty
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/PagerDuty/terraform-provider-pagerduty/util/mockapi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/heimweh/go-pagerduty/pagerduty"
+)
+
+func TestAccDataSourcePagerDutyEventOrchestrationSimulation_Basic(t *testing.T) {
+	team := fmt.Sprintf("tf-team-%s", acctest.RandString(5))
+	escalationPolicy := fmt.Sprintf("tf-%s", acctest.RandString(5))
+	service := fmt.Sprintf("tf-%s", acctest.RandString(5))
+	orchestration := fmt.Sprintf("tf-orchestration-%s", acctest.RandString(5))
+	n := "data.pagerduty_event_orchestration_simulation.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourcePagerDutyEventOrchestrationSimulationConfig(team, escalationPolicy, service, orchestration, "Database is down"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(n, "route", "pagerduty_service.bar", "id"),
+					resource.TestCheckResourceAttr(n, "matched_rules.#", "2"),
+					resource.TestCheckResourceAttr(n, "matched_rules.0.path", "router"),
+					resource.TestCheckResourceAttr(n, "matched_rules.1.path", "service"),
+					resource.TestCheckResourceAttr(n, "matched_rules.1.label", "critical"),
+					resource.TestCheckResourceAttr(n, "severity", "critical"),
+					resource.TestCheckResourceAttr(n, "variables.host", "db-01"),
+					resource.TestCheckResourceAttr(n, "suppressed", "false"),
+				),
+			},
+			{
+				Config: testAccDataSourcePagerDutyEventOrchestrationSimulationConfig(team, escalationPolicy, service, orchestration, "Web server is down"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(n, "route", "unrouted"),
+					resource.TestCheckResourceAttr(n, "matched_rules.#", "0"),
+					resource.TestCheckResourceAttr(n, "severity", "warning"),
+				),
+			},
+			{
+				Config:      testAccDataSourcePagerDutyEventOrchestrationSimulationInvalidEventConfig(),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("contains an invalid JSON"),
+			},
+		},
+	})
+}
+
+func testAccDataSourcePagerDutyEventOrchestrationSimulationConfig(t, ep, s, o, summary string) string {
+	return fmt.Sprintf("%s%s", testAccCheckPagerDutyEventOrchestrationRouterConfigWithCondition(t, ep, s, o, "event.summary matches part 'Database'"),
+		fmt.Sprintf(`resource "pagerduty_event_orchestration_service" "service" {
+			service = pagerduty_service.bar.id
+			enable_event_orchestration_for_service = true
+
+			set {
+				id = "start"
+				rule {
+					label = "critical"
+					actions {
+						severity = "critical"
+						variable {
+							name  = "host"
+							path  = "event.custom_details.host"
+							type  = "regex"
+							value = ".*"
+						}
+					}
+				}
+			}
+			catch_all {
+				actions {}
+			}
+		}
+
+		data "pagerduty_event_orchestration_simulation" "test" {
+			event_orchestration = pagerduty_event_orchestration.orch.id
+			event = jsonencode({
+				payload = {
+					summary        = %q
+					source         = "db-01"
+					severity       = "warning"
+					custom_details = { host = "db-01" }
+				}
+			})
+
+			depends_on = [
+				pagerduty_event_orchestration_router.router,
+				pagerduty_event_orchestration_service.service,
+			]
+		}
+	`, summary))
+}
+
+func testAccDataSourcePagerDutyEventOrchestrationSimulationInvalidEventConfig() string {
+	return `
+data "pagerduty_event_orchestration_simulation" "test" {
+  event_orchestration = "PORCH"
+  event               = "{not json"
+}
+`
+}
+
+func TestDataSourcePagerDutyEventOrchestrationSimulationConfiguredPaths(t *testing.T) {
+	server := mockapi.NewServer()
+	defer server.Close()
+	meta := &Config{ApiUrl: server.URL, Token: "foo", SkipCredsValidation: true}
+	client, err := meta.Client()
+	if err != nil {
+		t.Fatal(err)
+	}
+	orchestration, _, err := client.EventOrchestrations.Create(&pagerduty.EventOrchestration{Name: "foo"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The router of the orchestration routes every event to `unrouted`, so
+	// the configured one is evaluated instead.
+	router := `{
+  "sets": [{"id": "start", "rules": [
+    {"id": "dynamic", "actions": {"dynamic_route_to": {"source": "event.custom_details.key", "regex": "(.*)", "lookup_by": "service_integration_key"}}},
+    {"id": "db", "conditions": [{"expression": "event.summary matches part 'db'"}], "actions": {"route_to": "PDB"}}
+  ]}],
+  "catch_all": {"actions": {"route_to": "unrouted"}}
+}`
+	service := `{"sets": [{"id": "start", "rules": []}], "catch_all": {"actions": {"priority": "PHIGH"}}}`
+
+	res := dataSourcePagerDutyEventOrchestrationSimulation()
+	d := schema.TestResourceDataRaw(t, res.Schema, map[string]interface{}{
+		"event_orchestration": orchestration.ID,
+		"event":               `{"payload": {"summary": "db-01 is down", "custom_details": {"key": "R0KEY"}}}`,
+		"router_path":         router,
+		"service_paths":       map[string]interface{}{"PDB": service},
+	})
+	diags := dataSourcePagerDutyEventOrchestrationSimulationRead(context.Background(), d, meta)
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	if route := d.Get("route").(string); route != "PDB" {
+		t.Errorf("expected the event to be routed by the configured router, got %q", route)
+	}
+	if priority := d.Get("priority").(string); priority != "PHIGH" {
+		t.Errorf("expected the configured service path to set the priority, got %q", priority)
+	}
+	if len(diags) != 1 || diags[0].Severity != diag.Warning || !strings.Contains(diags[0].Detail, "service_integration_key") {
+		t.Errorf("expected a warning about the lookup by integration key, got %v", diags)
+	}
+}
@@ -0,0 +1,236 @@
+package pagerduty
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/heimweh/go-pagerduty/pagerduty"
+)
+
+func dataSourcePagerDutyRulesetMigration() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourcePagerDutyRulesetMigrationRead,
+
+		Schema: map[string]*schema.Schema{
+			"ruleset": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ExactlyOneOf: []string{"ruleset", "service"},
+			},
+			"service": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"event_orchestration": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"service"},
+			},
+			"resource_name": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "migrated",
+				ValidateFunc: validation.StringMatch(hclIdentifier, "must be a valid Terraform resource name"),
+			},
+			"hcl": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"global_path": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"router_path": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"service_path": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"rules": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"source_rule_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"catch_all": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"label": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"conditions": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+						"disabled": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"route_to": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"fully_migrated": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+					},
+				},
+			},
+			"diagnostics": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"source_rule_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"message": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourcePagerDutyRulesetMigrationRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, err := meta.(*Config).Client()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	name := d.Get("resource_name").(string)
+
+	var m *rulesetMigration
+	var id, hcl string
+	if rulesetID, ok := d.GetOk("ruleset"); ok {
+		id = rulesetID.(string)
+		log.Printf("[INFO] Migrating the rules of PagerDuty ruleset %s", id)
+
+		var ruleset *pagerduty.Ruleset
+		var rules []*pagerduty.RulesetRule
+		err := retry.RetryContext(ctx, 2*time.Minute, func() *retry.RetryError {
+			var err error
+			ruleset, _, err = client.Rulesets.Get(id)
+			if err == nil {
+				var resp *pagerduty.ListRulesetRulesResponse
+				resp, _, err = client.Rulesets.ListRules(id)
+				if err == nil {
+					rules = resp.Rules
+				}
+			}
+			if err != nil {
+				if isErrCode(err, http.StatusBadRequest) || isErrCode(err, http.StatusNotFound) {
+					return retry.NonRetryableError(err)
+				}
+				return retry.RetryableError(err)
+			}
+			return nil
+		})
+		if err != nil {
+			return diag.FromErr(fmt.Errorf("Unable to read the rules of ruleset %s: %w", id, err))
+		}
+
+		m = migrateRulesetRules(rules)
+		orchestration := "pagerduty_event_orchestration." + name + ".id"
+		if v, ok := d.GetOk("event_orchestration"); ok {
+			orchestration = strconv.Quote(v.(string))
+		} else {
+			w := &hclWriter{}
+			w.open(fmt.Sprintf("resource %q %q", "pagerduty_event_orchestration", name))
+			w.attr("name", hclQuote(ruleset.Name))
+			w.close()
+			hcl = w.String() + "\n"
+		}
+		hcl += m.renderHCL(orchestration, "", name)
+	} else {
+		id = d.Get("service").(string)
+		log.Printf("[INFO] Migrating the event rules of PagerDuty service %s", id)
+
+		var rules []*pagerduty.ServiceEventRule
+		err := retry.RetryContext(ctx, 2*time.Minute, func() *retry.RetryError {
+			resp, _, err := client.Services.ListEventRules(id, &pagerduty.ListServiceEventRuleOptions{})
+			if err != nil {
+				if isErrCode(err, http.StatusBadRequest) || isErrCode(err, http.StatusNotFound) {
+					return retry.NonRetryableError(err)
+				}
+				return retry.RetryableError(err)
+			}
+			rules = resp.EventRules
+			return nil
+		})
+		if err != nil {
+			return diag.FromErr(fmt.Errorf("Unable to read the event rules of service %s: %w", id, err))
+		}
+
+		m = migrateServiceEventRules(rules)
+		hcl = m.renderHCL("", strconv.Quote(id), name)
+	}
+
+	var rules, notes []map[string]interface{}
+	var diags diag.Diagnostics
+	for _, r := range m.rules {
+		rule := map[string]interface{}{
+			"source_rule_id": r.sourceID,
+			"catch_all":      r.catchAll,
+			"fully_migrated": len(r.notes) == 0,
+		}
+		if r.rule != nil {
+			var conditions []string
+			for _, c := range r.rule.Conditions {
+				conditions = append(conditions, c.Expression)
+			}
+			rule["label"] = r.rule.Label
+			rule["conditions"] = conditions
+			rule["disabled"] = r.rule.Disabled
+		}
+		if r.routerRule != nil {
+			rule["route_to"] = r.routerRule.Actions.RouteTo
+		}
+		rules = append(rules, rule)
+
+		for _, note := range r.notes {
+			notes = append(notes, map[string]interface{}{
+				"source_rule_id": r.sourceID,
+				"message":        note,
+			})
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary:  fmt.Sprintf("Event rule %s can't be fully migrated", r.sourceID),
+				Detail:   fmt.Sprintf("Event rule %s: %s.", r.sourceID, note),
+			})
+		}
+	}
+
+	d.SetId(id)
+	d.Set("hcl", hcl)
+	d.Set("global_path", eventOrchestrationPathJSON(m.global))
+	d.Set("router_path", eventOrchestrationPathJSON(m.router))
+	d.Set("service_path", eventOrchestrationPathJSON(m.service))
+	d.Set("rules", rules)
+	d.Set("diagnostics", notes)
+
+	return diags
+}
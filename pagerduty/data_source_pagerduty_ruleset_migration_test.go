@@ -0,0 +1,88 @@
+package pagerduty
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccDataSourcePagerDutyRulesetMigration_Basic(t *testing.T) {
+	team := fmt.Sprintf("tf-%s", acctest.RandString(5))
+	ruleset := fmt.Sprintf("tf-%s", acctest.RandString(5))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourcePagerDutyRulesetMigrationConfig(team, ruleset),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(
+						"data.pagerduty_ruleset_migration.foo", "id", "pagerduty_ruleset.foo", "id"),
+					resource.TestCheckResourceAttr(
+						"data.pagerduty_ruleset_migration.foo", "rules.#", "2"),
+					resource.TestCheckResourceAttrPair(
+						"data.pagerduty_ruleset_migration.foo", "rules.0.source_rule_id", "pagerduty_ruleset_rule.foo", "id"),
+					resource.TestCheckResourceAttr(
+						"data.pagerduty_ruleset_migration.foo", "rules.0.conditions.0", "event.summary matches part 'disk space'"),
+					resource.TestCheckResourceAttr(
+						"data.pagerduty_ruleset_migration.foo", "rules.0.fully_migrated", "true"),
+					resource.TestCheckResourceAttr(
+						"data.pagerduty_ruleset_migration.foo", "rules.1.catch_all", "true"),
+					resource.TestCheckResourceAttr(
+						"data.pagerduty_ruleset_migration.foo", "router_path", ""),
+					resource.TestCheckResourceAttr(
+						"data.pagerduty_ruleset_migration.foo", "diagnostics.#", "0"),
+					resource.TestMatchResourceAttr(
+						"data.pagerduty_ruleset_migration.foo", "hcl", regexp.MustCompile(`resource "pagerduty_event_orchestration_global" "migrated"`)),
+					resource.TestMatchResourceAttr(
+						"data.pagerduty_ruleset_migration.foo", "global_path", regexp.MustCompile(`"severity":"critical"`)),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourcePagerDutyRulesetMigrationConfig(team, ruleset string) string {
+	return fmt.Sprintf(`
+resource "pagerduty_team" "foo" {
+  name = "%s"
+}
+
+resource "pagerduty_ruleset" "foo" {
+  name = "%s"
+  team {
+    id = pagerduty_team.foo.id
+  }
+}
+
+resource "pagerduty_ruleset_rule" "foo" {
+  ruleset  = pagerduty_ruleset.foo.id
+  position = 0
+  conditions {
+    operator = "and"
+    subconditions {
+      operator = "contains"
+      parameter {
+        value = "disk space"
+        path  = "payload.summary"
+      }
+    }
+  }
+  actions {
+    severity {
+      value = "critical"
+    }
+  }
+}
+
+data "pagerduty_ruleset_migration" "foo" {
+  ruleset = pagerduty_ruleset.foo.id
+
+  depends_on = [pagerduty_ruleset_rule.foo]
+}
+`, team, ruleset)
+}
@@ -0,0 +1,143 @@
+package pagerduty
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+type webhookEventType struct {
+	name        string
+	description string
+}
+
+// webhookEventTypes is the catalogue of the event types V3 Webhooks can
+// subscribe to. PagerDuty doesn't offer a way to list them, so it may lag
+// behind the event types PagerDuty adds.
+var webhookEventTypes = []webhookEventType{
+	{"incident.acknowledged", "Sent when an incident is acknowledged."},
+	{"incident.annotated", "Sent when a note is added to an incident."},
+	{"incident.conference_bridge.updated", "Sent when the conference bridge of an incident is updated."},
+	{"incident.custom_field_values.updated", "Sent when the custom field values of an incident are updated."},
+	{"incident.delegated", "Sent when an incident is reassigned to another escalation policy."},
+	{"incident.escalated", "Sent when an incident is escalated within its escalation policy."},
+	{"incident.incident_type.changed", "Sent when the incident type of an incident is changed."},
+	{"incident.priority_updated", "Sent when the priority of an incident is changed."},
+	{"incident.reassigned", "Sent when an incident is reassigned to other users."},
+	{"incident.reopened", "Sent when an incident is reopened."},
+	{"incident.resolved", "Sent when an incident is resolved."},
+	{"incident.responder.added", "Sent when a responder is added to an incident."},
+	{"incident.responder.replied", "Sent when a responder replies to a request to respond to an incident."},
+	{"incident.service_updated", "Sent when an incident is moved to another service."},
+	{"incident.status_update_published", "Sent when a status update is added to an incident."},
+	{"incident.triggered", "Sent when an incident is newly created or triggered."},
+	{"incident.unacknowledged", "Sent when an incident is unacknowledged."},
+	{"incident.workflow.completed", "Sent when an incident workflow completes."},
+	{"incident.workflow.started", "Sent when an incident workflow starts."},
+	{"pagey.ping", "Sent when a test event is requested for the webhook subscription."},
+	{"service.created", "Sent when a service is created."},
+	{"service.deleted", "Sent when a service is deleted."},
+	{"service.updated", "Sent when a service is updated."},
+}
+
+func webhookEventTypeNames() []string {
+	names := make([]string, len(webhookEventTypes))
+	for i, t := range webhookEventTypes {
+		names[i] = t.name
+	}
+	return names
+}
+
+// validateWebhookEventType warns about the event types missing from
+// `webhookEventTypes`, which may be misspelt or only be unknown to this
+// provider.
+func validateWebhookEventType() schema.SchemaValidateDiagFunc {
+	return func(v interface{}, p cty.Path) diag.Diagnostics {
+		name := v.(string)
+		for _, t := range webhookEventTypes {
+			if t.name == name {
+				return nil
+			}
+		}
+		return diag.Diagnostics{{
+			Severity:      diag.Warning,
+			Summary:       fmt.Sprintf("Unknown webhook event type %q", name),
+			Detail:        fmt.Sprintf("The known event types are %s. PagerDuty rejects the subscription if it doesn't know about this event type either.", strings.Join(webhookEventTypeNames(), ", ")),
+			AttributePath: p,
+		}}
+	}
+}
+
+func dataSourcePagerDutyWebhookEventTypes() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourcePagerDutyWebhookEventTypesRead,
+
+		Schema: map[string]*schema.Schema{
+			"category": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateDiagFunc: validateValueDiagFunc([]string{"incident", "pagey", "service"}),
+			},
+			"names": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"event_types": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"category": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"description": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourcePagerDutyWebhookEventTypesRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	category := d.Get("category").(string)
+
+	var names []string
+	var eventTypes []map[string]interface{}
+	for _, t := range webhookEventTypes {
+		c := strings.SplitN(t.name, ".", 2)[0]
+		if category != "" && c != category {
+			continue
+		}
+		names = append(names, t.name)
+		eventTypes = append(eventTypes, map[string]interface{}{
+			"name":        t.name,
+			"category":    c,
+			"description": t.description,
+		})
+	}
+
+	if category == "" {
+		d.SetId("all")
+	} else {
+		d.SetId(category)
+	}
+	d.Set("names", names)
+	d.Set("event_types", eventTypes)
+
+	return nil
+}
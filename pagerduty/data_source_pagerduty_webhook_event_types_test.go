@@ -0,0 +1,38 @@
+package pagerduty
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccDataSourcePagerDutyWebhookEventTypes_Basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourcePagerDutyWebhookEventTypesConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"data.pagerduty_webhook_event_types.all", "names.#", fmt.Sprint(len(webhookEventTypes))),
+					resource.TestCheckResourceAttr(
+						"data.pagerduty_webhook_event_types.service", "names.#", "3"),
+					resource.TestCheckResourceAttr(
+						"data.pagerduty_webhook_event_types.service", "event_types.0.name", "service.created"),
+					resource.TestCheckResourceAttr(
+						"data.pagerduty_webhook_event_types.service", "event_types.0.category", "service"),
+				),
+			},
+		},
+	})
+}
+
+const testAccDataSourcePagerDutyWebhookEventTypesConfig = `
+data "pagerduty_webhook_event_types" "all" {}
+
+data "pagerduty_webhook_event_types" "service" {
+  category = "service"
+}
+`
@@ -0,0 +1,80 @@
+package pagerduty
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// webhookSignatureVersion prefixes the signatures of V3 Webhooks in the
+// X-PagerDuty-Signature header.
+const webhookSignatureVersion = "v1="
+
+func dataSourcePagerDutyWebhookSignature() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourcePagerDutyWebhookSignatureRead,
+
+		Schema: map[string]*schema.Schema{
+			"secret": {
+				Type:      schema.TypeString,
+				Required:  true,
+				Sensitive: true,
+			},
+			"payload": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"verify": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"signature": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"valid": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourcePagerDutyWebhookSignatureRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	secret := d.Get("secret").(string)
+	payload := d.Get("payload").(string)
+
+	signature := webhookSignature(secret, payload)
+
+	d.SetId(signature)
+	d.Set("signature", signature)
+	d.Set("valid", verifyWebhookSignature(secret, payload, d.Get("verify").(string)))
+
+	return nil
+}
+
+// webhookSignature returns the signature of `payload` as PagerDuty sets it
+// in the X-PagerDuty-Signature header of webhooks signed with `secret`.
+func webhookSignature(secret, payload string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(payload))
+	return webhookSignatureVersion + hex.EncodeToString(mac.Sum(nil))
+}
+
+// verifyWebhookSignature returns whether one of the comma separated
+// signatures of `header` is the signature of `payload` with `secret`.
+// PagerDuty sends several signatures while a secret is being rotated.
+func verifyWebhookSignature(secret, payload, header string) bool {
+	expected := []byte(webhookSignature(secret, payload))
+	for _, signature := range strings.Split(header, ",") {
+		if hmac.Equal([]byte(strings.TrimSpace(signature)), expected) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,73 @@
+package pagerduty
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestWebhookSignature(t *testing.T) {
+	secret := "key"
+	payload := "The quick brown fox jumps over the lazy dog"
+	expected := "v1=f7bc83f430538424b13298e6aa6fb143ef4d59a14946175997479dbc2d1a3cd8"
+
+	if signature := webhookSignature(secret, payload); signature != expected {
+		t.Fatalf("Expected signature %q, got %q", expected, signature)
+	}
+
+	cases := []struct {
+		header string
+		valid  bool
+	}{
+		{expected, true},
+		{"v1=0000, " + expected, true},
+		{expected + ",v1=0000", true},
+		{"v1=0000", false},
+		{"", false},
+		{"f7bc83f430538424b13298e6aa6fb143ef4d59a14946175997479dbc2d1a3cd8", false},
+	}
+	for _, c := range cases {
+		if valid := verifyWebhookSignature(secret, payload, c.header); valid != c.valid {
+			t.Errorf("Expected header %q to be valid: %t, got %t", c.header, c.valid, valid)
+		}
+	}
+}
+
+func TestAccDataSourcePagerDutyWebhookSignature_Basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourcePagerDutyWebhookSignatureConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"data.pagerduty_webhook_signature.sign", "signature", "v1=f7bc83f430538424b13298e6aa6fb143ef4d59a14946175997479dbc2d1a3cd8"),
+					resource.TestCheckResourceAttr(
+						"data.pagerduty_webhook_signature.verify", "valid", "true"),
+					resource.TestCheckResourceAttr(
+						"data.pagerduty_webhook_signature.tampered", "valid", "false"),
+				),
+			},
+		},
+	})
+}
+
+const testAccDataSourcePagerDutyWebhookSignatureConfig = `
+data "pagerduty_webhook_signature" "sign" {
+  secret  = "key"
+  payload = "The quick brown fox jumps over the lazy dog"
+}
+
+data "pagerduty_webhook_signature" "verify" {
+  secret  = "key"
+  payload = "The quick brown fox jumps over the lazy dog"
+  verify  = "v1=0000,${data.pagerduty_webhook_signature.sign.signature}"
+}
+
+data "pagerduty_webhook_signature" "tampered" {
+  secret  = "key"
+  payload = "The quick brown fox jumps over the lazy cat"
+  verify  = data.pagerduty_webhook_signature.sign.signature
+}
+`
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"log"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -137,6 +138,142 @@ func fetchPagerDutyEventOrchestrationCacheVariable(ctx context.Context, d *schem
 	return nil, fmt.Errorf("Reading Cache Variable '%s' for PagerDuty Event Orchestration '%s' returned `nil`.", id, oid)
 }
 
+// eventOrchestrationCacheVariableReferences returns the names of the cache
+// variables the PCL condition `expression` refers to, as `cache_var.<name>`.
+func eventOrchestrationCacheVariableReferences(expression string) []string {
+	tokens, err := lexPCL(expression)
+	if err != nil {
+		return nil
+	}
+
+	var names []string
+	for _, t := range tokens {
+		if t.kind != pclWord {
+			continue
+		}
+		segments := splitPCLFieldPath(t.text)
+		if len(segments) < 2 || segments[0] != pclCacheVariableNamespace {
+			continue
+		}
+		names = append(names, strings.Trim(segments[1], `[]'"`))
+	}
+	return names
+}
+
+// eventOrchestrationPathConditionExpressions returns the expressions of the
+// conditions of the rules of `sets`, the value of the `set` attribute of an
+// Event Orchestration path.
+func eventOrchestrationPathConditionExpressions(sets interface{}) []string {
+	var expressions []string
+	for _, set := range sets.([]interface{}) {
+		if set == nil {
+			continue
+		}
+		for _, rule := range set.(map[string]interface{})["rule"].([]interface{}) {
+			if rule == nil {
+				continue
+			}
+			expressions = append(expressions, eventOrchestrationConditionExpressions(rule.(map[string]interface{})["condition"])...)
+		}
+	}
+	return expressions
+}
+
+// eventOrchestrationConditionExpressions returns the expressions of the
+// `condition` blocks `conditions`.
+func eventOrchestrationConditionExpressions(conditions interface{}) []string {
+	var expressions []string
+	for _, c := range conditions.([]interface{}) {
+		if c == nil {
+			continue
+		}
+		if e := c.(map[string]interface{})["expression"].(string); e != "" {
+			expressions = append(expressions, e)
+		}
+	}
+	return expressions
+}
+
+// missingEventOrchestrationCacheVariables returns a message for each
+// reference in `expressions` to a cache variable which doesn't exist on the
+// Event Orchestration, or the service, `oid`. The cache variables are only
+// listed when some expression refers to them.
+func missingEventOrchestrationCacheVariables(ctx context.Context, client *pagerduty.Client, cacheVariableType, oid string, expressions []string) ([]string, error) {
+	references := map[string][]string{}
+	var names []string
+	for _, e := range expressions {
+		for _, name := range eventOrchestrationCacheVariableReferences(e) {
+			if _, ok := references[name]; !ok {
+				names = append(names, name)
+			}
+			references[name] = append(references[name], e)
+		}
+	}
+	if len(names) == 0 {
+		return nil, nil
+	}
+
+	var cacheVariables []*pagerduty.EventOrchestrationCacheVariable
+	retryErr := retry.RetryContext(ctx, 2*time.Minute, func() *retry.RetryError {
+		resp, _, err := client.EventOrchestrationCacheVariables.List(ctx, cacheVariableType, oid)
+		if err != nil {
+			if isErrCode(err, http.StatusBadRequest) || isErrCode(err, http.StatusNotFound) || isErrCode(err, http.StatusForbidden) {
+				return retry.NonRetryableError(err)
+			}
+			return retry.RetryableError(err)
+		}
+		cacheVariables = resp.CacheVariables
+		return nil
+	})
+	if retryErr != nil {
+		return nil, retryErr
+	}
+
+	existing := map[string]bool{}
+	for _, cv := range cacheVariables {
+		existing[cv.Name] = true
+	}
+
+	var messages []string
+	for _, name := range names {
+		if existing[name] {
+			continue
+		}
+		for _, e := range references[name] {
+			messages = append(messages, fmt.Sprintf("Condition %q refers to the cache variable %q, which doesn't exist on %s %s", e, name, eventOrchestrationCacheVariableParent(cacheVariableType), oid))
+		}
+	}
+	return messages, nil
+}
+
+func eventOrchestrationCacheVariableParent(cacheVariableType string) string {
+	if cacheVariableType == pagerduty.CacheVariableTypeService {
+		return "service"
+	}
+	return "Event Orchestration"
+}
+
+// cacheVariableReferenceDiagnostics returns warnings about the conditions in
+// `expressions` referring to cache variables which don't exist. It's called
+// when the conditions are applied, and only when they changed, as it lists the
+// cache variables.
+func cacheVariableReferenceDiagnostics(ctx context.Context, client *pagerduty.Client, cacheVariableType, oid string, expressions []string) diag.Diagnostics {
+	var diags diag.Diagnostics
+	messages, err := missingEventOrchestrationCacheVariables(ctx, client, cacheVariableType, oid, expressions)
+	if err != nil {
+		log.Printf("[WARN] Unable to check the cache variables referred to by the conditions of %s: %s", oid, err)
+		return nil
+	}
+	for _, m := range messages {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  "Unknown cache variable",
+			Detail:   m,
+		})
+	}
+	return diags
+}
+
 /*
 ****
 
@@ -494,3 +631,76 @@ func getEventOrchestrationCacheVariableByName(ctx context.Context, d *schema.Res
 
 	return diags
 }
+
+var dataSourceEventOrchestrationCacheVariablesSchema = map[string]*schema.Schema{
+	"id": {
+		Type:     schema.TypeString,
+		Computed: true,
+	},
+	"name": {
+		Type:     schema.TypeString,
+		Computed: true,
+	},
+	"condition": {
+		Type:     schema.TypeList,
+		Computed: true,
+		Elem: &schema.Resource{
+			Schema: dataSourceEventOrchestrationCacheVariableConditionSchema,
+		},
+	},
+	"configuration": {
+		Type:     schema.TypeList,
+		Computed: true,
+		Elem: &schema.Resource{
+			Schema: dataSourceEventOrchestrationCacheVariableConfigurationSchema,
+		},
+	},
+	"disabled": {
+		Type:     schema.TypeBool,
+		Computed: true,
+	},
+}
+
+func dataSourceEventOrchestrationCacheVariablesRead(ctx context.Context, d *schema.ResourceData, meta interface{}, cacheVariableType string) diag.Diagnostics {
+	client, err := meta.(*Config).Client()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	oid := d.Get(getIdentifier(cacheVariableType)).(string)
+
+	retryErr := retry.RetryContext(ctx, 2*time.Minute, func() *retry.RetryError {
+		log.Printf("[INFO] Listing Cache Variables for PagerDuty Event Orchestration '%s'", oid)
+
+		resp, _, err := client.EventOrchestrationCacheVariables.List(ctx, cacheVariableType, oid)
+		if err != nil {
+			if isErrCode(err, http.StatusBadRequest) || isErrCode(err, http.StatusNotFound) {
+				return retry.NonRetryableError(err)
+			}
+
+			return retry.RetryableError(err)
+		}
+
+		var cacheVariables []map[string]interface{}
+		for _, cv := range resp.CacheVariables {
+			cacheVariables = append(cacheVariables, map[string]interface{}{
+				"id":            cv.ID,
+				"name":          cv.Name,
+				"condition":     flattenEventOrchestrationCacheVariableConditions(cv.Conditions),
+				"configuration": flattenEventOrchestrationCacheVariableConfiguration(cv.Configuration),
+				"disabled":      cv.Disabled,
+			})
+		}
+
+		d.SetId(oid)
+		d.Set("cache_variables", cacheVariables)
+
+		return nil
+	})
+
+	if retryErr != nil {
+		return diag.FromErr(fmt.Errorf("Unable to list the Cache Variables of PagerDuty Event Orchestration '%s': %w", oid, retryErr))
+	}
+
+	return nil
+}
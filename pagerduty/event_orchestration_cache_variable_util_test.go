@@ -0,0 +1,137 @@
+package pagerduty
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/PagerDuty/terraform-provider-pagerduty/util/mockapi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	sdkterraform "github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/heimweh/go-pagerduty/pagerduty"
+)
+
+func TestEventOrchestrationCacheVariableReferences(t *testing.T) {
+	cases := map[string][]string{
+		`cache_var.count > 5`: {"count"},
+		`cache_var.count > 5 and cache_var['last host'] exists`:        {"count", "last host"},
+		`cache_variables.host matches 'db-01'`:                         nil,
+		`event.summary matches part 'cache_var.count'`:                 nil,
+		`variables.count > 5 or event.custom_details.cache_var exists`: nil,
+	}
+	for expression, want := range cases {
+		if got := eventOrchestrationCacheVariableReferences(expression); !reflect.DeepEqual(got, want) {
+			t.Errorf("%s: got %v, want %v", expression, got, want)
+		}
+	}
+}
+
+// The cache variables referred to are the field paths the condition
+// validation knows of.
+func TestEventOrchestrationCacheVariableReferencesAreKnownFields(t *testing.T) {
+	for _, expression := range []string{
+		`cache_var.count > 5`,
+		`cache_var['last host'] exists`,
+		`cache_variables.host matches 'db-01'`,
+	} {
+		condition, err := parsePCLCondition(expression)
+		if err != nil {
+			t.Fatalf("%q: unexpected error: %s", expression, err)
+		}
+		referenced := len(eventOrchestrationCacheVariableReferences(expression)) > 0
+		known := len(condition.unknownFields()) == 0
+		if referenced != known {
+			t.Errorf("%q: refers to a cache variable: %t, known field path: %t", expression, referenced, known)
+		}
+	}
+}
+
+func TestMissingEventOrchestrationCacheVariables(t *testing.T) {
+	server := mockapi.NewServer()
+	defer server.Close()
+	ctx := context.Background()
+	config := &Config{ApiUrl: server.URL, Token: "foo", SkipCredsValidation: true}
+	client, err := config.Client()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	orchestration, _, err := client.EventOrchestrations.Create(&pagerduty.EventOrchestration{Name: "foo"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _, err = client.EventOrchestrationCacheVariables.Create(ctx, pagerduty.CacheVariableTypeGlobal, orchestration.ID, &pagerduty.EventOrchestrationCacheVariable{Name: "count"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The orchestration doesn't exist, so listing its cache variables would
+	// fail.
+	messages, err := missingEventOrchestrationCacheVariables(ctx, client, pagerduty.CacheVariableTypeGlobal, "PMISSING", []string{
+		`event.summary exists`,
+	})
+	if err != nil || len(messages) != 0 {
+		t.Errorf("expected no request without references to cache variables; got %v, %v", messages, err)
+	}
+
+	messages, err = missingEventOrchestrationCacheVariables(ctx, client, pagerduty.CacheVariableTypeGlobal, orchestration.ID, []string{
+		`cache_var.count > 5`,
+		`cache_var.hosts matches part 'db'`,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(messages) != 1 || !strings.Contains(messages[0], `"hosts"`) || !strings.Contains(messages[0], "Event Orchestration "+orchestration.ID) {
+		t.Errorf("expected a message about the hosts cache variable; got %v", messages)
+	}
+}
+
+func TestGlobalRuleCacheVariableReferenceDiagnostics(t *testing.T) {
+	server := mockapi.NewServer()
+	defer server.Close()
+	ctx := context.Background()
+	meta := &Config{ApiUrl: server.URL, Token: "foo", SkipCredsValidation: true}
+	client, err := meta.Client()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	orchestration, _, err := client.EventOrchestrations.Create(&pagerduty.EventOrchestration{Name: "foo"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	res := resourcePagerDutyEventOrchestrationPathGlobalRule()
+	apply := func(state *sdkterraform.InstanceState, label string) (*sdkterraform.InstanceState, diag.Diagnostics) {
+		raw := map[string]interface{}{
+			"event_orchestration": orchestration.ID,
+			"label":               label,
+			"condition":           []interface{}{map[string]interface{}{"expression": "cache_var.hosts matches part 'db'"}},
+			"actions":             []interface{}{map[string]interface{}{"priority": "PPRIORITY"}},
+		}
+		if state == nil {
+			state = &sdkterraform.InstanceState{RawConfig: testRawConfig(res, nil)}
+		}
+		diff, err := res.Diff(ctx, state, sdkterraform.NewResourceConfigRaw(raw), meta)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return res.Apply(ctx, state, diff, meta)
+	}
+
+	state, diags := apply(nil, "foo")
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if len(diags) != 1 || diags[0].Severity != diag.Warning || !strings.Contains(diags[0].Detail, `"hosts"`) {
+		t.Errorf("expected a warning about the hosts cache variable; got %v", diags)
+	}
+
+	// The conditions didn't change, so they aren't checked again.
+	state.RawConfig = testRawConfig(res, nil)
+	_, diags = apply(state, "bar")
+	if len(diags) != 0 {
+		t.Errorf("expected no diagnostics when the conditions didn't change; got %v", diags)
+	}
+}
@@ -0,0 +1,181 @@
+package pagerduty
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// exportedResource is a resource of an exported Event Orchestration, along
+// with its state as its Read function would set it.
+type exportedResource struct {
+	resourceType string
+	name         string
+	importID     string
+	resource     *schema.Resource
+	data         *schema.ResourceData
+	// references are the expressions set instead of the values of some
+	// top-level attributes, to refer to other exported resources.
+	references map[string]string
+}
+
+func (r *exportedResource) address() string {
+	return r.resourceType + "." + r.name
+}
+
+var nonIdentifierChars = regexp.MustCompile(`[^a-z0-9_]+`)
+
+// exportResourceNames hands out unique names of resources of a same type.
+type exportResourceNames map[string]bool
+
+// name returns a resource name based on `s`, unique among the names
+// returned for `resourceType`.
+func (n exportResourceNames) name(resourceType, s string) string {
+	base := strings.Trim(nonIdentifierChars.ReplaceAllString(strings.ToLower(s), "_"), "_")
+	if base == "" {
+		base = "resource"
+	}
+	if base[0] >= '0' && base[0] <= '9' {
+		base = "_" + base
+	}
+
+	name := base
+	for i := 2; n[resourceType+"."+name]; i++ {
+		name = fmt.Sprintf("%s_%d", base, i)
+	}
+	n[resourceType+"."+name] = true
+	return name
+}
+
+// renderExportedResourcesHCL returns the configuration of `resources`,
+// followed by the `import` blocks importing them.
+func renderExportedResourcesHCL(resources []*exportedResource) string {
+	w := &hclWriter{}
+	for i, r := range resources {
+		if i > 0 {
+			w.line("")
+		}
+		w.open(fmt.Sprintf("resource %q %q", r.resourceType, r.name))
+		writeSchemaHCL(w, r.resource.Schema, func(k string) interface{} { return r.data.Get(k) }, r.references)
+		w.close()
+	}
+	for _, r := range resources {
+		w.line("")
+		w.open("import")
+		w.attr("to", r.address())
+		w.attr("id", strconv.Quote(r.importID))
+		w.close()
+	}
+	return w.String()
+}
+
+// writeSchemaHCL writes the attributes and blocks of `s` which can be
+// configured, reading their values with `get`. Optional attributes set to
+// their default, or to their zero value, are left out, as are deprecated
+// ones.
+func writeSchemaHCL(w *hclWriter, s map[string]*schema.Schema, get func(string) interface{}, references map[string]string) {
+	var attrs, blocks []string
+	for k, v := range s {
+		if !(v.Optional || v.Required) || v.Deprecated != "" {
+			continue
+		}
+		if _, ok := v.Elem.(*schema.Resource); ok && (v.Type == schema.TypeList || v.Type == schema.TypeSet) {
+			blocks = append(blocks, k)
+		} else {
+			attrs = append(attrs, k)
+		}
+	}
+	sort.Strings(attrs)
+	sort.Strings(blocks)
+
+	for _, k := range attrs {
+		if ref, ok := references[k]; ok {
+			w.attr(k, ref)
+			continue
+		}
+		v := s[k]
+		value := get(k)
+		if !v.Required && isDefaultSchemaValue(v, value) {
+			continue
+		}
+		w.attr(k, hclValue(value))
+	}
+
+	for _, k := range blocks {
+		v := s[k]
+		elem := v.Elem.(*schema.Resource)
+		items := get(k)
+		if set, ok := items.(*schema.Set); ok {
+			items = set.List()
+		}
+		for _, item := range items.([]interface{}) {
+			m, _ := item.(map[string]interface{})
+			inner := &hclWriter{depth: w.depth + 1}
+			writeSchemaHCL(inner, elem.Schema, func(k string) interface{} { return m[k] }, nil)
+			if inner.Len() == 0 && !v.Required {
+				continue
+			}
+			w.open(k)
+			w.WriteString(inner.String())
+			w.close()
+		}
+	}
+}
+
+func isDefaultSchemaValue(s *schema.Schema, value interface{}) bool {
+	if s.Default != nil {
+		return reflect.DeepEqual(s.Default, value)
+	}
+	if value == nil {
+		return true
+	}
+	switch v := value.(type) {
+	case []interface{}:
+		return len(v) == 0
+	case map[string]interface{}:
+		return len(v) == 0
+	case *schema.Set:
+		return v.Len() == 0
+	}
+	return reflect.ValueOf(value).IsZero()
+}
+
+// hclValue returns the Terraform expression of a primitive value, or of a
+// list or map of primitive values.
+func hclValue(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return hclQuote(v)
+	case bool:
+		return strconv.FormatBool(v)
+	case int:
+		return strconv.Itoa(v)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case *schema.Set:
+		return hclValue(v.List())
+	case []interface{}:
+		items := make([]string, len(v))
+		for i, item := range v {
+			items[i] = hclValue(item)
+		}
+		return "[" + strings.Join(items, ", ") + "]"
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		items := make([]string, len(keys))
+		for i, k := range keys {
+			items[i] = fmt.Sprintf("%s = %s", strconv.Quote(k), hclValue(v[k]))
+		}
+		return "{ " + strings.Join(items, ", ") + " }"
+	}
+	return hclQuote(fmt.Sprint(value))
+}
@@ -0,0 +1,119 @@
+package pagerduty
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/heimweh/go-pagerduty/pagerduty"
+)
+
+func TestExportResourceNames(t *testing.T) {
+	names := exportResourceNames{}
+	cases := []struct {
+		resourceType, s, want string
+	}{
+		{"pagerduty_event_orchestration", "My Orchestration!", "my_orchestration"},
+		{"pagerduty_event_orchestration", "my-orchestration", "my_orchestration_2"},
+		{"pagerduty_event_orchestration_router", "my orchestration", "my_orchestration"},
+		{"pagerduty_event_orchestration", "42 services", "_42_services"},
+		{"pagerduty_event_orchestration", "???", "resource"},
+	}
+	for _, c := range cases {
+		if got := names.name(c.resourceType, c.s); got != c.want {
+			t.Errorf("%s %q: got %q, want %q", c.resourceType, c.s, got, c.want)
+		}
+	}
+}
+
+func TestRenderExportedResourcesHCL(t *testing.T) {
+	orchestration := resourcePagerDutyEventOrchestration()
+	orchestrationData := orchestration.Data(nil)
+	setEventOrchestrationProps(orchestrationData, &pagerduty.EventOrchestration{
+		Name:        "Main",
+		Description: `Events for "${env}"`,
+		Routes:      3,
+		Integrations: []*pagerduty.EventOrchestrationIntegration{
+			{ID: "I1", Label: "Default", Parameters: &pagerduty.EventOrchestrationIntegrationParameters{RoutingKey: "R1", Type: "global"}},
+		},
+	})
+
+	global := resourcePagerDutyEventOrchestrationPathGlobal()
+	globalData := global.Data(nil)
+	setEventOrchestrationPathGlobalProps(globalData, &pagerduty.EventOrchestrationPath{
+		Parent: &pagerduty.EventOrchestrationPathReference{ID: "E1"},
+		Sets: []*pagerduty.EventOrchestrationPathSet{{
+			ID: "start",
+			Rules: []*pagerduty.EventOrchestrationPathRule{{
+				ID:         "R1",
+				Label:      "Disk alerts",
+				Conditions: []*pagerduty.EventOrchestrationPathRuleCondition{{Expression: "event.summary matches part 'disk'"}},
+				Actions: &pagerduty.EventOrchestrationPathRuleActions{
+					Severity: "critical",
+					Variables: []*pagerduty.EventOrchestrationPathActionVariables{
+						{Name: "host", Path: "event.summary", Type: "regex", Value: `on (\S+)`},
+					},
+				},
+			}},
+		}},
+		CatchAll: &pagerduty.EventOrchestrationPathCatchAll{Actions: &pagerduty.EventOrchestrationPathRuleActions{}},
+	})
+
+	config := renderExportedResourcesHCL([]*exportedResource{
+		{resourceType: "pagerduty_event_orchestration", name: "main", importID: "E1", resource: orchestration, data: orchestrationData},
+		{
+			resourceType: "pagerduty_event_orchestration_global", name: "main", importID: "E1", resource: global, data: globalData,
+			references: map[string]string{"event_orchestration": "pagerduty_event_orchestration.main.id"},
+		},
+	})
+
+	file, diags := hclsyntax.ParseConfig([]byte(config), "export.tf", hcl.InitialPos)
+	if diags.HasErrors() {
+		t.Fatalf("invalid configuration: %s\n%s", diags, config)
+	}
+	blocks := file.Body.(*hclsyntax.Body).Blocks
+	if len(blocks) != 4 || blocks[2].Type != "import" || blocks[3].Type != "import" {
+		t.Fatalf("expected 2 resources followed by 2 import blocks:\n%s", config)
+	}
+
+	for _, want := range []string{
+		`name = "Main"`,
+		`description = "Events for \"$${env}\""`,
+		`event_orchestration = pagerduty_event_orchestration.main.id`,
+		`expression = "event.summary matches part 'disk'"`,
+		`value = "on (\\S+)"`,
+		`severity = "critical"`,
+		"catch_all {\n    actions {\n    }\n  }",
+		`to = pagerduty_event_orchestration_global.main`,
+	} {
+		if !strings.Contains(config, want) {
+			t.Errorf("expected the configuration to contain %q:\n%s", want, config)
+		}
+	}
+	// Computed attributes, and optional ones left unset, are left out.
+	for _, unwanted := range []string{"routes", "integration", "R1", "suppress", "team"} {
+		if strings.Contains(config, unwanted) {
+			t.Errorf("expected the configuration not to contain %q:\n%s", unwanted, config)
+		}
+	}
+}
+
+func TestEventOrchestrationExportServices(t *testing.T) {
+	router := &pagerduty.EventOrchestrationPath{
+		Sets: []*pagerduty.EventOrchestrationPathSet{{
+			ID: "start",
+			Rules: []*pagerduty.EventOrchestrationPathRule{
+				{Actions: &pagerduty.EventOrchestrationPathRuleActions{DynamicRouteTo: &pagerduty.EventOrchestrationPathDynamicRouteTo{LookupBy: "service_id"}}},
+				{Actions: &pagerduty.EventOrchestrationPathRuleActions{RouteTo: "PB"}},
+				{Actions: &pagerduty.EventOrchestrationPathRuleActions{RouteTo: "PA"}},
+			},
+		}},
+		CatchAll: &pagerduty.EventOrchestrationPathCatchAll{Actions: &pagerduty.EventOrchestrationPathRuleActions{RouteTo: "unrouted"}},
+	}
+
+	got := eventOrchestrationExportServices(router, []interface{}{"PC", "PA"})
+	if strings.Join(got, ",") != "PA,PB,PC" {
+		t.Errorf("unexpected services %v", got)
+	}
+}
@@ -0,0 +1,626 @@
+package pagerduty
+
+import (
+	"fmt"
+	"net"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// validateEventOrchestrationPathCondition checks the syntax of the PCL
+// (PagerDuty Condition Language) expressions of the conditions of rules, so
+// mistakes are reported by `terraform validate` instead of when PagerDuty
+// rejects them. Fields of events unknown to the provider are only warned
+// about, as PagerDuty may support fields the provider doesn't know about yet.
+func validateEventOrchestrationPathCondition() schema.SchemaValidateDiagFunc {
+	return func(v interface{}, p cty.Path) diag.Diagnostics {
+		expression := v.(string)
+
+		c, err := parsePCLCondition(expression)
+		if err != nil {
+			return diag.Diagnostics{pclDiagnostic(diag.Error, "Invalid PCL condition", err, expression, p)}
+		}
+
+		var diags diag.Diagnostics
+		for _, w := range c.unknownFields() {
+			d := pclDiagnostic(diag.Warning, "Unknown field in PCL condition", w, expression, p)
+			d.Detail += "\n\nThe condition never matches if PagerDuty doesn't know about this field either."
+			diags = append(diags, d)
+		}
+		return diags
+	}
+}
+
+func pclDiagnostic(severity diag.Severity, summary string, err *pclError, expression string, p cty.Path) diag.Diagnostic {
+	at := "Error"
+	if severity == diag.Warning {
+		at = "Warning"
+	}
+	return diag.Diagnostic{
+		Severity: severity,
+		Summary:  fmt.Sprintf("%s: %s", summary, err.Message),
+		Detail: fmt.Sprintf("%s at column %d of the condition:\n\n  %s\n  %s^",
+			at, err.Column, expression, strings.Repeat(" ", err.Column-1)),
+		AttributePath: p,
+	}
+}
+
+// pclError is a syntax error in a PCL condition, or an unknown field of one.
+// Column is the 1-based position of the character where the error was found.
+type pclError struct {
+	Column  int
+	Message string
+	// Field is the field path which caused the error, if any.
+	Field string
+}
+
+func (e *pclError) Error() string {
+	return fmt.Sprintf("column %d: %s", e.Column, e.Message)
+}
+
+// pclEventFields are the fields of events which can be used in conditions,
+// mapped to whether they have subfields.
+var pclEventFields = map[string]bool{
+	"summary":        false,
+	"source":         false,
+	"severity":       false,
+	"timestamp":      false,
+	"class":          false,
+	"component":      false,
+	"group":          false,
+	"event_action":   false,
+	"dedup_key":      false,
+	"client":         false,
+	"client_url":     false,
+	"custom_details": true,
+	"images":         true,
+	"links":          true,
+}
+
+// pclCacheVariableNamespace is the root of the field paths referring to a cache
+// variable.
+const pclCacheVariableNamespace = "cache_var"
+
+// pclNamespaces are the roots of field paths which must be followed by a
+// name.
+var pclNamespaces = []string{"event", "raw_event", "variables", pclCacheVariableNamespace}
+
+var pclWeekdays = map[string]time.Weekday{
+	"mon": time.Monday, "tue": time.Tuesday, "wed": time.Wednesday, "thu": time.Thursday,
+	"fri": time.Friday, "sat": time.Saturday, "sun": time.Sunday,
+}
+
+var pclTimeOfDay = regexp.MustCompile(`^([01]?[0-9]|2[0-3]):[0-5][0-9](:[0-5][0-9])?$`)
+
+type pclTokenKind int
+
+const (
+	pclWord pclTokenKind = iota
+	pclString
+	pclOperator
+	pclLeftParen
+	pclRightParen
+	pclEnd
+)
+
+type pclToken struct {
+	kind  pclTokenKind
+	text  string
+	value string // The unquoted value of strings
+	col   int
+}
+
+func (t pclToken) String() string {
+	if t.kind == pclEnd {
+		return "end of the condition"
+	}
+	return fmt.Sprintf("%q", t.text)
+}
+
+// is reports whether the token is the keyword `keyword`.
+func (t pclToken) is(keyword string) bool {
+	return t.kind == pclWord && strings.EqualFold(t.text, keyword)
+}
+
+// pclCondition is a parsed PCL condition. Conditions joined with `and` or
+// `or` are held in `left` and `right`, and negated ones in `left`.
+type pclCondition struct {
+	op          string
+	left, right *pclCondition
+
+	field    string
+	fieldCol int
+	value    string
+	regex    *regexp.Regexp
+	cidr     *net.IPNet
+	window   *pclTimeWindow
+}
+
+// pclTimeWindow is the window of time of a `now in` condition. Times of the
+// day are in seconds since midnight.
+type pclTimeWindow struct {
+	days       map[time.Weekday]bool
+	start, end int
+	location   string
+}
+
+// parsePCLCondition parses the PCL condition `expression`. It supports
+// conditions joined with `and` and `or`, negated with `not` and grouped with
+// parenthesis, and the operators `exists`, `matches`, `matches part`,
+// `matches regex`, `matches cidr`, comparisons and `in`.
+func parsePCLCondition(expression string) (*pclCondition, *pclError) {
+	tokens, err := lexPCL(expression)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &pclParser{tokens: tokens}
+	c, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if t := p.peek(); t.kind != pclEnd {
+		return nil, &pclError{Column: t.col, Message: fmt.Sprintf("expected \"and\" or \"or\", got %s", t)}
+	}
+	return c, nil
+}
+
+func lexPCL(expression string) ([]pclToken, *pclError) {
+	runes := []rune(expression)
+	var tokens []pclToken
+
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		col := i + 1
+
+		switch {
+		case unicode.IsSpace(r):
+			i++
+
+		case r == '(':
+			tokens = append(tokens, pclToken{kind: pclLeftParen, text: "(", col: col})
+			i++
+
+		case r == ')':
+			tokens = append(tokens, pclToken{kind: pclRightParen, text: ")", col: col})
+			i++
+
+		case r == '\'' || r == '"':
+			var value strings.Builder
+			j := i + 1
+			for ; j < len(runes) && runes[j] != r; j++ {
+				if runes[j] == '\\' && j+1 < len(runes) {
+					j++
+				}
+				value.WriteRune(runes[j])
+			}
+			if j == len(runes) {
+				return nil, &pclError{Column: col, Message: "unterminated string"}
+			}
+			tokens = append(tokens, pclToken{kind: pclString, text: string(runes[i : j+1]), value: value.String(), col: col})
+			i = j + 1
+
+		case strings.ContainsRune("<>=!", r):
+			op := string(r)
+			if i+1 < len(runes) && runes[i+1] == '=' {
+				op += "="
+			}
+			if op == "=" || op == "!" {
+				return nil, &pclError{Column: col, Message: fmt.Sprintf("unexpected %q, did you mean \"==\" or \"matches\"?", op)}
+			}
+			tokens = append(tokens, pclToken{kind: pclOperator, text: op, col: col})
+			i += len(op)
+
+		default:
+			j := i
+			for ; j < len(runes); j++ {
+				c := runes[j]
+				if unicode.IsSpace(c) || strings.ContainsRune("()'\"<>=!", c) {
+					break
+				}
+				// Brackets may contain quoted keys with any character.
+				if c == '[' {
+					end := pclClosingBracket(runes, j)
+					if end < 0 {
+						return nil, &pclError{Column: j + 1, Message: "unterminated \"[\""}
+					}
+					j = end
+				}
+			}
+			tokens = append(tokens, pclToken{kind: pclWord, text: string(runes[i:j]), col: col})
+			i = j
+		}
+	}
+
+	return append(tokens, pclToken{kind: pclEnd, col: len(runes) + 1}), nil
+}
+
+// pclClosingBracket returns the index of the bracket closing the one at
+// `start`, or -1 if there is none.
+func pclClosingBracket(runes []rune, start int) int {
+	var quote rune
+	for i := start + 1; i < len(runes); i++ {
+		switch c := runes[i]; {
+		case quote != 0 && c == '\\':
+			i++
+		case quote != 0 && c == quote:
+			quote = 0
+		case quote != 0:
+		case c == '\'' || c == '"':
+			quote = c
+		case c == ']':
+			return i
+		}
+	}
+	return -1
+}
+
+type pclParser struct {
+	tokens []pclToken
+	pos    int
+}
+
+func (p *pclParser) peek() pclToken {
+	return p.tokens[p.pos]
+}
+
+func (p *pclParser) next() pclToken {
+	t := p.tokens[p.pos]
+	if t.kind != pclEnd {
+		p.pos++
+	}
+	return t
+}
+
+func (p *pclParser) parseOr() (*pclCondition, *pclError) {
+	c, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().is("or") {
+		p.next()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		c = &pclCondition{op: "or", left: c, right: right}
+	}
+	return c, nil
+}
+
+func (p *pclParser) parseAnd() (*pclCondition, *pclError) {
+	c, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().is("and") {
+		p.next()
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		c = &pclCondition{op: "and", left: c, right: right}
+	}
+	return c, nil
+}
+
+func (p *pclParser) parseUnary() (*pclCondition, *pclError) {
+	if p.peek().is("not") {
+		p.next()
+		c, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &pclCondition{op: "not", left: c}, nil
+	}
+
+	if p.peek().kind == pclLeftParen {
+		open := p.next()
+		c, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if t := p.next(); t.kind != pclRightParen {
+			return nil, &pclError{Column: t.col, Message: fmt.Sprintf("expected \")\" closing the one at column %d, got %s", open.col, t)}
+		}
+		return c, nil
+	}
+
+	return p.parseCondition()
+}
+
+func (p *pclParser) parseCondition() (*pclCondition, *pclError) {
+	field := p.next()
+	if field.kind != pclWord || isPCLKeyword(field.text) {
+		return nil, &pclError{Column: field.col, Message: fmt.Sprintf("expected a field path, got %s", field)}
+	}
+	if err := validatePCLFieldPath(field); err != nil {
+		return nil, err
+	}
+	c := &pclCondition{field: field.text, fieldCol: field.col}
+
+	op := p.next()
+	switch {
+	case op.is("exists"):
+		c.op = "exists"
+		return c, nil
+
+	case op.is("matches"):
+		c.op = "matches"
+		if t := p.peek(); t.is("part") || t.is("regex") || t.is("cidr") {
+			c.op += " " + strings.ToLower(p.next().text)
+		}
+		value, err := p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		c.value = value.value
+
+		switch c.op {
+		case "matches regex":
+			re, rerr := regexp.Compile(c.value)
+			if rerr != nil {
+				return nil, &pclError{Column: value.col, Message: fmt.Sprintf("invalid regular expression: %s", rerr)}
+			}
+			c.regex = re
+		case "matches cidr":
+			_, cidr, cerr := net.ParseCIDR(c.value)
+			if cerr != nil {
+				return nil, &pclError{Column: value.col, Message: fmt.Sprintf("invalid CIDR block %q", c.value)}
+			}
+			c.cidr = cidr
+		}
+		return c, nil
+
+	case op.kind == pclOperator:
+		value, err := p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		c.op, c.value = op.text, value.value
+		return c, nil
+
+	case op.is("in") && field.text == "now":
+		window, err := p.parseTimeWindow()
+		if err != nil {
+			return nil, err
+		}
+		c.op, c.window = "in", window
+		return c, nil
+	}
+
+	return nil, &pclError{Column: op.col, Message: fmt.Sprintf("expected an operator such as \"matches\" or \"exists\" after %s, got %s", field, op)}
+}
+
+// parseValue parses the value of a condition, a string or a number.
+func (p *pclParser) parseValue() (pclToken, *pclError) {
+	t := p.next()
+	switch t.kind {
+	case pclString:
+		return t, nil
+	case pclWord:
+		if _, err := strconv.ParseFloat(t.text, 64); err == nil && !isPCLKeyword(t.text) {
+			t.value = t.text
+			return t, nil
+		}
+	}
+	return t, &pclError{Column: t.col, Message: fmt.Sprintf("expected a quoted string or a number, got %s", t)}
+}
+
+// parseTimeWindow parses the window of time of a `now in` condition, such as
+// `Mon,Tue 09:00:00 to 17:00:00 America/Los_Angeles`.
+func (p *pclParser) parseTimeWindow() (*pclTimeWindow, *pclError) {
+	window := &pclTimeWindow{}
+
+	t := p.next()
+	if t.kind == pclWord && !pclTimeOfDay.MatchString(t.text) {
+		window.days = map[time.Weekday]bool{}
+		col := t.col
+		for _, day := range strings.Split(t.text, ",") {
+			weekday, ok := pclWeekdays[strings.ToLower(day)]
+			if !ok {
+				return nil, &pclError{Column: col, Message: fmt.Sprintf("invalid day of the week %q, expected one of Mon, Tue, Wed, Thu, Fri, Sat or Sun", day)}
+			}
+			window.days[weekday] = true
+			col += len([]rune(day)) + 1
+		}
+		t = p.next()
+	}
+
+	if t.kind != pclWord || !pclTimeOfDay.MatchString(t.text) {
+		return nil, &pclError{Column: t.col, Message: fmt.Sprintf("expected a time of the day such as \"09:00:00\", got %s", t)}
+	}
+	window.start = pclSecondsOfDay(t.text)
+	if t = p.next(); !t.is("to") {
+		return nil, &pclError{Column: t.col, Message: fmt.Sprintf("expected \"to\", got %s", t)}
+	}
+	if t = p.next(); t.kind != pclWord || !pclTimeOfDay.MatchString(t.text) {
+		return nil, &pclError{Column: t.col, Message: fmt.Sprintf("expected a time of the day such as \"17:00:00\", got %s", t)}
+	}
+	window.end = pclSecondsOfDay(t.text)
+	if t = p.next(); t.kind != pclWord || isPCLKeyword(t.text) {
+		return nil, &pclError{Column: t.col, Message: fmt.Sprintf("expected a time zone such as \"America/Los_Angeles\", got %s", t)}
+	}
+	window.location = t.text
+	return window, nil
+}
+
+// pclSecondsOfDay converts a time of the day matching pclTimeOfDay to
+// seconds since midnight.
+func pclSecondsOfDay(s string) int {
+	seconds := 0
+	parts := strings.Split(s, ":")
+	for i, multiplier := range []int{3600, 60, 1} {
+		if i < len(parts) {
+			n, _ := strconv.Atoi(parts[i])
+			seconds += n * multiplier
+		}
+	}
+	return seconds
+}
+
+func isPCLKeyword(s string) bool {
+	switch strings.ToLower(s) {
+	case "and", "or", "not", "exists", "matches", "part", "regex", "cidr", "in", "to":
+		return true
+	}
+	return false
+}
+
+// validatePCLFieldPath checks the syntax of the field path `t`.
+func validatePCLFieldPath(t pclToken) *pclError {
+	segments := splitPCLFieldPath(t.text)
+	for _, s := range segments[1:] {
+		if s == "" {
+			return &pclError{Column: t.col, Message: fmt.Sprintf("invalid field path %q", t.text), Field: t.text}
+		}
+	}
+	return nil
+}
+
+// unknownFields returns an error for every field path of the condition which
+// doesn't refer to a known field of events, variables or cache variables.
+func (c *pclCondition) unknownFields() []*pclError {
+	if c == nil {
+		return nil
+	}
+	if c.left != nil || c.right != nil {
+		return append(c.left.unknownFields(), c.right.unknownFields()...)
+	}
+	if err := checkPCLFieldPath(c.field, c.fieldCol); err != nil {
+		return []*pclError{err}
+	}
+	return nil
+}
+
+func checkPCLFieldPath(path string, col int) *pclError {
+	if path == "now" {
+		return nil
+	}
+
+	segments := splitPCLFieldPath(path)
+	unknown := func() *pclError {
+		msg := fmt.Sprintf("unknown field path %q", path)
+		if s := suggestPCLFieldPath(path, segments); s != "" {
+			msg += fmt.Sprintf(", did you mean %q?", s)
+		}
+		return &pclError{Column: col, Message: msg, Field: path}
+	}
+
+	known := false
+	for _, ns := range pclNamespaces {
+		known = known || segments[0] == ns
+	}
+	if !known || len(segments) < 2 {
+		return unknown()
+	}
+
+	if segments[0] == "event" {
+		hasSubfields, ok := pclEventFields[segments[1]]
+		if !ok || (!hasSubfields && len(segments) > 2) {
+			return unknown()
+		}
+	}
+	return nil
+}
+
+// splitPCLFieldPath splits a field path such as `event.custom_details.a[0]`
+// into its segments, here `event`, `custom_details`, `a` and `[0]`.
+func splitPCLFieldPath(path string) []string {
+	var segments []string
+	runes := []rune(path)
+	start := 0
+	for i := 0; i < len(runes); i++ {
+		switch runes[i] {
+		case '.':
+			segments = append(segments, string(runes[start:i]))
+			start = i + 1
+		case '[':
+			if i > start {
+				segments = append(segments, string(runes[start:i]))
+			}
+			end := pclClosingBracket(runes, i)
+			segments = append(segments, string(runes[i:end+1]))
+			i = end
+			start = end + 1
+			if start < len(runes) && runes[start] == '.' {
+				i++
+				start++
+			}
+		}
+	}
+	if start < len(runes) || len(segments) == 0 {
+		segments = append(segments, string(runes[start:]))
+	}
+	return segments
+}
+
+// suggestPCLFieldPath returns the known field path closest to `path`, made of
+// `segments`, or an empty string if none is close enough.
+func suggestPCLFieldPath(path string, segments []string) string {
+	root, field := segments[0], ""
+	if len(segments) > 1 {
+		field = segments[1]
+	}
+	rest := strings.TrimPrefix(strings.TrimPrefix(path, root), ".")
+	rest = strings.TrimPrefix(rest, field)
+
+	best, bestDistance := "", 3
+	consider := func(candidateRoot, candidateField string) {
+		d := levenshteinDistance(root, candidateRoot) + levenshteinDistance(field, candidateField)
+		if d > 0 && d < bestDistance {
+			best, bestDistance = candidateRoot+"."+candidateField, d
+		}
+	}
+	names := make([]string, 0, len(pclEventFields))
+	for name := range pclEventFields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		consider("event", name)
+	}
+	for _, ns := range pclNamespaces[1:] {
+		consider(ns, field)
+	}
+
+	if best == "" {
+		return ""
+	}
+	return best + rest
+}
+
+func levenshteinDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		cur := make([]int, len(rb)+1)
+		cur[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			cur[j] = prev[j-1] + cost
+			if prev[j]+1 < cur[j] {
+				cur[j] = prev[j] + 1
+			}
+			if cur[j-1]+1 < cur[j] {
+				cur[j] = cur[j-1] + 1
+			}
+		}
+		prev = cur
+	}
+	return prev[len(rb)]
+}
@@ -0,0 +1,161 @@
+package pagerduty
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	sdkterraform "github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestPagerDutyEventOrchestrationPath_ParsePCLCondition(t *testing.T) {
+	valid := []string{
+		"event.severity matches 'critical'",
+		"event.source exists",
+		"not event.source exists",
+		"event.summary matches part 'running out of space'",
+		"event.source matches regex 'db[0-9]+-server'",
+		"event.summary matches '[test - create incident]'",
+		`event.summary matches part "it's down"`,
+		`event.summary matches part 'it\'s down'`,
+		"event.custom_details.service_name matches part '-api' and event.custom_details.status_code matches '502'",
+		"event.custom_details['host name'] matches 'db01'",
+		"event.custom_details.hosts[0] exists",
+		"event.custom_details.ip matches cidr '10.0.0.0/8'",
+		"cache_var.num_db_triggers >= 5",
+		"variables.hostname matches 'db01' or raw_event.payload.x exists",
+		"event.severity matches 'info' and not (now in Mon,Tue,Wed,Thu,Fri 09:00:00 to 17:00:00 America/Los_Angeles)",
+		"now in 22:00:00 to 06:00:00 Europe/Dublin",
+		"(event.severity matches 'critical' or event.severity matches 'error') and event.summary matches part 'db'",
+		"event.custom_details.count > 10 AND event.custom_details.count <= 20.5",
+	}
+	for _, expression := range valid {
+		if _, err := parsePCLCondition(expression); err != nil {
+			t.Errorf("%q: unexpected error: %s", expression, err)
+		}
+	}
+
+	invalid := []struct {
+		expression string
+		column     int
+		message    string
+		field      string
+	}{
+		{"event..summary exists", 1, `invalid field path "event..summary"`, "event..summary"},
+		{"event.summary matchs 'db'", 15, `expected an operator such as "matches" or "exists" after "event.summary", got "matchs"`, ""},
+		{"event.summary matches part db", 28, `expected a quoted string or a number, got "db"`, ""},
+		{"event.summary matches 'db", 23, "unterminated string", ""},
+		{"event.summary matches regex 'db[0-9'", 29, "invalid regular expression: error parsing regexp: missing closing ]: `[0-9`", ""},
+		{"event.summary = 'db'", 15, `unexpected "=", did you mean "==" or "matches"?`, ""},
+		{"event.summary exists and", 25, "expected a field path, got end of the condition", ""},
+		{"(event.summary exists", 22, `expected ")" closing the one at column 1, got end of the condition`, ""},
+		{"event.summary exists event.source exists", 22, `expected "and" or "or", got "event.source"`, ""},
+		{"now in Mon,Tues 09:00:00 to 17:00:00 UTC", 12, `invalid day of the week "Tues", expected one of Mon, Tue, Wed, Thu, Fri, Sat or Sun`, ""},
+		{"now in Mon 09:00:00 until 17:00:00 UTC", 21, `expected "to", got "until"`, ""},
+		{"event.custom_details.ip matches cidr '10.0.0.0'", 38, `invalid CIDR block "10.0.0.0"`, ""},
+	}
+	for _, c := range invalid {
+		_, err := parsePCLCondition(c.expression)
+		if err == nil {
+			t.Errorf("%q: expected an error", c.expression)
+			continue
+		}
+		if err.Column != c.column || err.Message != c.message || err.Field != c.field {
+			t.Errorf("%q: got error %q at column %d for field %q, want %q at column %d for field %q",
+				c.expression, err.Message, err.Column, err.Field, c.message, c.column, c.field)
+		}
+	}
+
+	unknown := []struct {
+		expression string
+		column     int
+		message    string
+		field      string
+	}{
+		{"event.sumary matches part 'db'", 1, `unknown field path "event.sumary", did you mean "event.summary"?`, "event.sumary"},
+		{"event.severity matches 'critical' and events.source exists", 39, `unknown field path "events.source", did you mean "event.source"?`, "events.source"},
+		{"event.custom_detials.host matches 'db'", 1, `unknown field path "event.custom_detials.host", did you mean "event.custom_details.host"?`, "event.custom_detials.host"},
+		{"payload.summary exists", 1, `unknown field path "payload.summary"`, "payload.summary"},
+		{"not event.summary.text exists", 5, `unknown field path "event.summary.text"`, "event.summary.text"},
+	}
+	for _, c := range unknown {
+		condition, err := parsePCLCondition(c.expression)
+		if err != nil {
+			t.Errorf("%q: unexpected error: %s", c.expression, err)
+			continue
+		}
+		fields := condition.unknownFields()
+		if len(fields) != 1 {
+			t.Errorf("%q: expected an unknown field, got %v", c.expression, fields)
+			continue
+		}
+		if f := fields[0]; f.Column != c.column || f.Message != c.message || f.Field != c.field {
+			t.Errorf("%q: got %q at column %d for field %q, want %q at column %d for field %q",
+				c.expression, f.Message, f.Column, f.Field, c.message, c.column, c.field)
+		}
+	}
+	for _, expression := range valid {
+		condition, _ := parsePCLCondition(expression)
+		if fields := condition.unknownFields(); len(fields) > 0 {
+			t.Errorf("%q: unexpected unknown fields %v", expression, fields)
+		}
+	}
+}
+
+func TestPagerDutyEventOrchestrationPath_ValidateCondition(t *testing.T) {
+	p := cty.GetAttrPath("set").IndexInt(0).GetAttr("rule").IndexInt(0).GetAttr("condition").IndexInt(0).GetAttr("expression")
+
+	if diags := validateEventOrchestrationPathCondition()("event.summary exists", p); diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	diags := validateEventOrchestrationPathCondition()("event.sumary matches 'db'", p)
+	if len(diags) != 1 || diags[0].Severity != diag.Warning {
+		t.Fatalf("expected a warning, got %v", diags)
+	}
+	d := diags[0]
+	if !d.AttributePath.Equals(p) {
+		t.Errorf("unexpected attribute path %v", d.AttributePath)
+	}
+	if want := `Unknown field in PCL condition: unknown field path "event.sumary", did you mean "event.summary"?`; d.Summary != want {
+		t.Errorf("got summary %q, want %q", d.Summary, want)
+	}
+	wantDetail := "Warning at column 1 of the condition:\n\n  event.sumary matches 'db'\n  ^"
+	if !strings.HasPrefix(d.Detail, wantDetail) {
+		t.Errorf("got detail %q, want it to start with %q", d.Detail, wantDetail)
+	}
+
+	diags = validateEventOrchestrationPathCondition()("event.summary matchs 'db'", p)
+	if len(diags) != 1 || diags[0].Severity != diag.Error {
+		t.Fatalf("expected an error, got %v", diags)
+	}
+	wantDetail = "Error at column 15 of the condition:\n\n  event.summary matchs 'db'\n                ^"
+	if diags[0].Detail != wantDetail {
+		t.Errorf("got detail %q, want %q", diags[0].Detail, wantDetail)
+	}
+}
+
+func TestPagerDutyEventOrchestrationPathRouter_ValidateUnknownField(t *testing.T) {
+	config := sdkterraform.NewResourceConfigRaw(map[string]interface{}{
+		"event_orchestration": "PORCH",
+		"set": []interface{}{map[string]interface{}{
+			"id": "start",
+			"rule": []interface{}{map[string]interface{}{
+				"condition": []interface{}{map[string]interface{}{"expression": "event.sumary matches part 'database'"}},
+				"actions":   []interface{}{map[string]interface{}{"route_to": "PSERVICE"}},
+			}},
+		}},
+		"catch_all": []interface{}{map[string]interface{}{
+			"actions": []interface{}{map[string]interface{}{"route_to": "unrouted"}},
+		}},
+	})
+
+	diags := resourcePagerDutyEventOrchestrationPathRouter().Validate(config)
+	if diags.HasError() {
+		t.Fatalf("expected unknown fields not to fail the validation, got %v", diags)
+	}
+	if len(diags) != 1 || diags[0].Severity != diag.Warning || !strings.HasPrefix(diags[0].Summary, "Unknown field in PCL condition") {
+		t.Errorf("expected an unknown field warning, got %v", diags)
+	}
+}
@@ -0,0 +1,484 @@
+package pagerduty
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/heimweh/go-pagerduty/pagerduty"
+)
+
+// eventOrchestrationPathRuleSchema returns the schema of resources managing a
+// single rule of an Event Orchestration path, whose actions follow
+// `actionsSchema`.
+func eventOrchestrationPathRuleSchema(actionsSchema map[string]*schema.Schema) map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"event_orchestration": {
+			Type:     schema.TypeString,
+			Required: true,
+			ForceNew: true,
+		},
+		"label": {
+			Type:     schema.TypeString,
+			Optional: true,
+		},
+		"condition": {
+			Type:     schema.TypeList,
+			Optional: true,
+			Elem: &schema.Resource{
+				Schema: eventOrchestrationPathConditionsSchema,
+			},
+		},
+		"actions": {
+			Type:     schema.TypeList,
+			Required: true,
+			MaxItems: 1,
+			Elem: &schema.Resource{
+				Schema: actionsSchema,
+			},
+		},
+		"disabled": {
+			Type:     schema.TypeBool,
+			Optional: true,
+		},
+		"position": {
+			Type:          schema.TypeInt,
+			Optional:      true,
+			Computed:      true,
+			ConflictsWith: []string{"after_rule_id"},
+		},
+		"after_rule_id": {
+			Type:          schema.TypeString,
+			Optional:      true,
+			ConflictsWith: []string{"position"},
+		},
+	}
+}
+
+// eventOrchestrationPathLocks holds a lock per Event Orchestration path, so
+// the resources of this provider managing rules of the same path don't
+// overwrite each other's changes.
+var eventOrchestrationPathLocks = struct {
+	sync.Mutex
+	locks map[string]*sync.Mutex
+}{locks: map[string]*sync.Mutex{}}
+
+func lockEventOrchestrationPath(id, pathType string) func() {
+	key := fmt.Sprintf("%s/%s", id, pathType)
+
+	eventOrchestrationPathLocks.Lock()
+	l, ok := eventOrchestrationPathLocks.locks[key]
+	if !ok {
+		l = &sync.Mutex{}
+		eventOrchestrationPathLocks.locks[key] = l
+	}
+	eventOrchestrationPathLocks.Unlock()
+
+	l.Lock()
+	return l.Unlock
+}
+
+// updateEventOrchestrationPathRules reads the path `pathType` of `id`, applies
+// `modify` to it, writes it back and reads it again to check the write wasn't
+// undone by another writer, such as another Terraform configuration managing
+// rules of the same path. PagerDuty doesn't offer conditional writes, so when
+// a rule written is missing, or a rule removed is back, the path is read,
+// modified and written again, with backoff. `modify` is given the path
+// written by the previous attempt, nil on the first one.
+//
+// Updates made by this provider are serialized by a lock on the path. Writes
+// made elsewhere between the read and the write of an attempt are still
+// overwritten, and only restored when their writer checks them in turn, as
+// this provider does. Only the presence of rules is checked, so an overwritten
+// change to an existing rule isn't detected.
+func updateEventOrchestrationPathRules(ctx context.Context, client *pagerduty.Client, id, pathType string, modify func(path, previous *pagerduty.EventOrchestrationPath) error) (*pagerduty.EventOrchestrationPathPayload, error) {
+	defer lockEventOrchestrationPath(id, pathType)()
+
+	var payload *pagerduty.EventOrchestrationPathPayload
+	var previous *pagerduty.EventOrchestrationPath
+	err := retry.RetryContext(ctx, 2*time.Minute, func() *retry.RetryError {
+		path, rerr := getEventOrchestrationPathForRules(ctx, client, id, pathType)
+		if rerr != nil {
+			return rerr
+		}
+		read := eventOrchestrationPathRuleIDs(path)
+		if err := modify(path, previous); err != nil {
+			return retry.NonRetryableError(err)
+		}
+
+		update := &pagerduty.EventOrchestrationPath{
+			Parent:   &pagerduty.EventOrchestrationPathReference{ID: id},
+			Sets:     path.Sets,
+			CatchAll: path.CatchAll,
+		}
+		var err error
+		payload, _, err = client.EventOrchestrationPaths.UpdateContext(ctx, id, pathType, update)
+		if err != nil {
+			if isErrCode(err, http.StatusBadRequest) {
+				return retry.NonRetryableError(err)
+			}
+			return retry.RetryableError(err)
+		}
+		if payload == nil || payload.OrchestrationPath == nil {
+			return retry.NonRetryableError(fmt.Errorf("No Event Orchestration %s path found.", pathType))
+		}
+		previous = payload.OrchestrationPath
+
+		current, rerr := getEventOrchestrationPathForRules(ctx, client, id, pathType)
+		if rerr != nil {
+			return rerr
+		}
+		if err := checkEventOrchestrationPathRulesKept(read, previous, current); err != nil {
+			log.Printf("[WARN] Event Orchestration %s %s path changed while being updated, retrying: %s", id, pathType, err)
+			return retry.RetryableError(err)
+		}
+		return nil
+	})
+	return payload, err
+}
+
+// eventOrchestrationPathRuleIDs returns the IDs of the rules of `path`.
+func eventOrchestrationPathRuleIDs(path *pagerduty.EventOrchestrationPath) map[string]bool {
+	ids := map[string]bool{}
+	for _, set := range path.Sets {
+		for _, rule := range set.Rules {
+			ids[rule.ID] = true
+		}
+	}
+	return ids
+}
+
+// checkEventOrchestrationPathRulesKept checks that `current` has the rules of
+// the path `written` over the one with the rules `read`, and none of the rules
+// removed in between.
+func checkEventOrchestrationPathRulesKept(read map[string]bool, written, current *pagerduty.EventOrchestrationPath) error {
+	want := eventOrchestrationPathRuleIDs(written)
+	got := eventOrchestrationPathRuleIDs(current)
+	for id := range want {
+		if !got[id] {
+			return fmt.Errorf("rule %s is missing after the update", id)
+		}
+	}
+	for id := range read {
+		if !want[id] && got[id] {
+			return fmt.Errorf("rule %s is back after being removed", id)
+		}
+	}
+	return nil
+}
+
+func getEventOrchestrationPathForRules(ctx context.Context, client *pagerduty.Client, id, pathType string) (*pagerduty.EventOrchestrationPath, *retry.RetryError) {
+	path, _, err := client.EventOrchestrationPaths.GetContext(ctx, id, pathType)
+	if err != nil {
+		if isErrCode(err, http.StatusBadRequest) || isErrCode(err, http.StatusNotFound) {
+			return nil, retry.NonRetryableError(err)
+		}
+		return nil, retry.RetryableError(err)
+	}
+	if path == nil {
+		return nil, retry.NonRetryableError(fmt.Errorf("No Event Orchestration %s path found.", pathType))
+	}
+	return path, nil
+}
+
+// findEventOrchestrationPathRule returns the rule `id` of `path`, along with
+// its set and its position in the set.
+func findEventOrchestrationPathRule(path *pagerduty.EventOrchestrationPath, id string) (*pagerduty.EventOrchestrationPathSet, int, *pagerduty.EventOrchestrationPathRule) {
+	for _, set := range path.Sets {
+		for i, rule := range set.Rules {
+			if rule.ID == id {
+				return set, i, rule
+			}
+		}
+	}
+	return nil, -1, nil
+}
+
+// eventOrchestrationPathRuleAt returns the rule at `index` of the set `setID`
+// of `path`, nil when there's none.
+func eventOrchestrationPathRuleAt(path *pagerduty.EventOrchestrationPath, setID string, index int) *pagerduty.EventOrchestrationPathRule {
+	for _, set := range path.Sets {
+		if set.ID == setID && index < len(set.Rules) {
+			return set.Rules[index]
+		}
+	}
+	return nil
+}
+
+// insertEventOrchestrationPathRule inserts `rule` into `set` at `position`,
+// or right after the rule `afterRuleID`, or at the end of the set when
+// neither is given, and returns where it was inserted.
+func insertEventOrchestrationPathRule(set *pagerduty.EventOrchestrationPathSet, rule *pagerduty.EventOrchestrationPathRule, position int, afterRuleID string) (int, error) {
+	i := len(set.Rules)
+	switch {
+	case afterRuleID != "":
+		i = -1
+		for j, r := range set.Rules {
+			if r.ID == afterRuleID {
+				i = j + 1
+			}
+		}
+		if i < 0 {
+			return 0, fmt.Errorf("rule %q doesn't exist in set %q", afterRuleID, set.ID)
+		}
+	case position >= 0:
+		if position > len(set.Rules) {
+			return 0, fmt.Errorf("position %d is out of range, set %q has %d other rules", position, set.ID, len(set.Rules))
+		}
+		i = position
+	}
+
+	set.Rules = append(set.Rules, nil)
+	copy(set.Rules[i+1:], set.Rules[i:])
+	set.Rules[i] = rule
+	return i, nil
+}
+
+// checkDynamicRoutingRulePlacement checks that the rule at `index` of `set` is
+// the first rule of the set when it has the Dynamic Routing action, as
+// PagerDuty only supports a single Dynamic Routing rule at the top of the
+// Router.
+func checkDynamicRoutingRulePlacement(set *pagerduty.EventOrchestrationPathSet, index int) error {
+	rule := set.Rules[index]
+	if rule.Actions == nil || rule.Actions.DynamicRouteTo == nil || index == 0 {
+		return nil
+	}
+	if first := set.Rules[0]; first.Actions != nil && first.Actions.DynamicRouteTo != nil {
+		return fmt.Errorf("a Router can have a single Dynamic Routing rule, and rule %s already is", first.ID)
+	}
+	return fmt.Errorf("the Dynamic Routing rule must be the first rule of the Router, got position %d; set `position` to 0", index)
+}
+
+// removeEventOrchestrationPathRule removes the rule `id` from `path`, if it
+// exists.
+func removeEventOrchestrationPathRule(path *pagerduty.EventOrchestrationPath, id string) {
+	if set, i, _ := findEventOrchestrationPathRule(path, id); set != nil {
+		set.Rules = append(set.Rules[:i], set.Rules[i+1:]...)
+	}
+}
+
+func expandEventOrchestrationPathRule(d *schema.ResourceData, pathType string) *pagerduty.EventOrchestrationPathRule {
+	rule := &pagerduty.EventOrchestrationPathRule{
+		ID:         d.Id(),
+		Label:      d.Get("label").(string),
+		Disabled:   d.Get("disabled").(bool),
+		Conditions: expandEventOrchestrationPathConditions(d.Get("condition")),
+	}
+	if pathType == pagerduty.PathTypeRouter {
+		rule.Actions = expandRouterActions(d.Get("actions"))
+	} else {
+		rule.Actions = expandGlobalPathActions(d.Get("actions"))
+	}
+	return rule
+}
+
+// eventOrchestrationPathRulePlacement returns where the rule should be placed
+// in its set: either a position or the ID of the rule it follows. The
+// position is -1 when the rule should stay where it is, or be appended to the
+// set when it's created.
+func eventOrchestrationPathRulePlacement(d *schema.ResourceData) (int, string) {
+	if v, ok := d.GetOk("after_rule_id"); ok {
+		return -1, v.(string)
+	}
+	configured := !d.GetRawConfig().GetAttr("position").IsNull()
+	if configured && (d.Id() == "" || d.HasChange("position")) {
+		return d.Get("position").(int), ""
+	}
+	return -1, ""
+}
+
+func createEventOrchestrationPathRule(ctx context.Context, d *schema.ResourceData, meta interface{}, pathType, setID string) diag.Diagnostics {
+	client, err := meta.(*Config).Client()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	oid := d.Get("event_orchestration").(string)
+	rule := expandEventOrchestrationPathRule(d, pathType)
+	position, afterRuleID := eventOrchestrationPathRulePlacement(d)
+
+	log.Printf("[INFO] Creating PagerDuty Event Orchestration %s rule in set %s for orchestration: %s", pathType, setID, oid)
+
+	var index int
+	response, err := updateEventOrchestrationPathRules(ctx, client, oid, pathType, func(path, previous *pagerduty.EventOrchestrationPath) error {
+		// The rule written by a previous attempt is inserted again, so that
+		// it's only created once.
+		rule.ID = ""
+		if previous != nil {
+			if written := eventOrchestrationPathRuleAt(previous, setID, index); written != nil {
+				removeEventOrchestrationPathRule(path, written.ID)
+			}
+		}
+
+		var set *pagerduty.EventOrchestrationPathSet
+		for _, s := range path.Sets {
+			if s.ID == setID {
+				set = s
+			}
+		}
+		if set == nil {
+			if pathType == pagerduty.PathTypeRouter {
+				return fmt.Errorf("the Router of Event Orchestration %s has no set %q", oid, setID)
+			}
+			set = &pagerduty.EventOrchestrationPathSet{ID: setID, Rules: []*pagerduty.EventOrchestrationPathRule{}}
+			path.Sets = append(path.Sets, set)
+		}
+
+		var err error
+		if index, err = insertEventOrchestrationPathRule(set, rule, position, afterRuleID); err != nil {
+			return err
+		}
+		return checkDynamicRoutingRulePlacement(set, index)
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if rule := eventOrchestrationPathRuleAt(response.OrchestrationPath, setID, index); rule != nil {
+		d.SetId(rule.ID)
+	}
+	if d.Id() == "" {
+		return diag.Errorf("Unable to find the rule created in set %q of the %s path of Event Orchestration %s", setID, pathType, oid)
+	}
+
+	diags := readEventOrchestrationPathRule(ctx, d, meta, pathType)
+	return convertEventOrchestrationPathWarningsToDiagnostics(response.Warnings, diags)
+}
+
+func readEventOrchestrationPathRule(ctx context.Context, d *schema.ResourceData, meta interface{}, pathType string) diag.Diagnostics {
+	client, err := meta.(*Config).Client()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	oid := d.Get("event_orchestration").(string)
+	log.Printf("[INFO] Reading PagerDuty Event Orchestration %s rule %s for orchestration: %s", pathType, d.Id(), oid)
+
+	var path *pagerduty.EventOrchestrationPath
+	retryErr := retry.RetryContext(ctx, 2*time.Minute, func() *retry.RetryError {
+		var rerr *retry.RetryError
+		path, rerr = getEventOrchestrationPathForRules(ctx, client, oid, pathType)
+		if rerr != nil && rerr.Retryable {
+			time.Sleep(2 * time.Second)
+		}
+		return rerr
+	})
+	if retryErr != nil {
+		if isErrCode(retryErr, http.StatusNotFound) {
+			log.Printf("[WARN] Removing %s rule %s because Event Orchestration %s is gone", pathType, d.Id(), oid)
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(retryErr)
+	}
+
+	set, index, rule := findEventOrchestrationPathRule(path, d.Id())
+	if rule == nil {
+		log.Printf("[WARN] Removing %s rule %s because it's gone from Event Orchestration %s", pathType, d.Id(), oid)
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("label", rule.Label)
+	d.Set("disabled", rule.Disabled)
+	d.Set("condition", flattenEventOrchestrationPathConditions(rule.Conditions))
+	if pathType == pagerduty.PathTypeRouter {
+		d.Set("actions", flattenRouterActions(rule.Actions))
+	} else {
+		d.Set("set", set.ID)
+		d.Set("actions", flattenGlobalPathActions(rule.Actions))
+	}
+	d.Set("position", index)
+	if d.Get("after_rule_id").(string) != "" {
+		previous := ""
+		if index > 0 {
+			previous = set.Rules[index-1].ID
+		}
+		d.Set("after_rule_id", previous)
+	}
+
+	return nil
+}
+
+func updateEventOrchestrationPathRule(ctx context.Context, d *schema.ResourceData, meta interface{}, pathType string) diag.Diagnostics {
+	client, err := meta.(*Config).Client()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	oid := d.Get("event_orchestration").(string)
+	rule := expandEventOrchestrationPathRule(d, pathType)
+	position, afterRuleID := eventOrchestrationPathRulePlacement(d)
+
+	log.Printf("[INFO] Updating PagerDuty Event Orchestration %s rule %s for orchestration: %s", pathType, d.Id(), oid)
+
+	response, err := updateEventOrchestrationPathRules(ctx, client, oid, pathType, func(path, _ *pagerduty.EventOrchestrationPath) error {
+		set, index, existing := findEventOrchestrationPathRule(path, d.Id())
+		if existing == nil {
+			return fmt.Errorf("rule %s doesn't exist anymore in the %s path of Event Orchestration %s", d.Id(), pathType, oid)
+		}
+		if position < 0 && afterRuleID == "" {
+			set.Rules[index] = rule
+			return checkDynamicRoutingRulePlacement(set, index)
+		}
+
+		set.Rules = append(set.Rules[:index], set.Rules[index+1:]...)
+		if index, err = insertEventOrchestrationPathRule(set, rule, position, afterRuleID); err != nil {
+			return err
+		}
+		return checkDynamicRoutingRulePlacement(set, index)
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	diags := readEventOrchestrationPathRule(ctx, d, meta, pathType)
+	return convertEventOrchestrationPathWarningsToDiagnostics(response.Warnings, diags)
+}
+
+func deleteEventOrchestrationPathRule(ctx context.Context, d *schema.ResourceData, meta interface{}, pathType string) diag.Diagnostics {
+	client, err := meta.(*Config).Client()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	oid := d.Get("event_orchestration").(string)
+	log.Printf("[INFO] Deleting PagerDuty Event Orchestration %s rule %s for orchestration: %s", pathType, d.Id(), oid)
+
+	_, err = updateEventOrchestrationPathRules(ctx, client, oid, pathType, func(path, _ *pagerduty.EventOrchestrationPath) error {
+		removeEventOrchestrationPathRule(path, d.Id())
+		return nil
+	})
+	if err != nil && !isErrCode(err, http.StatusNotFound) {
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+// importEventOrchestrationPathRule imports rules given as
+// `<event orchestration ID>:<rule ID>`.
+func importEventOrchestrationPathRule(ctx context.Context, d *schema.ResourceData, meta interface{}, pathType string) ([]*schema.ResourceData, error) {
+	oid, ruleID, ok := strings.Cut(d.Id(), ":")
+	if !ok || oid == "" || ruleID == "" {
+		return []*schema.ResourceData{}, fmt.Errorf("Error importing %s rule. Expected import ID format: <event_orchestration_id>:<rule_id>", pathType)
+	}
+
+	d.SetId(ruleID)
+	d.Set("event_orchestration", oid)
+	if diags := readEventOrchestrationPathRule(ctx, d, meta, pathType); diags.HasError() {
+		return []*schema.ResourceData{}, fmt.Errorf("%s", diags[0].Summary)
+	}
+	if d.Id() == "" {
+		return []*schema.ResourceData{}, fmt.Errorf("Rule %s doesn't exist in the %s path of Event Orchestration %s", ruleID, pathType, oid)
+	}
+	return []*schema.ResourceData{d}, nil
+}
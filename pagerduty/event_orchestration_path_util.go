@@ -3,16 +3,20 @@ package pagerduty
 import (
 	"context"
 	"fmt"
+	"net/http"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/heimweh/go-pagerduty/pagerduty"
 )
 
 var eventOrchestrationPathConditionsSchema = map[string]*schema.Schema{
 	"expression": {
-		Type:     schema.TypeString,
-		Required: true,
+		Type:             schema.TypeString,
+		Required:         true,
+		ValidateDiagFunc: validateEventOrchestrationPathCondition(),
 	},
 }
 
@@ -431,3 +435,24 @@ func emptyOrchestrationPathStructBuilder(pathType string) *pagerduty.EventOrches
 func isNonEmptyList(arg interface{}) bool {
 	return !isNilFunc(arg) && len(arg.([]interface{})) > 0
 }
+
+// fetchEventOrchestrationPath returns the path of type `pathType` of the Event
+// Orchestration, or service, `id`.
+func fetchEventOrchestrationPath(ctx context.Context, client *pagerduty.Client, id, pathType string) (*pagerduty.EventOrchestrationPath, error) {
+	var path *pagerduty.EventOrchestrationPath
+	err := retry.RetryContext(ctx, 2*time.Minute, func() *retry.RetryError {
+		var err error
+		path, _, err = client.EventOrchestrationPaths.GetContext(ctx, id, pathType)
+		if err != nil {
+			if isErrCode(err, http.StatusBadRequest) || isErrCode(err, http.StatusNotFound) {
+				return retry.NonRetryableError(err)
+			}
+			return retry.RetryableError(err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Unable to read the %s path of %s: %w", pathType, id, err)
+	}
+	return path, nil
+}
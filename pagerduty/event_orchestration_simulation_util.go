@@ -0,0 +1,555 @@
+package pagerduty
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/heimweh/go-pagerduty/pagerduty"
+)
+
+// pclPayloadFields are the fields of events PCL reads from their `payload`,
+// the other ones being read from the top level of events.
+var pclPayloadFields = map[string]bool{
+	"summary":        true,
+	"source":         true,
+	"severity":       true,
+	"timestamp":      true,
+	"class":          true,
+	"component":      true,
+	"group":          true,
+	"custom_details": true,
+}
+
+var eventOrchestrationTemplateVariable = regexp.MustCompile(`\{\{\s*([^{}]+?)\s*\}\}`)
+
+// eventOrchestrationSimulation evaluates locally the paths of an Event
+// Orchestration against a sample event, following the order PagerDuty
+// processes them in: the global path, the router and then either the service
+// path of the service the event is routed to or the unrouted path.
+type eventOrchestrationSimulation struct {
+	// event is the sample event, in the shape of the Events API v2, updated
+	// by the extractions of the rules it matches.
+	event     map[string]interface{}
+	variables map[string]string
+	now       time.Time
+
+	// lookupService returns the ID of the service whose `lookupBy` (either
+	// `service_id`, `service_name` or `service_integration_key`) is `value`,
+	// or an empty string if there is none. It's used by dynamic routing.
+	lookupService func(lookupBy, value string) (string, error)
+
+	matchedRules []simulatedEventOrchestrationRule
+	route        string
+	severity     string
+	priority     string
+	eventAction  string
+	suppressed   bool
+	dropped      bool
+	warnings     []string
+
+	conditions map[string]*pclCondition
+}
+
+type simulatedEventOrchestrationRule struct {
+	path  string
+	setID string
+	id    string
+	label string
+}
+
+// newEventOrchestrationSimulation prepares the simulation of the event
+// `rawEvent`, a JSON object in the shape of the Events API v2, at time `now`.
+func newEventOrchestrationSimulation(rawEvent string, now time.Time) (*eventOrchestrationSimulation, error) {
+	var event map[string]interface{}
+	if err := json.Unmarshal([]byte(rawEvent), &event); err != nil {
+		return nil, fmt.Errorf("event must be a JSON object: %w", err)
+	}
+	if _, ok := event["payload"].(map[string]interface{}); !ok {
+		return nil, fmt.Errorf("event must have a \"payload\" object, like events sent to the Events API v2")
+	}
+
+	s := &eventOrchestrationSimulation{
+		event:       event,
+		variables:   map[string]string{},
+		now:         now,
+		eventAction: "trigger",
+		conditions:  map[string]*pclCondition{},
+	}
+	if v, ok := s.resolve("event.severity"); ok {
+		s.severity = pclValueString(v)
+	}
+	if v, ok := s.resolve("event.event_action"); ok {
+		s.eventAction = pclValueString(v)
+	}
+	return s, nil
+}
+
+// runPath evaluates the global, service or unrouted path `path`, applying the
+// actions of the rules the event matches.
+func (s *eventOrchestrationSimulation) runPath(pathType string, path *pagerduty.EventOrchestrationPath) {
+	if path == nil {
+		return
+	}
+
+	visited := map[string]bool{}
+	set := eventOrchestrationStartSet(path)
+	for set != nil && !visited[set.ID] {
+		visited[set.ID] = true
+
+		rule := s.firstMatchingRule(pathType, set, nil)
+		if rule == nil {
+			if path.CatchAll != nil {
+				s.applyActions(path.CatchAll.Actions)
+			}
+			return
+		}
+		s.applyActions(rule.Actions)
+		if s.dropped || rule.Actions == nil || rule.Actions.RouteTo == "" {
+			return
+		}
+
+		next := rule.Actions.RouteTo
+		set = nil
+		for _, candidate := range path.Sets {
+			if candidate.ID == next {
+				set = candidate
+			}
+		}
+		if set == nil {
+			s.warn("rule %s of the %s path routes to the unknown set %q", rule.ID, pathType, next)
+		}
+	}
+}
+
+// runRouter evaluates the router `path` and sets the route of the event,
+// either the ID of a service or `unrouted`.
+func (s *eventOrchestrationSimulation) runRouter(path *pagerduty.EventOrchestrationPath) error {
+	s.route = "unrouted"
+	if path == nil {
+		return nil
+	}
+
+	var lookupErr error
+	set := eventOrchestrationStartSet(path)
+	if set != nil {
+		rule := s.firstMatchingRule(pagerduty.PathTypeRouter, set, func(rule *pagerduty.EventOrchestrationPathRule) bool {
+			if rule.Actions == nil || rule.Actions.DynamicRouteTo == nil {
+				return true
+			}
+			route, err := s.dynamicRoute(rule.Actions.DynamicRouteTo)
+			if err != nil {
+				lookupErr = err
+				return false
+			}
+			if route == "" {
+				return false
+			}
+			s.route = route
+			return true
+		})
+		if lookupErr != nil {
+			return lookupErr
+		}
+		if rule != nil {
+			if rule.Actions != nil && rule.Actions.DynamicRouteTo == nil && rule.Actions.RouteTo != "" {
+				s.route = rule.Actions.RouteTo
+			}
+			return nil
+		}
+	}
+
+	if path.CatchAll != nil && path.CatchAll.Actions != nil && path.CatchAll.Actions.RouteTo != "" {
+		s.route = path.CatchAll.Actions.RouteTo
+	}
+	return nil
+}
+
+// dynamicRoute returns the ID of the service the dynamic routing rule `d`
+// routes the event to, or an empty string if there is none.
+func (s *eventOrchestrationSimulation) dynamicRoute(d *pagerduty.EventOrchestrationPathDynamicRouteTo) (string, error) {
+	source, ok := s.resolve(d.Source)
+	if !ok {
+		return "", nil
+	}
+	value := s.extract(d.Regex, pclValueString(source))
+	if value == "" {
+		return "", nil
+	}
+	if s.lookupService == nil {
+		s.warn("dynamic routing by %s to %q was not evaluated", d.LookupBy, value)
+		return "", nil
+	}
+	return s.lookupService(d.LookupBy, value)
+}
+
+// firstMatchingRule returns the first enabled rule of `set` the event
+// matches, recording it. When `accept` is set, rules for which it returns
+// false are skipped even if the event matches them.
+func (s *eventOrchestrationSimulation) firstMatchingRule(pathType string, set *pagerduty.EventOrchestrationPathSet, accept func(*pagerduty.EventOrchestrationPathRule) bool) *pagerduty.EventOrchestrationPathRule {
+	for _, rule := range set.Rules {
+		if rule.Disabled || !s.matchesRule(rule) {
+			continue
+		}
+		if accept != nil && !accept(rule) {
+			continue
+		}
+		s.matchedRules = append(s.matchedRules, simulatedEventOrchestrationRule{
+			path: pathType, setID: set.ID, id: rule.ID, label: rule.Label,
+		})
+		return rule
+	}
+	return nil
+}
+
+// matchesRule reports whether the event matches any of the conditions of
+// `rule`. Rules without conditions match every event.
+func (s *eventOrchestrationSimulation) matchesRule(rule *pagerduty.EventOrchestrationPathRule) bool {
+	if len(rule.Conditions) == 0 {
+		return true
+	}
+	for _, condition := range rule.Conditions {
+		if condition == nil {
+			continue
+		}
+		c, ok := s.conditions[condition.Expression]
+		if !ok {
+			var err *pclError
+			c, err = parsePCLCondition(condition.Expression)
+			if err != nil {
+				s.warn("condition %q of rule %s can't be evaluated: %s", condition.Expression, rule.ID, err.Message)
+			}
+			s.conditions[condition.Expression] = c
+		}
+		if c != nil && s.evaluate(c) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *eventOrchestrationSimulation) applyActions(actions *pagerduty.EventOrchestrationPathRuleActions) {
+	if actions == nil {
+		return
+	}
+
+	for _, v := range actions.Variables {
+		if v == nil {
+			continue
+		}
+		if v.Type != "regex" {
+			s.warn("variable %q of type %q was not evaluated", v.Name, v.Type)
+			continue
+		}
+		if source, ok := s.resolve(v.Path); ok {
+			if value := s.extract(v.Value, pclValueString(source)); value != "" {
+				s.variables[v.Name] = value
+			}
+		}
+	}
+
+	for _, e := range actions.Extractions {
+		if e == nil {
+			continue
+		}
+		var value string
+		if e.Template != "" {
+			value = s.render(e.Template)
+		} else {
+			source, ok := s.resolve(e.Source)
+			if !ok {
+				continue
+			}
+			value = s.extract(e.Regex, pclValueString(source))
+			if value == "" {
+				continue
+			}
+		}
+		s.set(e.Target, value)
+	}
+
+	if actions.Severity != "" {
+		s.severity = actions.Severity
+	}
+	if actions.Priority != "" {
+		s.priority = actions.Priority
+	}
+	if actions.EventAction != "" {
+		s.eventAction = actions.EventAction
+	}
+	s.suppressed = s.suppressed || actions.Suppress
+	s.dropped = s.dropped || actions.DropEvent
+}
+
+// extract returns the value the regular expression `expr` extracts from
+// `source`: the values of its capture groups appended together, or the whole
+// match if it has none.
+func (s *eventOrchestrationSimulation) extract(expr, source string) string {
+	re, err := regexp.Compile(expr)
+	if err != nil {
+		s.warn("invalid regular expression %q: %s", expr, err)
+		return ""
+	}
+	m := re.FindStringSubmatch(source)
+	if m == nil {
+		return ""
+	}
+	if len(m) == 1 {
+		return m[0]
+	}
+	return strings.Join(m[1:], "")
+}
+
+// render replaces the field paths between double curly braces of
+// `template`, such as `{{variables.host}}`, with their values.
+func (s *eventOrchestrationSimulation) render(template string) string {
+	return eventOrchestrationTemplateVariable.ReplaceAllStringFunc(template, func(m string) string {
+		path := eventOrchestrationTemplateVariable.FindStringSubmatch(m)[1]
+		if v, ok := s.resolve(path); ok {
+			return pclValueString(v)
+		}
+		return ""
+	})
+}
+
+// resolve returns the value of the field path `path`, and whether the event
+// has it.
+func (s *eventOrchestrationSimulation) resolve(path string) (interface{}, bool) {
+	segments := splitPCLFieldPath(path)
+	if len(segments) < 2 {
+		return nil, false
+	}
+
+	var v interface{}
+	switch segments[0] {
+	case "event":
+		v = s.eventField(segments[1], false)
+	case "raw_event":
+		v = s.event[segments[1]]
+	case "variables":
+		if value, ok := s.variables[segments[1]]; ok {
+			v = value
+		}
+	case "cache_var":
+		s.warn("cache variable %q is not available to simulations and is treated as missing", segments[1])
+		return nil, false
+	default:
+		return nil, false
+	}
+
+	for _, segment := range segments[2:] {
+		if v == nil {
+			break
+		}
+		v = pclSubfield(v, segment)
+	}
+	return v, v != nil
+}
+
+// set sets the field path `target`, such as `event.summary` or
+// `event.custom_details.host`, to `value`.
+func (s *eventOrchestrationSimulation) set(target, value string) {
+	segments := splitPCLFieldPath(target)
+	if len(segments) < 2 || segments[0] != "event" {
+		s.warn("extraction target %q was not applied", target)
+		return
+	}
+	if len(segments) == 2 {
+		s.eventField(segments[1], true)
+		if pclPayloadFields[segments[1]] {
+			s.event["payload"].(map[string]interface{})[segments[1]] = value
+		} else {
+			s.event[segments[1]] = value
+		}
+		return
+	}
+
+	parent, ok := s.eventField(segments[1], true).(map[string]interface{})
+	if !ok {
+		s.warn("extraction target %q was not applied", target)
+		return
+	}
+	for _, segment := range segments[2 : len(segments)-1] {
+		child, ok := parent[pclSegmentKey(segment)].(map[string]interface{})
+		if !ok {
+			child = map[string]interface{}{}
+			parent[pclSegmentKey(segment)] = child
+		}
+		parent = child
+	}
+	parent[pclSegmentKey(segments[len(segments)-1])] = value
+}
+
+// eventField returns the value of the field `name` of the event, taken from
+// its payload or its top level depending on the field. When `create` is
+// set, the field is created as an object if it's missing.
+func (s *eventOrchestrationSimulation) eventField(name string, create bool) interface{} {
+	parent := s.event
+	if pclPayloadFields[name] {
+		parent = s.event["payload"].(map[string]interface{})
+	}
+	v, ok := parent[name]
+	if !ok && create {
+		v = map[string]interface{}{}
+		parent[name] = v
+	}
+	return v
+}
+
+func (s *eventOrchestrationSimulation) evaluate(c *pclCondition) bool {
+	switch c.op {
+	case "and":
+		return s.evaluate(c.left) && s.evaluate(c.right)
+	case "or":
+		return s.evaluate(c.left) || s.evaluate(c.right)
+	case "not":
+		return !s.evaluate(c.left)
+	case "in":
+		return s.inTimeWindow(c.window)
+	}
+
+	if c.field == "now" {
+		s.warn("condition on \"now\" with operator %q was not evaluated", c.op)
+		return false
+	}
+	v, ok := s.resolve(c.field)
+	if c.op == "exists" || !ok {
+		return ok
+	}
+	value := pclValueString(v)
+
+	switch c.op {
+	case "matches":
+		return value == c.value
+	case "matches part":
+		return strings.Contains(value, c.value)
+	case "matches regex":
+		return c.regex.MatchString(value)
+	case "matches cidr":
+		ip := net.ParseIP(value)
+		return ip != nil && c.cidr.Contains(ip)
+	}
+	return pclCompare(c.op, value, c.value)
+}
+
+// inTimeWindow reports whether the time of the simulation is within
+// `window`. Windows ending before they start span midnight.
+func (s *eventOrchestrationSimulation) inTimeWindow(window *pclTimeWindow) bool {
+	location, err := time.LoadLocation(window.location)
+	if err != nil {
+		s.warn("unknown time zone %q", window.location)
+		return false
+	}
+	now := s.now.In(location)
+	if window.days != nil && !window.days[now.Weekday()] {
+		return false
+	}
+	seconds := now.Hour()*3600 + now.Minute()*60 + now.Second()
+	if window.start <= window.end {
+		return seconds >= window.start && seconds < window.end
+	}
+	return seconds >= window.start || seconds < window.end
+}
+
+func (s *eventOrchestrationSimulation) warn(format string, args ...interface{}) {
+	msg := fmt.Sprintf(format, args...)
+	for _, w := range s.warnings {
+		if w == msg {
+			return
+		}
+	}
+	s.warnings = append(s.warnings, msg)
+}
+
+// eventOrchestrationStartSet returns the set events are evaluated against
+// first in `path`.
+func eventOrchestrationStartSet(path *pagerduty.EventOrchestrationPath) *pagerduty.EventOrchestrationPathSet {
+	for _, set := range path.Sets {
+		if set.ID == "start" {
+			return set
+		}
+	}
+	if len(path.Sets) > 0 {
+		return path.Sets[0]
+	}
+	return nil
+}
+
+// pclCompare compares `a` and `b` with the operator `op`, as numbers if both
+// are numbers and as strings otherwise.
+func pclCompare(op, a, b string) bool {
+	var cmp int
+	x, errA := strconv.ParseFloat(a, 64)
+	y, errB := strconv.ParseFloat(b, 64)
+	switch {
+	case errA == nil && errB == nil && x < y, (errA != nil || errB != nil) && a < b:
+		cmp = -1
+	case errA == nil && errB == nil && x > y, (errA != nil || errB != nil) && a > b:
+		cmp = 1
+	}
+
+	switch op {
+	case "==":
+		return cmp == 0
+	case "!=":
+		return cmp != 0
+	case "<":
+		return cmp < 0
+	case "<=":
+		return cmp <= 0
+	case ">":
+		return cmp > 0
+	case ">=":
+		return cmp >= 0
+	}
+	return false
+}
+
+// pclSubfield returns the subfield `segment` of `v`, either a key of an
+// object or, for segments such as `[0]`, an element of an array.
+func pclSubfield(v interface{}, segment string) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		return v[pclSegmentKey(segment)]
+	case []interface{}:
+		if !strings.HasPrefix(segment, "[") {
+			return nil
+		}
+		i, err := strconv.Atoi(strings.Trim(segment, "[]"))
+		if err != nil || i < 0 || i >= len(v) {
+			return nil
+		}
+		return v[i]
+	}
+	return nil
+}
+
+// pclSegmentKey returns the key of an object a segment of a field path
+// refers to, unquoting segments such as `["a.b"]`.
+func pclSegmentKey(segment string) string {
+	if strings.HasPrefix(segment, "[") && strings.HasSuffix(segment, "]") {
+		inner := segment[1 : len(segment)-1]
+		if unquoted, err := strconv.Unquote(inner); err == nil {
+			return unquoted
+		}
+		if len(inner) >= 2 && inner[0] == '\'' && inner[len(inner)-1] == '\'' {
+			return inner[1 : len(inner)-1]
+		}
+		return inner
+	}
+	return segment
+}
+
+// pclValueString returns the value of a field as PCL compares it: strings as is,
+// and other values in JSON.
+func pclValueString(v interface{}) string {
+	if s, ok := v.(string); ok {
+		return s
+	}
+	b, _ := json.Marshal(v)
+	return string(b)
+}
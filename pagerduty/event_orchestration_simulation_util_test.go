@@ -0,0 +1,237 @@
+package pagerduty
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/heimweh/go-pagerduty/pagerduty"
+)
+
+const testSimulationEvent = `{
+  "event_action": "trigger",
+  "payload": {
+    "summary": "Disk full on db-01.prod",
+    "source": "10.0.4.12",
+    "severity": "warning",
+    "custom_details": {"free_mb": 12, "tags": ["db", "prod"]}
+  }
+}`
+
+func newTestEventOrchestrationSimulation(t *testing.T, event string) *eventOrchestrationSimulation {
+	t.Helper()
+	// A Wednesday, at 10:30 in New York.
+	s, err := newEventOrchestrationSimulation(event, time.Date(2024, 5, 15, 14, 30, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+func TestEventOrchestrationSimulationConditions(t *testing.T) {
+	cases := []struct {
+		expression string
+		want       bool
+	}{
+		{`event.summary matches part "db-01"`, true},
+		{`event.summary matches "Disk full on db-01.prod"`, true},
+		{`event.summary matches "disk full on db-01.prod"`, false},
+		{`event.summary matches regex "db-\\d+\\.prod$"`, true},
+		{`event.source matches cidr "10.0.0.0/16"`, true},
+		{`event.source matches cidr "192.168.0.0/16"`, false},
+		{`event.custom_details.free_mb < 100`, true},
+		{`event.custom_details.free_mb >= 100`, false},
+		{`event.custom_details.tags[1] matches "prod"`, true},
+		{`event.custom_details.missing exists`, false},
+		{`event.event_action matches "trigger" and not event.severity matches "critical"`, true},
+		{`event.severity matches "critical" or (event.source exists and event.dedup_key exists)`, false},
+		{`raw_event.payload.severity matches "warning"`, true},
+		{`now in Mon,Wed 09:00:00 to 17:00:00 America/New_York`, true},
+		{`now in Tue 09:00:00 to 17:00:00 America/New_York`, false},
+		{`now in 22:00:00 to 11:00:00 America/New_York`, true},
+	}
+
+	for _, c := range cases {
+		t.Run(c.expression, func(t *testing.T) {
+			s := newTestEventOrchestrationSimulation(t, testSimulationEvent)
+			condition, err := parsePCLCondition(c.expression)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := s.evaluate(condition); got != c.want {
+				t.Errorf("got %t, want %t", got, c.want)
+			}
+		})
+	}
+}
+
+func TestEventOrchestrationSimulationRunPath(t *testing.T) {
+	path := &pagerduty.EventOrchestrationPath{
+		Sets: []*pagerduty.EventOrchestrationPathSet{
+			{
+				ID: "start",
+				Rules: []*pagerduty.EventOrchestrationPathRule{
+					{
+						ID:         "disabled",
+						Conditions: []*pagerduty.EventOrchestrationPathRuleCondition{{Expression: `event.summary exists`}},
+						Actions:    &pagerduty.EventOrchestrationPathRuleActions{DropEvent: true},
+						Disabled:   true,
+					},
+					{
+						ID:    "disk",
+						Label: "Disk alerts",
+						Conditions: []*pagerduty.EventOrchestrationPathRuleCondition{
+							{Expression: `event.summary matches part "CPU"`},
+							{Expression: `event.summary matches part "Disk"`},
+						},
+						Actions: &pagerduty.EventOrchestrationPathRuleActions{
+							RouteTo:  "enrich",
+							Severity: "critical",
+							Variables: []*pagerduty.EventOrchestrationPathActionVariables{
+								{Name: "host", Path: "event.summary", Type: "regex", Value: `on (\S+)\.prod`},
+							},
+						},
+					},
+				},
+			},
+			{
+				ID: "enrich",
+				Rules: []*pagerduty.EventOrchestrationPathRule{
+					{
+						ID:         "host",
+						Conditions: []*pagerduty.EventOrchestrationPathRuleCondition{{Expression: `variables.host matches "db-01"`}},
+						Actions: &pagerduty.EventOrchestrationPathRuleActions{
+							Priority: "P1",
+							Extractions: []*pagerduty.EventOrchestrationPathActionExtractions{
+								{Target: "event.summary", Template: "[{{variables.host}}] {{event.summary}}"},
+								{Target: "event.custom_details.subnet", Source: "event.source", Regex: `^(\d+\.\d+)\.`},
+							},
+						},
+					},
+				},
+			},
+		},
+		CatchAll: &pagerduty.EventOrchestrationPathCatchAll{
+			Actions: &pagerduty.EventOrchestrationPathRuleActions{Suppress: true},
+		},
+	}
+
+	s := newTestEventOrchestrationSimulation(t, testSimulationEvent)
+	s.runPath(pagerduty.PathTypeGlobal, path)
+
+	wantRules := []simulatedEventOrchestrationRule{
+		{path: "global", setID: "start", id: "disk", label: "Disk alerts"},
+		{path: "global", setID: "enrich", id: "host"},
+	}
+	if !reflect.DeepEqual(s.matchedRules, wantRules) {
+		t.Errorf("unexpected matched rules: %+v", s.matchedRules)
+	}
+	if s.severity != "critical" || s.priority != "P1" || s.suppressed || s.dropped {
+		t.Errorf("unexpected result: severity %q, priority %q, suppressed %t, dropped %t", s.severity, s.priority, s.suppressed, s.dropped)
+	}
+	if got := s.variables["host"]; got != "db-01" {
+		t.Errorf("expected variable host to be db-01; got %q", got)
+	}
+
+	payload := s.event["payload"].(map[string]interface{})
+	if got := payload["summary"]; got != "[db-01] Disk full on db-01.prod" {
+		t.Errorf("unexpected summary: %q", got)
+	}
+	if got := payload["custom_details"].(map[string]interface{})["subnet"]; got != "10.0" {
+		t.Errorf("unexpected subnet: %q", got)
+	}
+
+	// Events matching no rule get the actions of the catch-all.
+	s = newTestEventOrchestrationSimulation(t, `{"payload": {"summary": "Memory high"}}`)
+	s.runPath(pagerduty.PathTypeGlobal, path)
+	if len(s.matchedRules) != 0 || !s.suppressed {
+		t.Errorf("expected no matched rules and the event to be suppressed; got %+v, suppressed %t", s.matchedRules, s.suppressed)
+	}
+}
+
+func TestEventOrchestrationSimulationRunRouter(t *testing.T) {
+	router := &pagerduty.EventOrchestrationPath{
+		Sets: []*pagerduty.EventOrchestrationPathSet{{
+			ID: "start",
+			Rules: []*pagerduty.EventOrchestrationPathRule{
+				{
+					ID:         "dynamic",
+					Conditions: []*pagerduty.EventOrchestrationPathRuleCondition{{Expression: `event.custom_details.service exists`}},
+					Actions: &pagerduty.EventOrchestrationPathRuleActions{
+						DynamicRouteTo: &pagerduty.EventOrchestrationPathDynamicRouteTo{
+							Source: "event.custom_details.service", Regex: ".*", LookupBy: "service_name",
+						},
+					},
+				},
+				{
+					ID:         "db",
+					Conditions: []*pagerduty.EventOrchestrationPathRuleCondition{{Expression: `event.summary matches part "db-"`}},
+					Actions:    &pagerduty.EventOrchestrationPathRuleActions{RouteTo: "PDB"},
+				},
+			},
+		}},
+		CatchAll: &pagerduty.EventOrchestrationPathCatchAll{
+			Actions: &pagerduty.EventOrchestrationPathRuleActions{RouteTo: "unrouted"},
+		},
+	}
+	lookup := func(lookupBy, value string) (string, error) {
+		if lookupBy == "service_name" && value == "Payments" {
+			return "PPAYMENTS", nil
+		}
+		return "", nil
+	}
+
+	cases := []struct {
+		name      string
+		event     string
+		wantRoute string
+		wantRules []string
+	}{
+		{"dynamic", `{"payload": {"summary": "db-01", "custom_details": {"service": "Payments"}}}`, "PPAYMENTS", []string{"dynamic"}},
+		{"dynamic not found", `{"payload": {"summary": "db-01", "custom_details": {"service": "Missing"}}}`, "PDB", []string{"db"}},
+		{"static", `{"payload": {"summary": "db-01"}}`, "PDB", []string{"db"}},
+		{"catch all", `{"payload": {"summary": "web-01"}}`, "unrouted", nil},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			s := newTestEventOrchestrationSimulation(t, c.event)
+			s.lookupService = lookup
+			if err := s.runRouter(router); err != nil {
+				t.Fatal(err)
+			}
+			if s.route != c.wantRoute {
+				t.Errorf("got route %q, want %q", s.route, c.wantRoute)
+			}
+			var rules []string
+			for _, r := range s.matchedRules {
+				rules = append(rules, r.id)
+			}
+			if !reflect.DeepEqual(rules, c.wantRules) {
+				t.Errorf("got matched rules %v, want %v", rules, c.wantRules)
+			}
+		})
+	}
+}
+
+func TestNewEventOrchestrationSimulation(t *testing.T) {
+	if _, err := newEventOrchestrationSimulation(`{"summary": "foo"}`, time.Now()); err == nil {
+		t.Errorf("expected an error for an event without payload")
+	}
+
+	s := newTestEventOrchestrationSimulation(t, `{"event_action": "resolve", "payload": {"severity": "info"}}`)
+	if s.eventAction != "resolve" || s.severity != "info" {
+		t.Errorf("unexpected initial state: event action %q, severity %q", s.eventAction, s.severity)
+	}
+
+	s = newTestEventOrchestrationSimulation(t, `{"payload": {"summary": "foo"}}`)
+	s.resolve("cache_var.count")
+	if len(s.warnings) != 1 {
+		t.Errorf("expected a warning for cache variables; got %v", s.warnings)
+	}
+	b, _ := json.Marshal(s.event)
+	if string(b) != `{"payload":{"summary":"foo"}}` {
+		t.Errorf("expected the event to be left as is; got %s", b)
+	}
+}
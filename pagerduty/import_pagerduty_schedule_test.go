@@ -27,9 +27,10 @@ func TestAccPagerDutySchedule_import(t *testing.T) {
 			},
 
 			{
-				ResourceName:      "pagerduty_schedule.foo",
-				ImportState:       true,
-				ImportStateVerify: true,
+				ResourceName:            "pagerduty_schedule.foo",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"require_full_coverage"},
 			},
 		},
 	})
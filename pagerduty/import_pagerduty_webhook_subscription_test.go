@@ -28,6 +28,8 @@ func TestAccPagerDutyWebhookSubscription_import(t *testing.T) {
 				ResourceName:      "pagerduty_webhook_subscription.foo",
 				ImportState:       true,
 				ImportStateVerify: true,
+				// The secret is only returned on creation.
+				ImportStateVerifyIgnore: []string{"secret"},
 			},
 		},
 	})
@@ -4,12 +4,19 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"os"
 	"regexp"
 	"runtime"
 	"strings"
+	"time"
 
+	"github.com/PagerDuty/terraform-provider-pagerduty/util/cache"
+	"github.com/PagerDuty/terraform-provider-pagerduty/util/httpclient"
+	"github.com/PagerDuty/terraform-provider-pagerduty/util/oauthtoken"
+	"github.com/PagerDuty/terraform-provider-pagerduty/util/profile"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/heimweh/go-pagerduty/pagerduty"
 	"github.com/heimweh/go-pagerduty/persistentconfig"
 )
@@ -47,6 +54,12 @@ func Provider(isMux bool) *schema.Provider {
 				DefaultFunc: schema.EnvDefaultFunc("PAGERDUTY_SERVICE_REGION", ""),
 			},
 
+			"profile": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("PAGERDUTY_PROFILE", nil),
+			},
+
 			"use_app_oauth_scoped_token": {
 				Type:     schema.TypeList,
 				Optional: true,
@@ -68,6 +81,16 @@ func Provider(isMux bool) *schema.Provider {
 							Optional:    true,
 							DefaultFunc: schema.EnvDefaultFunc("PAGERDUTY_SUBDOMAIN", nil),
 						},
+						"scopes": {
+							Type:     schema.TypeList,
+							Optional: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+						"token_cache_dir": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							DefaultFunc: schema.EnvDefaultFunc("PAGERDUTY_TOKEN_CACHE_DIR", nil),
+						},
 					},
 				},
 			},
@@ -83,38 +106,136 @@ func Provider(isMux bool) *schema.Provider {
 				Optional: true,
 				Default:  false,
 			},
+
+			"max_retries": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      httpclient.DefaultMaxRetries,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+
+			"request_timeout": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      httpclient.DefaultRequestTimeout,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+
+			"max_requests_per_minute": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      httpclient.DefaultMaxRequestsPerMinute,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+
+			"default_teams": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringIsNotWhiteSpace,
+				},
+			},
+
+			"default_tags": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringIsNotWhiteSpace,
+				},
+			},
+
+			"bulk_refresh": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			"cache": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"type": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringInSlice(cache.Types(), false),
+						},
+						"max_age": {
+							Type:     schema.TypeString,
+							Optional: true,
+							ValidateFunc: func(v interface{}, k string) (ws []string, errs []error) {
+								if d, err := time.ParseDuration(v.(string)); err != nil || d <= 0 {
+									errs = append(errs, fmt.Errorf("%s must be a positive duration, such as 30s or 10m, got %q", k, v))
+								}
+								return
+							},
+						},
+						"prefill": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  false,
+						},
+						"path": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"url": {
+							Type:      schema.TypeString,
+							Optional:  true,
+							Sensitive: true,
+						},
+						"failure_mode": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Default:      cache.FailureModeError,
+							ValidateFunc: validation.StringInSlice(cache.FailureModes(), false),
+						},
+					},
+				},
+			},
 		},
 
 		DataSourcesMap: map[string]*schema.Resource{
-			"pagerduty_escalation_policy":                          dataSourcePagerDutyEscalationPolicy(),
-			"pagerduty_schedule":                                   dataSourcePagerDutySchedule(),
-			"pagerduty_user":                                       dataSourcePagerDutyUser(),
-			"pagerduty_users":                                      dataSourcePagerDutyUsers(),
-			"pagerduty_licenses":                                   dataSourcePagerDutyLicenses(),
-			"pagerduty_user_contact_method":                        dataSourcePagerDutyUserContactMethod(),
-			"pagerduty_team":                                       dataSourcePagerDutyTeam(),
-			"pagerduty_vendor":                                     dataSourcePagerDutyVendor(),
-			"pagerduty_service":                                    dataSourcePagerDutyService(),
-			"pagerduty_service_integration":                        dataSourcePagerDutyServiceIntegration(),
-			"pagerduty_business_service":                           dataSourcePagerDutyBusinessService(),
-			"pagerduty_priority":                                   dataSourcePagerDutyPriority(),
-			"pagerduty_ruleset":                                    dataSourcePagerDutyRuleset(),
-			"pagerduty_event_orchestration":                        dataSourcePagerDutyEventOrchestration(),
-			"pagerduty_event_orchestrations":                       dataSourcePagerDutyEventOrchestrations(),
-			"pagerduty_event_orchestration_integration":            dataSourcePagerDutyEventOrchestrationIntegration(),
-			"pagerduty_event_orchestration_global_cache_variable":  dataSourcePagerDutyEventOrchestrationGlobalCacheVariable(),
-			"pagerduty_event_orchestration_service_cache_variable": dataSourcePagerDutyEventOrchestrationServiceCacheVariable(),
-			"pagerduty_automation_actions_runner":                  dataSourcePagerDutyAutomationActionsRunner(),
-			"pagerduty_automation_actions_action":                  dataSourcePagerDutyAutomationActionsAction(),
-			"pagerduty_incident_workflow":                          dataSourcePagerDutyIncidentWorkflow(),
-			"pagerduty_incident_custom_field":                      dataSourcePagerDutyIncidentCustomField(),
-			"pagerduty_team_members":                               dataSourcePagerDutyTeamMembers(),
+			"pagerduty_escalation_policy":                           dataSourcePagerDutyEscalationPolicy(),
+			"pagerduty_schedule":                                    dataSourcePagerDutySchedule(),
+			"pagerduty_user":                                        dataSourcePagerDutyUser(),
+			"pagerduty_users":                                       dataSourcePagerDutyUsers(),
+			"pagerduty_licenses":                                    dataSourcePagerDutyLicenses(),
+			"pagerduty_user_contact_method":                         dataSourcePagerDutyUserContactMethod(),
+			"pagerduty_team":                                        dataSourcePagerDutyTeam(),
+			"pagerduty_vendor":                                      dataSourcePagerDutyVendor(),
+			"pagerduty_service":                                     dataSourcePagerDutyService(),
+			"pagerduty_service_integration":                         dataSourcePagerDutyServiceIntegration(),
+			"pagerduty_business_service":                            dataSourcePagerDutyBusinessService(),
+			"pagerduty_priority":                                    dataSourcePagerDutyPriority(),
+			"pagerduty_ruleset":                                     dataSourcePagerDutyRuleset(),
+			"pagerduty_ruleset_migration":                           dataSourcePagerDutyRulesetMigration(),
+			"pagerduty_event_orchestration":                         dataSourcePagerDutyEventOrchestration(),
+			"pagerduty_event_orchestrations":                        dataSourcePagerDutyEventOrchestrations(),
+			"pagerduty_event_orchestration_simulation":              dataSourcePagerDutyEventOrchestrationSimulation(),
+			"pagerduty_event_orchestration_export":                  dataSourcePagerDutyEventOrchestrationExport(),
+			"pagerduty_event_orchestration_integration":             dataSourcePagerDutyEventOrchestrationIntegration(),
+			"pagerduty_event_orchestration_global_cache_variable":   dataSourcePagerDutyEventOrchestrationGlobalCacheVariable(),
+			"pagerduty_event_orchestration_service_cache_variable":  dataSourcePagerDutyEventOrchestrationServiceCacheVariable(),
+			"pagerduty_event_orchestration_global_cache_variables":  dataSourcePagerDutyEventOrchestrationGlobalCacheVariables(),
+			"pagerduty_event_orchestration_service_cache_variables": dataSourcePagerDutyEventOrchestrationServiceCacheVariables(),
+			"pagerduty_automation_actions_runner":                   dataSourcePagerDutyAutomationActionsRunner(),
+			"pagerduty_automation_actions_action":                   dataSourcePagerDutyAutomationActionsAction(),
+			"pagerduty_incident_workflow":                           dataSourcePagerDutyIncidentWorkflow(),
+			"pagerduty_incident_custom_field":                       dataSourcePagerDutyIncidentCustomField(),
+			"pagerduty_team_members":                                dataSourcePagerDutyTeamMembers(),
+			"pagerduty_webhook_signature":                           dataSourcePagerDutyWebhookSignature(),
+			"pagerduty_webhook_event_types":                         dataSourcePagerDutyWebhookEventTypes(),
 		},
 
 		ResourcesMap: map[string]*schema.Resource{
 			"pagerduty_addon":                                         resourcePagerDutyAddon(),
 			"pagerduty_escalation_policy":                             resourcePagerDutyEscalationPolicy(),
 			"pagerduty_maintenance_window":                            resourcePagerDutyMaintenanceWindow(),
+			"pagerduty_recurring_maintenance_window":                  resourcePagerDutyRecurringMaintenanceWindow(),
 			"pagerduty_schedule":                                      resourcePagerDutySchedule(),
 			"pagerduty_service":                                       resourcePagerDutyService(),
 			"pagerduty_service_integration":                           resourcePagerDutyServiceIntegration(),
@@ -135,7 +256,9 @@ func Provider(isMux bool) *schema.Provider {
 			"pagerduty_event_orchestration":                           resourcePagerDutyEventOrchestration(),
 			"pagerduty_event_orchestration_integration":               resourcePagerDutyEventOrchestrationIntegration(),
 			"pagerduty_event_orchestration_global":                    resourcePagerDutyEventOrchestrationPathGlobal(),
+			"pagerduty_event_orchestration_global_rule":               resourcePagerDutyEventOrchestrationPathGlobalRule(),
 			"pagerduty_event_orchestration_router":                    resourcePagerDutyEventOrchestrationPathRouter(),
+			"pagerduty_event_orchestration_router_rule":               resourcePagerDutyEventOrchestrationPathRouterRule(),
 			"pagerduty_event_orchestration_unrouted":                  resourcePagerDutyEventOrchestrationPathUnrouted(),
 			"pagerduty_event_orchestration_service":                   resourcePagerDutyEventOrchestrationPathService(),
 			"pagerduty_event_orchestration_global_cache_variable":     resourcePagerDutyEventOrchestrationGlobalCacheVariable(),
@@ -218,9 +341,21 @@ func handleNotFoundError(err error, d *schema.ResourceData) error {
 	return genError(err, d)
 }
 
-func providerConfigureContextFunc(_ context.Context, data *schema.ResourceData, terraformVersion string) (interface{}, diag.Diagnostics) {
+func providerConfigureContextFunc(ctx context.Context, data *schema.ResourceData, terraformVersion string) (interface{}, diag.Diagnostics) {
 	var diags diag.Diagnostics
+
+	var prof *profile.Profile
+	if name := data.Get("profile").(string); name != "" {
+		var err error
+		if prof, err = profile.Load(name); err != nil {
+			return nil, diag.FromErr(err)
+		}
+	}
+
 	serviceRegion := strings.ToLower(data.Get("service_region").(string))
+	if serviceRegion == "" && prof != nil {
+		serviceRegion = strings.ToLower(prof.ServiceRegion)
+	}
 
 	var regionApiUrl string
 	if serviceRegion == "us" || serviceRegion == "" {
@@ -230,22 +365,64 @@ func providerConfigureContextFunc(_ context.Context, data *schema.ResourceData,
 	}
 
 	config := Config{
-		ApiUrl:              "https://api." + regionApiUrl + "pagerduty.com",
-		AppUrl:              "https://app." + regionApiUrl + "pagerduty.com",
-		SkipCredsValidation: data.Get("skip_credentials_validation").(bool),
-		Token:               data.Get("token").(string),
-		UserToken:           data.Get("user_token").(string),
-		UserAgent:           fmt.Sprintf("(%s %s) Terraform/%s", runtime.GOOS, runtime.GOARCH, terraformVersion),
-		ApiUrlOverride:      data.Get("api_url_override").(string),
-		ServiceRegion:       serviceRegion,
-		InsecureTls:         data.Get("insecure_tls").(bool),
+		ApiUrl:               "https://api." + regionApiUrl + "pagerduty.com",
+		AppUrl:               "https://app." + regionApiUrl + "pagerduty.com",
+		SkipCredsValidation:  data.Get("skip_credentials_validation").(bool),
+		Token:                data.Get("token").(string),
+		UserToken:            data.Get("user_token").(string),
+		UserAgent:            fmt.Sprintf("(%s %s) Terraform/%s", runtime.GOOS, runtime.GOARCH, terraformVersion),
+		ApiUrlOverride:       data.Get("api_url_override").(string),
+		ServiceRegion:        serviceRegion,
+		InsecureTls:          data.Get("insecure_tls").(bool),
+		MaxRetries:           data.Get("max_retries").(int),
+		RequestTimeout:       time.Duration(data.Get("request_timeout").(int)) * time.Second,
+		MaxRequestsPerMinute: data.Get("max_requests_per_minute").(int),
+		DefaultTeams:         expandStringList(data.Get("default_teams").([]interface{})),
+		DefaultTags:          expandStringList(data.Get("default_tags").([]interface{})),
+		BulkRefresh:          data.Get("bulk_refresh").(bool),
+	}
+
+	if prof != nil {
+		if config.Token == "" {
+			config.Token = prof.Token
+		}
+		if config.UserToken == "" {
+			config.UserToken = prof.UserToken
+		}
 	}
 
 	useAuthTokenType := pagerduty.AuthTokenTypeAPIToken
-	if attr, ok := data.GetOk("use_app_oauth_scoped_token"); ok {
+	attr, useAppOauthScopedToken := data.GetOk("use_app_oauth_scoped_token")
+	// A profile with the credentials of a Scoped OAuth client, and no API
+	// token, authenticates with an App OAuth token.
+	if !useAppOauthScopedToken && config.Token == "" && prof != nil && prof.HasAppOauthCredentials() {
+		useAppOauthScopedToken = true
+		attr = []interface{}{map[string]interface{}{
+			"pd_client_id":     os.Getenv("PAGERDUTY_CLIENT_ID"),
+			"pd_client_secret": os.Getenv("PAGERDUTY_CLIENT_SECRET"),
+			"pd_subdomain":     os.Getenv("PAGERDUTY_SUBDOMAIN"),
+		}}
+	}
+	if useAppOauthScopedToken {
 		config.AppOauthScopedTokenParams = expandAppOauthTokenParams(attr)
 		config.AppOauthScopedTokenParams.Region = serviceRegion
-		useAuthTokenType = pagerduty.AuthTokenTypeUseAppCredentials
+		if prof != nil {
+			fillAppOauthTokenParamsFromProfile(config.AppOauthScopedTokenParams, prof)
+		}
+		// The token is requested by the provider, with the configured
+		// scopes, rather than by the client.
+		useAuthTokenType = pagerduty.AuthTokenTypeScopedOauthToken
+
+		scopes, err := oauthtoken.Scopes(expandStringList(data.Get("use_app_oauth_scoped_token.0.scopes").([]interface{})))
+		if err != nil {
+			return nil, diag.FromErr(fmt.Errorf("use_app_oauth_scoped_token.0.scopes: %w", err))
+		}
+		config.AppOauthScopes = scopes
+		config.AppOauthTokenCacheDir = data.Get("use_app_oauth_scoped_token.0.token_cache_dir").(string)
+		if config.AppOauthTokenCacheDir == "" {
+			config.AppOauthTokenCacheDir = os.Getenv("PAGERDUTY_TOKEN_CACHE_DIR")
+		}
+
 		if err := validateAuthMethodConfig(data); err != nil {
 			diag := diag.Diagnostic{
 				Severity: diag.Warning,
@@ -258,6 +435,12 @@ func providerConfigureContextFunc(_ context.Context, data *schema.ResourceData,
 
 	config.APITokenType = &useAuthTokenType
 
+	cacheDiags := configureCache(ctx, data, &config)
+	diags = append(diags, cacheDiags...)
+	if cacheDiags.HasError() {
+		return nil, diags
+	}
+
 	log.Println("[INFO] Initializing PagerDuty client")
 	return &config, diags
 }
@@ -278,6 +461,79 @@ func expandAppOauthTokenParams(v interface{}) *persistentconfig.AppOauthScopedTo
 	return aotp
 }
 
+// fillAppOauthTokenParamsFromProfile sets the App OAuth client credentials
+// which aren't configured to the ones of the profile.
+func fillAppOauthTokenParamsFromProfile(aotp *persistentconfig.AppOauthScopedTokenParams, prof *profile.Profile) {
+	if aotp.ClientID == "" {
+		aotp.ClientID = prof.ClientID
+	}
+	if aotp.ClientSecret == "" {
+		aotp.ClientSecret = prof.ClientSecret
+	}
+	if aotp.PDSubDomain == "" {
+		aotp.PDSubDomain = prof.Subdomain
+	}
+}
+
+// legacyCacheEnvVar turns on the cache of the PagerDuty client itself, which
+// the `cache` block supersedes.
+const legacyCacheEnvVar = "TF_PAGERDUTY_CACHE"
+
+// configureCache opens the cache configured by the `cache` block, carrying on
+// without it when it can't be opened and its failure mode is `disable`.
+func configureCache(ctx context.Context, data *schema.ResourceData, config *Config) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	_, legacy := os.LookupEnv(legacyCacheEnvVar)
+	if _, ok := data.GetOk("cache"); !ok {
+		if legacy {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary:  fmt.Sprintf("%s is deprecated", legacyCacheEnvVar),
+				Detail:   fmt.Sprintf("The cache enabled by the %s, %s_MAX_AGE and %s_PREFILL environment variables is deprecated, and only caches users and their contact methods, notification rules and teams. Use the `cache` block of the provider instead.", legacyCacheEnvVar, legacyCacheEnvVar, legacyCacheEnvVar),
+			})
+		}
+		return diags
+	}
+
+	opts := cache.Options{
+		Type: data.Get("cache.0.type").(string),
+		Path: data.Get("cache.0.path").(string),
+		URL:  data.Get("cache.0.url").(string),
+	}
+	if v := data.Get("cache.0.max_age").(string); v != "" {
+		maxAge, err := time.ParseDuration(v)
+		if err != nil {
+			return append(diags, diag.FromErr(fmt.Errorf("cache.0.max_age: %w", err))...)
+		}
+		opts.MaxAge = maxAge
+	}
+
+	if legacy && opts.Type != cache.TypeNone {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("`cache` and %s are both configured", legacyCacheEnvVar),
+			Detail:   fmt.Sprintf("The cache of the PagerDuty client enabled by %s keeps caching users along with the `cache` block, which may serve them for longer than its `max_age`. Unset %s to only use the `cache` block.", legacyCacheEnvVar, legacyCacheEnvVar),
+		})
+	}
+
+	c, err := cache.Shared(ctx, opts)
+	if err != nil {
+		if data.Get("cache.0.failure_mode").(string) != cache.FailureModeDisable {
+			return append(diags, diag.FromErr(err)...)
+		}
+		log.Printf("[WARN] %s, carrying on without caching", err)
+		return append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  "Caching disabled",
+			Detail:   fmt.Sprintf("%s. Since the failure mode of the cache is `disable`, the provider carries on without caching.", err),
+		})
+	}
+	config.Cache = c
+	config.CachePrefill = c != nil && data.Get("cache.0.prefill").(bool)
+	return diags
+}
+
 var validationAuthMethodConfigWarning = "PagerDuty Provider has been set to authenticate API calls utilizing API token and App Oauth token at same time, in this scenario the use of App Oauth token is prioritised over API token authentication configuration. It is recommended to explicitely set just one authentication method.\nWe also suggest you to check your environment variables in case `token` being automatically read by Provider configuration through `PAGERDUTY_TOKEN` environment variable."
 
 func validateAuthMethodConfig(data *schema.ResourceData) error {
@@ -0,0 +1,218 @@
+package pagerduty
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/heimweh/go-pagerduty/pagerduty"
+)
+
+// customizeDefaultTeamsDiff returns a CustomizeDiffFunc planning the `teams`
+// of a resource as the `default_teams` of the provider when they aren't
+// configured, keeping the first `maxItems` of them when it isn't zero.
+func customizeDefaultTeamsDiff(maxItems int) schema.CustomizeDiffFunc {
+	return func(ctx context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+		config, ok := meta.(*Config)
+		if !ok {
+			return nil
+		}
+		rawConfig := diff.GetRawConfig()
+		if rawConfig.IsNull() || !rawConfig.IsKnown() {
+			return nil
+		}
+		raw := rawConfig.GetAttr("teams")
+		if !raw.IsWhollyKnown() {
+			return nil
+		}
+
+		var teams []string
+		if raw.IsNull() {
+			teams = config.DefaultTeams
+			if maxItems > 0 && len(teams) > maxItems {
+				teams = teams[:maxItems]
+			}
+		} else {
+			// Planned explicitly, since an empty list of teams is otherwise
+			// taken as not configured.
+			for it := raw.ElementIterator(); it.Next(); {
+				_, v := it.Element()
+				if !v.IsNull() && v.Type() == cty.String {
+					teams = append(teams, v.AsString())
+				}
+			}
+		}
+
+		if diff.Id() != "" && stringSlicesEqual(expandStringList(diff.Get("teams").([]interface{})), teams) {
+			return nil
+		}
+		return diff.SetNew("teams", teams)
+	}
+}
+
+// customizeDefaultTagsDiff plans the `default_tags` of a resource as the ones
+// of the provider, so that default tags missing from the object are assigned
+// on update.
+func customizeDefaultTagsDiff(ctx context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+	config, ok := meta.(*Config)
+	if !ok {
+		return nil
+	}
+
+	tags := schema.NewSet(schema.HashString, nil)
+	for _, label := range config.DefaultTags {
+		tags.Add(label)
+	}
+	if diff.Id() != "" && diff.Get("default_tags").(*schema.Set).Equal(tags) {
+		return nil
+	}
+	return diff.SetNew("default_tags", tags)
+}
+
+// readDefaultTags sets the `default_tags` of the object to the default tags
+// of the provider assigned to it.
+func readDefaultTags(d *schema.ResourceData, meta interface{}, entityType string) error {
+	config := meta.(*Config)
+	if len(config.DefaultTags) == 0 {
+		return d.Set("default_tags", nil)
+	}
+
+	assigned, err := fetchEntityTagLabels(config, entityType, d.Id())
+	if err != nil {
+		return err
+	}
+
+	var labels []string
+	for _, label := range config.DefaultTags {
+		if assigned[label] {
+			labels = append(labels, label)
+		}
+	}
+	return d.Set("default_tags", labels)
+}
+
+// assignDefaultTags assigns the default tags of the provider missing from the
+// object. Tags which don't exist yet are created.
+func assignDefaultTags(d *schema.ResourceData, meta interface{}, entityType string) error {
+	config := meta.(*Config)
+	if len(config.DefaultTags) == 0 {
+		return nil
+	}
+
+	client, err := config.Client()
+	if err != nil {
+		return err
+	}
+
+	assigned, err := fetchEntityTagLabels(config, entityType, d.Id())
+	if err != nil {
+		return err
+	}
+
+	var missing []string
+	for _, label := range config.DefaultTags {
+		if !assigned[label] {
+			missing = append(missing, label)
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+
+	tags, err := fetchTagsByLabel(client)
+	if err != nil {
+		return err
+	}
+	assignments := &pagerduty.TagAssignments{}
+	for _, label := range missing {
+		if tag, ok := tags[label]; ok {
+			assignments.Add = append(assignments.Add, &pagerduty.TagAssignment{Type: "tag_reference", TagID: tag.ID})
+		} else {
+			assignments.Add = append(assignments.Add, &pagerduty.TagAssignment{Type: "tag", Label: label})
+		}
+	}
+
+	log.Printf("[INFO] Assigning %d default tags to %s %s", len(assignments.Add), entityType, d.Id())
+
+	return retry.Retry(2*time.Minute, func() *retry.RetryError {
+		if _, err := client.Tags.Assign(entityType, d.Id(), assignments); err != nil {
+			if isErrCode(err, http.StatusBadRequest) || isErrCode(err, http.StatusNotFound) {
+				return retry.NonRetryableError(err)
+			}
+			return retry.RetryableError(err)
+		}
+		return nil
+	})
+}
+
+func fetchEntityTagLabels(config *Config, entityType, entityID string) (map[string]bool, error) {
+	client, err := config.Client()
+	if err != nil {
+		return nil, err
+	}
+
+	var tags []*pagerduty.Tag
+	err = retry.Retry(2*time.Minute, func() *retry.RetryError {
+		resp, _, err := client.Tags.ListTagsForEntity(entityType, entityID)
+		if err != nil {
+			if isErrCode(err, http.StatusBadRequest) || isErrCode(err, http.StatusNotFound) {
+				return retry.NonRetryableError(err)
+			}
+			time.Sleep(2 * time.Second)
+			return retry.RetryableError(err)
+		}
+		tags = resp.Tags
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	labels := make(map[string]bool, len(tags))
+	for _, t := range tags {
+		labels[t.Label] = true
+	}
+	return labels, nil
+}
+
+// fetchTagsByLabel returns the existing tags by their label.
+func fetchTagsByLabel(client *pagerduty.Client) (map[string]*pagerduty.Tag, error) {
+	var tags []*pagerduty.Tag
+	err := retry.Retry(2*time.Minute, func() *retry.RetryError {
+		resp, _, err := client.Tags.List(&pagerduty.ListTagsOptions{})
+		if err != nil {
+			if isErrCode(err, http.StatusBadRequest) {
+				return retry.NonRetryableError(err)
+			}
+			time.Sleep(2 * time.Second)
+			return retry.RetryableError(err)
+		}
+		tags = resp.Tags
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	byLabel := make(map[string]*pagerduty.Tag, len(tags))
+	for _, t := range tags {
+		byLabel[t.Label] = t
+	}
+	return byLabel, nil
+}
+
+func stringSlicesEqual(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
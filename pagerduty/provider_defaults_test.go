@@ -0,0 +1,199 @@
+package pagerduty
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/PagerDuty/terraform-provider-pagerduty/util/mockapi"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	sdkterraform "github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/heimweh/go-pagerduty/pagerduty"
+)
+
+func TestCustomizeDefaultTeamsDiff(t *testing.T) {
+	res := resourcePagerDutyEscalationPolicy()
+	meta := &Config{DefaultTeams: []string{"PTEAM1", "PTEAM2"}, DefaultTags: []string{"owner:sre"}}
+
+	raw := map[string]interface{}{
+		"name": "foo",
+		"rule": []interface{}{
+			map[string]interface{}{
+				"escalation_delay_in_minutes": 10,
+				"target":                      []interface{}{map[string]interface{}{"id": "PUSER"}},
+			},
+		},
+	}
+
+	// Terraform sends the configuration along with the prior state.
+	state := &sdkterraform.InstanceState{RawConfig: testRawConfig(res, nil)}
+	diff, err := res.Diff(context.Background(), state, sdkterraform.NewResourceConfigRaw(raw), meta)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a := diff.Attributes["teams.#"]; a == nil || a.New != "1" || diff.Attributes["teams.0"].New != "PTEAM1" {
+		t.Errorf("expected the first default team to be planned, got %+v", diff.Attributes)
+	}
+	if a := diff.Attributes["default_tags.#"]; a == nil || a.New != "1" {
+		t.Errorf("expected the default tags to be planned, got %+v", diff.Attributes)
+	}
+
+	raw["teams"] = []interface{}{"PTEAM3"}
+	state = &sdkterraform.InstanceState{RawConfig: testRawConfig(res, map[string]cty.Value{"teams": cty.ListVal([]cty.Value{cty.StringVal("PTEAM3")})})}
+	diff, err = res.Diff(context.Background(), state, sdkterraform.NewResourceConfigRaw(raw), meta)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a := diff.Attributes["teams.0"]; a == nil || a.New != "PTEAM3" {
+		t.Errorf("expected the configured team to be planned, got %+v", diff.Attributes)
+	}
+}
+
+// testRawConfig returns the configuration of `res` with the attributes
+// `attrs`, the other ones being null.
+func testRawConfig(res *schema.Resource, attrs map[string]cty.Value) cty.Value {
+	vals := map[string]cty.Value{}
+	for name, ty := range res.CoreConfigSchema().ImpliedType().AttributeTypes() {
+		vals[name] = cty.NullVal(ty)
+	}
+	for name, v := range attrs {
+		vals[name] = v
+	}
+	return cty.ObjectVal(vals)
+}
+
+func TestDefaultTeams(t *testing.T) {
+	server := mockapi.NewServer()
+	defer server.Close()
+	ctx := context.Background()
+	meta := &Config{ApiUrl: server.URL, Token: "foo", SkipCredsValidation: true}
+	client, err := meta.Client()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	team, _, err := client.Teams.Create(&pagerduty.Team{Name: "foo"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	meta.DefaultTeams = []string{team.ID}
+
+	res := resourcePagerDutyEscalationPolicy()
+	raw := map[string]interface{}{
+		"name": "foo",
+		"rule": []interface{}{
+			map[string]interface{}{
+				"escalation_delay_in_minutes": 10,
+				"target":                      []interface{}{map[string]interface{}{"id": "PUSER"}},
+			},
+		},
+	}
+	state := &sdkterraform.InstanceState{RawConfig: testRawConfig(res, nil)}
+	diff, err := res.Diff(ctx, state, sdkterraform.NewResourceConfigRaw(raw), meta)
+	if err != nil {
+		t.Fatal(err)
+	}
+	state, diags := res.Apply(ctx, state, diff, meta)
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	ep, _, err := client.EscalationPolicies.Get(state.ID, &pagerduty.GetEscalationPolicyOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ep.Teams) != 1 || ep.Teams[0].ID != team.ID {
+		t.Errorf("expected the escalation policy to be assigned the default team %s, got %+v", team.ID, ep.Teams)
+	}
+	if got := state.Attributes["teams.0"]; got != team.ID {
+		t.Errorf("expected the default team in the state, got %q", got)
+	}
+}
+
+func TestDefaultTags(t *testing.T) {
+	server := mockapi.NewServer()
+	defer server.Close()
+	ctx := context.Background()
+	meta := &Config{ApiUrl: server.URL, Token: "foo", SkipCredsValidation: true, DefaultTags: []string{"owner:sre", "env:prod"}}
+	client, err := meta.Client()
+	if err != nil {
+		t.Fatal(err)
+	}
+	owner, _, err := client.Tags.Create(&pagerduty.Tag{Type: "tag", Label: "owner:sre"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	res := resourcePagerDutyEscalationPolicy()
+	raw := map[string]interface{}{
+		"name": "foo",
+		"rule": []interface{}{
+			map[string]interface{}{
+				"escalation_delay_in_minutes": 10,
+				"target":                      []interface{}{map[string]interface{}{"id": "PUSER"}},
+			},
+		},
+	}
+	apply := func(state *sdkterraform.InstanceState) *sdkterraform.InstanceState {
+		diff, err := res.Diff(ctx, state, sdkterraform.NewResourceConfigRaw(raw), meta)
+		if err != nil {
+			t.Fatal(err)
+		}
+		state, diags := res.Apply(ctx, state, diff, meta)
+		if diags.HasError() {
+			t.Fatalf("unexpected error: %v", diags)
+		}
+		return state
+	}
+	assignedLabels := func(id string) []string {
+		resp, _, err := client.Tags.ListTagsForEntity("escalation_policies", id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var labels []string
+		for _, tag := range resp.Tags {
+			labels = append(labels, tag.Label)
+		}
+		return labels
+	}
+
+	state := apply(&sdkterraform.InstanceState{RawConfig: testRawConfig(res, nil)})
+	if got := assignedLabels(state.ID); !reflect.DeepEqual(got, []string{"owner:sre", "env:prod"}) {
+		t.Errorf("expected the default tags to be assigned, got %v", got)
+	}
+	if got := state.Attributes["default_tags.#"]; got != "2" {
+		t.Errorf("expected the default tags in the state, got %q", got)
+	}
+
+	// Unassigning a default tag elsewhere shows up on refresh, and the next
+	// apply assigns it again.
+	_, err = client.Tags.Assign("escalation_policies", state.ID, &pagerduty.TagAssignments{
+		Remove: []*pagerduty.TagAssignment{{Type: "tag_reference", TagID: owner.ID}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	d := res.Data(state)
+	if err := resourcePagerDutyEscalationPolicyRead(d, meta); err != nil {
+		t.Fatal(err)
+	}
+	tags := d.Get("default_tags").(*schema.Set)
+	if tags.Len() != 1 || !tags.Contains("env:prod") {
+		t.Errorf("expected only the assigned default tags to be read, got %v", tags.List())
+	}
+
+	state = d.State()
+	state.RawConfig = testRawConfig(res, nil)
+	diff, err := res.Diff(ctx, state, sdkterraform.NewResourceConfigRaw(raw), meta)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff == nil || diff.Attributes["default_tags.#"] == nil || diff.Attributes["default_tags.#"].New != "2" {
+		t.Fatalf("expected the missing default tag to be planned, got %+v", diff)
+	}
+	state = apply(state)
+	if got := assignedLabels(state.ID); !reflect.DeepEqual(got, []string{"env:prod", "owner:sre"}) {
+		t.Errorf("expected the missing default tag to be assigned again, got %v", got)
+	}
+}
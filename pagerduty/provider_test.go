@@ -1,14 +1,19 @@
 package pagerduty
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"net/url"
 	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
+	"github.com/PagerDuty/terraform-provider-pagerduty/util/mockapi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	sdkterraform "github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
@@ -19,8 +24,12 @@ var testAccProviders map[string]*schema.Provider
 var testAccProvider *schema.Provider
 var testAccProviderFactories map[string]func() (*schema.Provider, error)
 
+// testAccAPIURLOverride is the URL of the mock API acceptance tests run
+// against when PAGERDUTY_ACC_MOCK_API is set.
+var testAccAPIURLOverride = mockapi.StartForAcceptanceTests()
+
 func init() {
-	testAccProvider = Provider(IsNotMuxed)
+	testAccProvider = testAccWithAPIURLOverride(Provider(IsNotMuxed))
 	testAccProviders = map[string]*schema.Provider{
 		"pagerduty": testAccProvider,
 	}
@@ -31,6 +40,24 @@ func init() {
 	}
 }
 
+// testAccWithAPIURLOverride makes the provider `p` use the mock API when
+// acceptance tests run against it, unless `api_url_override` is configured.
+func testAccWithAPIURLOverride(p *schema.Provider) *schema.Provider {
+	if testAccAPIURLOverride == "" {
+		return p
+	}
+
+	configure := p.ConfigureContextFunc
+	p.ConfigureContextFunc = func(ctx context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
+		meta, diags := configure(ctx, d)
+		if config, ok := meta.(*Config); ok && config.ApiUrlOverride == "" {
+			config.ApiUrlOverride = testAccAPIURLOverride
+		}
+		return meta, diags
+	}
+	return p
+}
+
 func TestProvider(t *testing.T) {
 	if err := Provider(IsNotMuxed).InternalValidate(); err != nil {
 		t.Fatalf("err: %s", err)
@@ -41,6 +68,127 @@ func TestProviderImpl(t *testing.T) {
 	var _ *schema.Provider = Provider(IsNotMuxed)
 }
 
+func TestProviderProfile(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	for _, k := range []string{"PAGERDUTY_TOKEN", "PAGERDUTY_USER_TOKEN", "PAGERDUTY_SERVICE_REGION", "PAGERDUTY_PROFILE", "PAGERDUTY_CLIENT_ID", "PAGERDUTY_CLIENT_SECRET", "PAGERDUTY_SUBDOMAIN"} {
+		t.Setenv(k, "")
+	}
+	if err := os.MkdirAll(filepath.Join(home, ".pagerduty"), 0o700); err != nil {
+		t.Fatal(err)
+	}
+	credentials := `[eu]
+token          = eu-token
+user_token     = eu-user-token
+service_region = eu
+
+[oauth]
+pd_client_id     = client
+pd_client_secret = secret
+pd_subdomain     = acme
+`
+	if err := os.WriteFile(filepath.Join(home, ".pagerduty", "credentials"), []byte(credentials), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	configure := func(raw map[string]interface{}) (*Config, diag.Diagnostics) {
+		p := Provider(IsNotMuxed)
+		diags := p.Configure(context.Background(), sdkterraform.NewResourceConfigRaw(raw))
+		if diags.HasError() {
+			return nil, diags
+		}
+		return p.Meta().(*Config), diags
+	}
+
+	config, diags := configure(map[string]interface{}{"profile": "eu"})
+	if diags.HasError() {
+		t.Fatal(diags)
+	}
+	if config.Token != "eu-token" || config.UserToken != "eu-user-token" || config.ApiUrl != "https://api.eu.pagerduty.com" {
+		t.Errorf("expected the credentials and region of the eu profile, got %+v", config)
+	}
+
+	config, diags = configure(map[string]interface{}{"profile": "eu", "token": "configured", "service_region": "us"})
+	if diags.HasError() {
+		t.Fatal(diags)
+	}
+	if config.Token != "configured" || config.ApiUrl != "https://api.pagerduty.com" {
+		t.Errorf("expected configured arguments to take precedence over the profile, got %+v", config)
+	}
+
+	config, diags = configure(map[string]interface{}{"profile": "oauth"})
+	if diags.HasError() {
+		t.Fatal(diags)
+	}
+	if *config.APITokenType != pagerduty.AuthTokenTypeScopedOauthToken {
+		t.Errorf("expected the oauth profile to use an App OAuth token, got %s", config.APITokenType)
+	}
+	if params := config.AppOauthScopedTokenParams; params == nil || params.ClientID != "client" || params.ClientSecret != "secret" || params.PDSubDomain != "acme" {
+		t.Errorf("expected the App OAuth credentials of the oauth profile, got %+v", params)
+	}
+
+	if _, diags := configure(map[string]interface{}{"profile": "missing"}); !diags.HasError() {
+		t.Error("expected an error for a missing profile")
+	}
+}
+
+func TestProviderCache(t *testing.T) {
+	t.Setenv("TF_PAGERDUTY_CACHE", "")
+	os.Unsetenv("TF_PAGERDUTY_CACHE")
+
+	// A file in place of the directory of the cache, which can't be opened.
+	blocked := filepath.Join(t.TempDir(), "blocked")
+	if err := os.WriteFile(blocked, nil, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	configure := func(cache map[string]interface{}) (*Config, diag.Diagnostics) {
+		p := Provider(IsNotMuxed)
+		diags := p.Configure(context.Background(), sdkterraform.NewResourceConfigRaw(map[string]interface{}{
+			"token": "foo",
+			"cache": []interface{}{cache},
+		}))
+		if diags.HasError() {
+			return nil, diags
+		}
+		return p.Meta().(*Config), diags
+	}
+
+	config, diags := configure(map[string]interface{}{"type": "memory", "max_age": "1m", "prefill": true})
+	if diags.HasError() {
+		t.Fatal(diags)
+	}
+	if config.Cache == nil || !config.CachePrefill {
+		t.Errorf("expected a prefilled cache, got %+v", config)
+	}
+
+	config, diags = configure(map[string]interface{}{"type": "none", "prefill": true})
+	if diags.HasError() {
+		t.Fatal(diags)
+	}
+	if config.Cache != nil || config.CachePrefill {
+		t.Errorf("expected no cache, got %+v", config)
+	}
+
+	if _, diags := configure(map[string]interface{}{"type": "file", "path": filepath.Join(blocked, "cache")}); !diags.HasError() {
+		t.Error("expected an error for a cache which can't be opened")
+	}
+
+	config, diags = configure(map[string]interface{}{"type": "file", "path": filepath.Join(blocked, "cache"), "failure_mode": "disable"})
+	if diags.HasError() {
+		t.Fatal(diags)
+	}
+	if config.Cache != nil || len(diags) != 1 || diags[0].Severity != diag.Warning {
+		t.Errorf("expected to carry on without caching with a warning, got %+v", diags)
+	}
+
+	t.Setenv("TF_PAGERDUTY_CACHE", "memory")
+	_, diags = configure(map[string]interface{}{"type": "memory"})
+	if diags.HasError() || len(diags) != 1 || diags[0].Severity != diag.Warning {
+		t.Errorf("expected a warning when both caches are configured, got %+v", diags)
+	}
+}
+
 func TestAccPagerDutyProviderAuthMethods_Basic(t *testing.T) {
 	username := fmt.Sprintf("tf-%s", acctest.RandString(5))
 	email := fmt.Sprintf("%s@foo.test", username)
@@ -283,8 +431,9 @@ func testAccPreCheckPagerDutyAbility(t *testing.T, ability string) {
 	}
 
 	config := &Config{
-		Token:     os.Getenv("PAGERDUTY_TOKEN"),
-		UserToken: os.Getenv("PAGERDUTY_USER_TOKEN"),
+		Token:          os.Getenv("PAGERDUTY_TOKEN"),
+		UserToken:      os.Getenv("PAGERDUTY_USER_TOKEN"),
+		ApiUrlOverride: testAccAPIURLOverride,
 	}
 
 	client, err := config.Client()
@@ -308,8 +457,9 @@ func testAccGetPagerDutyAccountDomain(t *testing.T) string {
 	}
 
 	config := &Config{
-		Token:     os.Getenv("PAGERDUTY_TOKEN"),
-		UserToken: os.Getenv("PAGERDUTY_USER_TOKEN"),
+		Token:          os.Getenv("PAGERDUTY_TOKEN"),
+		UserToken:      os.Getenv("PAGERDUTY_USER_TOKEN"),
+		ApiUrlOverride: testAccAPIURLOverride,
 	}
 
 	client, err := config.Client()
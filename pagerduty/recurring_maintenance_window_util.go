@@ -0,0 +1,315 @@
+package pagerduty
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxRecurrencePeriods bounds the number of periods a recurrence is expanded
+// over, so that a rule which never matches doesn't loop forever.
+const maxRecurrencePeriods = 100000
+
+var recurrenceWeekdays = map[string]time.Weekday{
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+	"SU": time.Sunday,
+}
+
+// recurrenceDay is a BYDAY value of a recurrence rule. A non-zero `n` picks
+// the nth such weekday of the month, counted from its end when negative.
+type recurrenceDay struct {
+	weekday time.Weekday
+	n       int
+}
+
+// recurrence is the subset of the iCalendar (RFC 5545) RRULE supported by
+// recurring maintenance windows.
+type recurrence struct {
+	freq       string
+	interval   int
+	byDay      []recurrenceDay
+	byMonthDay []int
+	count      int
+	until      time.Time
+}
+
+// parseRecurrence parses a recurrence rule such as
+// `FREQ=WEEKLY;INTERVAL=2;BYDAY=TU,TH`. FREQ can be DAILY, WEEKLY or MONTHLY,
+// along with the INTERVAL, BYDAY, BYMONTHDAY, COUNT and UNTIL parts.
+func parseRecurrence(rule string) (*recurrence, error) {
+	r := &recurrence{interval: 1}
+
+	rule = strings.TrimPrefix(strings.TrimSpace(rule), "RRULE:")
+	for _, part := range strings.Split(rule, ";") {
+		if part == "" {
+			continue
+		}
+		kv := strings.SplitN(part, "=", 2)
+		if len(kv) != 2 || kv[1] == "" {
+			return nil, fmt.Errorf("invalid recurrence rule part %q, expected NAME=VALUE", part)
+		}
+		name, value := strings.ToUpper(kv[0]), strings.ToUpper(kv[1])
+
+		var err error
+		switch name {
+		case "FREQ":
+			if value != "DAILY" && value != "WEEKLY" && value != "MONTHLY" {
+				return nil, fmt.Errorf("unsupported FREQ %q, expected DAILY, WEEKLY or MONTHLY", value)
+			}
+			r.freq = value
+		case "INTERVAL":
+			r.interval, err = strconv.Atoi(value)
+			if err != nil || r.interval < 1 {
+				return nil, fmt.Errorf("invalid INTERVAL %q, expected a positive integer", value)
+			}
+		case "COUNT":
+			r.count, err = strconv.Atoi(value)
+			if err != nil || r.count < 1 {
+				return nil, fmt.Errorf("invalid COUNT %q, expected a positive integer", value)
+			}
+		case "UNTIL":
+			r.until, err = parseRecurrenceUntil(value)
+			if err != nil {
+				return nil, err
+			}
+		case "BYDAY":
+			for _, v := range strings.Split(value, ",") {
+				day, err := parseRecurrenceDay(v)
+				if err != nil {
+					return nil, err
+				}
+				r.byDay = append(r.byDay, day)
+			}
+		case "BYMONTHDAY":
+			for _, v := range strings.Split(value, ",") {
+				day, err := strconv.Atoi(v)
+				if err != nil || day == 0 || day < -31 || day > 31 {
+					return nil, fmt.Errorf("invalid BYMONTHDAY %q, expected a day of the month between 1 and 31, or between -31 and -1", v)
+				}
+				r.byMonthDay = append(r.byMonthDay, day)
+			}
+		default:
+			return nil, fmt.Errorf("unsupported recurrence rule part %q", name)
+		}
+	}
+
+	if r.freq == "" {
+		return nil, fmt.Errorf("recurrence rule must have a FREQ")
+	}
+	if r.count > 0 && !r.until.IsZero() {
+		return nil, fmt.Errorf("COUNT and UNTIL can't both be set")
+	}
+	if r.freq != "MONTHLY" {
+		if len(r.byMonthDay) > 0 {
+			return nil, fmt.Errorf("BYMONTHDAY is only supported with FREQ=MONTHLY")
+		}
+		for _, d := range r.byDay {
+			if d.n != 0 {
+				return nil, fmt.Errorf("BYDAY with an ordinal is only supported with FREQ=MONTHLY")
+			}
+		}
+	}
+
+	return r, nil
+}
+
+func parseRecurrenceDay(v string) (recurrenceDay, error) {
+	if len(v) < 2 {
+		return recurrenceDay{}, fmt.Errorf("invalid BYDAY %q", v)
+	}
+	weekday, ok := recurrenceWeekdays[v[len(v)-2:]]
+	if !ok {
+		return recurrenceDay{}, fmt.Errorf("invalid BYDAY %q, expected one of MO, TU, WE, TH, FR, SA or SU, optionally preceded by an ordinal", v)
+	}
+	day := recurrenceDay{weekday: weekday}
+	if ordinal := v[:len(v)-2]; ordinal != "" {
+		n, err := strconv.Atoi(ordinal)
+		if err != nil || n == 0 || n < -5 || n > 5 {
+			return recurrenceDay{}, fmt.Errorf("invalid BYDAY %q, expected an ordinal between 1 and 5, or between -5 and -1", v)
+		}
+		day.n = n
+	}
+	return day, nil
+}
+
+func parseRecurrenceUntil(v string) (time.Time, error) {
+	for _, layout := range []string{"20060102T150405Z", "20060102"} {
+		if t, err := time.Parse(layout, v); err == nil {
+			if layout == "20060102" {
+				// A date includes the whole day.
+				t = t.AddDate(0, 0, 1).Add(-time.Second)
+			}
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid UNTIL %q, expected a UTC date-time such as 20240131T235959Z, or a date such as 20240131", v)
+}
+
+// occurrences returns the start times of the occurrences of `r`, starting at
+// `start`, which last `duration` and overlap with [from, to). The time of day
+// of the occurrences is the one of `start`, in its location, so it doesn't
+// shift with daylight saving time.
+func (r *recurrence) occurrences(start time.Time, duration time.Duration, from, to time.Time) []time.Time {
+	var result []time.Time
+	n := 0
+	for period := 0; period < maxRecurrencePeriods; period++ {
+		first, candidates := r.period(start, period)
+		if !first.Before(to) {
+			break
+		}
+		for _, c := range candidates {
+			if c.Before(start) {
+				continue
+			}
+			n++
+			if r.count > 0 && n > r.count {
+				return result
+			}
+			if !r.until.IsZero() && c.After(r.until) {
+				return result
+			}
+			if !c.Before(to) {
+				return result
+			}
+			if c.Add(duration).After(from) {
+				result = append(result, c)
+			}
+		}
+	}
+	return result
+}
+
+// period returns the first day of the nth period of `r` from `start`, and the
+// sorted start times of the occurrences during that period.
+func (r *recurrence) period(start time.Time, n int) (time.Time, []time.Time) {
+	y, m, d := start.Date()
+	hh, mm, ss := start.Clock()
+	loc := start.Location()
+	at := func(y int, m time.Month, d int) time.Time {
+		return time.Date(y, m, d, hh, mm, ss, 0, loc)
+	}
+
+	var first time.Time
+	var candidates []time.Time
+	switch r.freq {
+	case "DAILY":
+		first = at(y, m, d+n*r.interval)
+		if r.matchesWeekday(first.Weekday()) {
+			candidates = append(candidates, first)
+		}
+	case "WEEKLY":
+		// Weeks start on Monday.
+		offset := (int(start.Weekday()) + 6) % 7
+		first = at(y, m, d-offset+7*n*r.interval)
+		for i := 0; i < 7; i++ {
+			day := at(y, m, d-offset+7*n*r.interval+i)
+			if len(r.byDay) == 0 && day.Weekday() == start.Weekday() || len(r.byDay) > 0 && r.matchesWeekday(day.Weekday()) {
+				candidates = append(candidates, day)
+			}
+		}
+	case "MONTHLY":
+		first = at(y, m+time.Month(n*r.interval), 1)
+		fy, fm, _ := first.Date()
+		daysInMonth := time.Date(fy, fm+1, 0, 0, 0, 0, 0, loc).Day()
+		days := map[int]bool{}
+		for _, md := range r.byMonthDay {
+			if md < 0 {
+				md = daysInMonth + md + 1
+			}
+			if md >= 1 && md <= daysInMonth {
+				days[md] = true
+			}
+		}
+		for _, bd := range r.byDay {
+			var matching []int
+			for day := 1; day <= daysInMonth; day++ {
+				if time.Date(fy, fm, day, 0, 0, 0, 0, loc).Weekday() == bd.weekday {
+					matching = append(matching, day)
+				}
+			}
+			switch {
+			case bd.n == 0:
+				for _, day := range matching {
+					days[day] = true
+				}
+			case bd.n > 0 && bd.n <= len(matching):
+				days[matching[bd.n-1]] = true
+			case bd.n < 0 && -bd.n <= len(matching):
+				days[matching[len(matching)+bd.n]] = true
+			}
+		}
+		if len(r.byMonthDay) == 0 && len(r.byDay) == 0 && d <= daysInMonth {
+			days[d] = true
+		}
+		for day := range days {
+			candidates = append(candidates, at(fy, fm, day))
+		}
+		sort.Slice(candidates, func(i, j int) bool { return candidates[i].Before(candidates[j]) })
+	}
+	return first, candidates
+}
+
+func (r *recurrence) matchesWeekday(weekday time.Weekday) bool {
+	if len(r.byDay) == 0 {
+		return true
+	}
+	for _, d := range r.byDay {
+		if d.weekday == weekday {
+			return true
+		}
+	}
+	return false
+}
+
+// recurringMaintenanceWindow is a concrete maintenance window created for a
+// recurring maintenance window.
+type recurringMaintenanceWindow struct {
+	id    string
+	start time.Time
+	end   time.Time
+}
+
+// planRecurringMaintenanceWindows compares the existing windows with the
+// occurrences expected in the future, starting at `occurrences` and lasting
+// `duration`. It returns the windows to keep, the ones to delete because
+// they don't match an occurrence any more, and the occurrences to create
+// windows for. Windows which ended are left out, and windows in progress are
+// kept, since their start can't be changed.
+func planRecurringMaintenanceWindows(existing []recurringMaintenanceWindow, occurrences []time.Time, duration time.Duration, now time.Time) (keep, remove []recurringMaintenanceWindow, create []time.Time) {
+	matched := map[int]bool{}
+	for _, w := range existing {
+		if !w.end.After(now) {
+			continue
+		}
+		if !w.start.After(now) {
+			keep = append(keep, w)
+			continue
+		}
+		found := false
+		for i, o := range occurrences {
+			if !matched[i] && o.Equal(w.start) && o.Add(duration).Equal(w.end) {
+				matched[i] = true
+				found = true
+				break
+			}
+		}
+		if found {
+			keep = append(keep, w)
+		} else {
+			remove = append(remove, w)
+		}
+	}
+	for i, o := range occurrences {
+		if !matched[i] && o.After(now) {
+			create = append(create, o)
+		}
+	}
+	return keep, remove, create
+}
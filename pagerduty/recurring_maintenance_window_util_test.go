@@ -0,0 +1,180 @@
+package pagerduty
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseRecurrence(t *testing.T) {
+	valid := []string{
+		"FREQ=DAILY",
+		"RRULE:FREQ=WEEKLY;INTERVAL=2;BYDAY=TU,TH",
+		"freq=monthly;byday=-1fr",
+		"FREQ=MONTHLY;BYMONTHDAY=1,15,-1;COUNT=6",
+		"FREQ=WEEKLY;UNTIL=20250131T235959Z",
+		"FREQ=DAILY;UNTIL=20250131",
+	}
+	for _, rule := range valid {
+		if _, err := parseRecurrence(rule); err != nil {
+			t.Errorf("%s: unexpected error: %s", rule, err)
+		}
+	}
+
+	invalid := map[string]string{
+		"BYDAY=MO":                            "must have a FREQ",
+		"FREQ=YEARLY":                         "unsupported FREQ",
+		"FREQ=DAILY;INTERVAL=0":               "invalid INTERVAL",
+		"FREQ=WEEKLY;BYDAY=XX":                "invalid BYDAY",
+		"FREQ=WEEKLY;BYDAY=1MO":               "only supported with FREQ=MONTHLY",
+		"FREQ=DAILY;BYMONTHDAY=1":             "only supported with FREQ=MONTHLY",
+		"FREQ=MONTHLY;BYMONTHDAY=32":          "invalid BYMONTHDAY",
+		"FREQ=DAILY;COUNT=2;UNTIL=20250131":   "can't both be set",
+		"FREQ=DAILY;BYHOUR=2":                 "unsupported recurrence rule part",
+		"FREQ=DAILY;UNTIL=2025-01-31":         "invalid UNTIL",
+		"FREQ=DAILY;INTERVAL":                 "expected NAME=VALUE",
+		"FREQ=MONTHLY;BYDAY=6MO":              "invalid BYDAY",
+		"FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,TUE": "invalid BYDAY",
+	}
+	for rule, expected := range invalid {
+		_, err := parseRecurrence(rule)
+		if err == nil || !strings.Contains(err.Error(), expected) {
+			t.Errorf("%s: expected error %q, got %v", rule, expected, err)
+		}
+	}
+}
+
+func TestRecurrenceOccurrences(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatal(err)
+	}
+	at := func(y int, m time.Month, d, hh int) time.Time {
+		return time.Date(y, m, d, hh, 0, 0, 0, loc)
+	}
+	// Wednesday, Jan 3 2024.
+	start := at(2024, time.January, 3, 2)
+
+	cases := []struct {
+		rule     string
+		duration time.Duration
+		from, to time.Time
+		expected []time.Time
+	}{
+		{
+			rule: "FREQ=DAILY;INTERVAL=2",
+			from: start, to: at(2024, time.January, 10, 0),
+			expected: []time.Time{at(2024, time.January, 3, 2), at(2024, time.January, 5, 2), at(2024, time.January, 7, 2), at(2024, time.January, 9, 2)},
+		},
+		{
+			rule: "FREQ=WEEKLY;BYDAY=MO,WE",
+			from: start, to: at(2024, time.January, 16, 0),
+			expected: []time.Time{at(2024, time.January, 3, 2), at(2024, time.January, 8, 2), at(2024, time.January, 10, 2), at(2024, time.January, 15, 2)},
+		},
+		{
+			// Every other week, on the weekday of the start.
+			rule: "FREQ=WEEKLY;INTERVAL=2",
+			from: at(2024, time.January, 10, 0), to: at(2024, time.February, 1, 0),
+			expected: []time.Time{at(2024, time.January, 17, 2), at(2024, time.January, 31, 2)},
+		},
+		{
+			// The window in progress at `from` is included.
+			rule: "FREQ=DAILY", duration: 4 * time.Hour,
+			from: at(2024, time.January, 5, 3), to: at(2024, time.January, 6, 3),
+			expected: []time.Time{at(2024, time.January, 5, 2), at(2024, time.January, 6, 2)},
+		},
+		{
+			// The time of day doesn't shift with daylight saving time.
+			rule: "FREQ=WEEKLY",
+			from: at(2024, time.March, 5, 0), to: at(2024, time.March, 14, 0),
+			expected: []time.Time{at(2024, time.March, 6, 2), at(2024, time.March, 13, 2)},
+		},
+		{
+			rule: "FREQ=MONTHLY;BYDAY=-1FR",
+			from: start, to: at(2024, time.April, 1, 0),
+			expected: []time.Time{at(2024, time.January, 26, 2), at(2024, time.February, 23, 2), at(2024, time.March, 29, 2)},
+		},
+		{
+			rule: "FREQ=MONTHLY;BYDAY=2TU",
+			from: start, to: at(2024, time.March, 1, 0),
+			expected: []time.Time{at(2024, time.January, 9, 2), at(2024, time.February, 13, 2)},
+		},
+		{
+			rule: "FREQ=MONTHLY;BYMONTHDAY=1,-1",
+			from: start, to: at(2024, time.March, 2, 0),
+			expected: []time.Time{at(2024, time.January, 31, 2), at(2024, time.February, 1, 2), at(2024, time.February, 29, 2), at(2024, time.March, 1, 2)},
+		},
+		{
+			// Months without a 31st are skipped.
+			rule: "FREQ=MONTHLY",
+			from: at(2024, time.January, 31, 0), to: at(2024, time.June, 1, 0),
+			expected: []time.Time{at(2024, time.January, 31, 2), at(2024, time.March, 31, 2), at(2024, time.May, 31, 2)},
+		},
+		{
+			// The occurrences before `from` count towards COUNT.
+			rule: "FREQ=DAILY;COUNT=3",
+			from: at(2024, time.January, 4, 12), to: at(2024, time.January, 31, 0),
+			expected: []time.Time{at(2024, time.January, 5, 2)},
+		},
+		{
+			rule: "FREQ=DAILY;UNTIL=20240105",
+			from: start, to: at(2024, time.January, 31, 0),
+			expected: []time.Time{at(2024, time.January, 3, 2), at(2024, time.January, 4, 2), at(2024, time.January, 5, 2)},
+		},
+	}
+
+	for _, c := range cases {
+		r, err := parseRecurrence(c.rule)
+		if err != nil {
+			t.Fatal(err)
+		}
+		s := start
+		if c.rule == "FREQ=MONTHLY" {
+			s = at(2024, time.January, 31, 2)
+		}
+		duration := c.duration
+		if duration == 0 {
+			duration = time.Hour
+		}
+		got := r.occurrences(s, duration, c.from, c.to)
+		if len(got) != len(c.expected) {
+			t.Errorf("%s: expected %v, got %v", c.rule, c.expected, got)
+			continue
+		}
+		for i := range got {
+			if !got[i].Equal(c.expected[i]) {
+				t.Errorf("%s: expected %v, got %v", c.rule, c.expected, got)
+				break
+			}
+		}
+	}
+}
+
+func TestPlanRecurringMaintenanceWindows(t *testing.T) {
+	now := time.Date(2024, time.January, 10, 12, 0, 0, 0, time.UTC)
+	hour := func(h int) time.Time { return now.Add(time.Duration(h) * time.Hour) }
+
+	existing := []recurringMaintenanceWindow{
+		{id: "ENDED", start: hour(-26), end: hour(-24)},
+		{id: "ONGOING", start: hour(-1), end: hour(1)},
+		{id: "MATCHING", start: hour(24), end: hour(26)},
+		{id: "STALE", start: hour(48), end: hour(49)},
+	}
+	occurrences := []time.Time{hour(-1), hour(24), hour(48), hour(72)}
+
+	keep, remove, create := planRecurringMaintenanceWindows(existing, occurrences, 2*time.Hour, now)
+
+	var ids []string
+	for _, w := range keep {
+		ids = append(ids, w.id)
+	}
+	if strings.Join(ids, ",") != "ONGOING,MATCHING" {
+		t.Errorf("expected to keep ONGOING and MATCHING, got %v", ids)
+	}
+	if len(remove) != 1 || remove[0].id != "STALE" {
+		t.Errorf("expected to remove STALE, got %v", remove)
+	}
+	if len(create) != 2 || !create[0].Equal(hour(48)) || !create[1].Equal(hour(72)) {
+		t.Errorf("expected to create windows at %v and %v, got %v", hour(48), hour(72), create)
+	}
+}
@@ -1,6 +1,7 @@
 package pagerduty
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"net/http"
@@ -21,6 +22,7 @@ func resourcePagerDutyEscalationPolicy() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
+		CustomizeDiff: customizeEscalationPolicyDiff,
 		Schema: map[string]*schema.Schema{
 			"name": {
 				Type:             schema.TypeString,
@@ -40,11 +42,21 @@ func resourcePagerDutyEscalationPolicy() *schema.Resource {
 			"teams": {
 				Type:     schema.TypeList,
 				Optional: true,
+				// Planned as the default teams of the provider when not
+				// configured.
+				Computed: true,
 				Elem: &schema.Schema{
 					Type: schema.TypeString,
 				},
 				MaxItems: 1,
 			},
+			"default_tags": {
+				Type:     schema.TypeSet,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
 			"rule": {
 				Type:     schema.TypeList,
 				Required: true,
@@ -106,6 +118,13 @@ func resourcePagerDutyEscalationPolicy() *schema.Resource {
 	}
 }
 
+func customizeEscalationPolicyDiff(ctx context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+	if err := customizeDefaultTeamsDiff(1)(ctx, diff, meta); err != nil {
+		return err
+	}
+	return customizeDefaultTagsDiff(ctx, diff, meta)
+}
+
 func buildEscalationPolicyStruct(d *schema.ResourceData) *pagerduty.EscalationPolicy {
 	escalationPolicy := &pagerduty.EscalationPolicy{
 		Name:            d.Get("name").(string),
@@ -137,7 +156,7 @@ func resourcePagerDutyEscalationPolicyCreate(d *schema.ResourceData, meta interf
 
 	log.Printf("[INFO] Creating PagerDuty escalation policy: %s", escalationPolicy.Name)
 
-	return retry.Retry(5*time.Minute, func() *retry.RetryError {
+	err = retry.Retry(5*time.Minute, func() *retry.RetryError {
 		escalationPolicy, _, err := client.EscalationPolicies.Create(escalationPolicy)
 		if err != nil {
 			if isErrCode(err, 429) {
@@ -157,11 +176,26 @@ func resourcePagerDutyEscalationPolicyCreate(d *schema.ResourceData, meta interf
 		}
 		return nil
 	})
+	if err != nil {
+		return err
+	}
+
+	if err := assignDefaultTags(d, meta, "escalation_policies"); err != nil {
+		return err
+	}
+	return readDefaultTags(d, meta, "escalation_policies")
 }
 
 func resourcePagerDutyEscalationPolicyRead(d *schema.ResourceData, meta interface{}) error {
 	log.Printf("[INFO] Reading PagerDuty escalation policy: %s", d.Id())
-	return fetchEscalationPolicy(d, meta, handleNotFoundError)
+	if escalationPolicy, ok := meta.(*Config).snapshotEscalationPolicy(d.Id()); ok {
+		if err := setResourceEPProps(d, escalationPolicy); err != nil {
+			return err
+		}
+	} else if err := fetchEscalationPolicy(d, meta, handleNotFoundError); err != nil || d.Id() == "" {
+		return err
+	}
+	return readDefaultTags(d, meta, "escalation_policies")
 }
 
 func fetchEscalationPolicy(d *schema.ResourceData, meta interface{}, errCallback func(error, *schema.ResourceData) error) error {
@@ -231,6 +265,7 @@ func resourcePagerDutyEscalationPolicyUpdate(d *schema.ResourceData, meta interf
 	if err != nil {
 		return err
 	}
+	meta.(*Config).forgetSnapshotObject("escalation_policies", d.Id())
 
 	escalationPolicy := buildEscalationPolicyStruct(d)
 
@@ -238,7 +273,7 @@ func resourcePagerDutyEscalationPolicyUpdate(d *schema.ResourceData, meta interf
 
 	_, _, err = client.EscalationPolicies.Update(d.Id(), escalationPolicy)
 	if err == nil {
-		return nil
+		return updateEscalationPolicyDefaultTags(d, meta)
 	}
 
 	if isErrCode(err, http.StatusForbidden) || isMalformedForbiddenError(err) {
@@ -273,7 +308,17 @@ func resourcePagerDutyEscalationPolicyUpdate(d *schema.ResourceData, meta interf
 		return retryErr
 	}
 
-	return nil
+	return updateEscalationPolicyDefaultTags(d, meta)
+}
+
+func updateEscalationPolicyDefaultTags(d *schema.ResourceData, meta interface{}) error {
+	if !d.HasChange("default_tags") {
+		return nil
+	}
+	if err := assignDefaultTags(d, meta, "escalation_policies"); err != nil {
+		return err
+	}
+	return readDefaultTags(d, meta, "escalation_policies")
 }
 
 func resourcePagerDutyEscalationPolicyDelete(d *schema.ResourceData, meta interface{}) error {
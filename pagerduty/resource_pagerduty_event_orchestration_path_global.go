@@ -212,6 +212,7 @@ func resourcePagerDutyEventOrchestrationPathGlobalCreate(ctx context.Context, d
 
 func resourcePagerDutyEventOrchestrationPathGlobalUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
+	conditionsChanged := d.IsNewResource() || d.HasChange("set")
 
 	client, err := meta.(*Config).Client()
 	if err != nil {
@@ -245,6 +246,10 @@ func resourcePagerDutyEventOrchestrationPathGlobalUpdate(ctx context.Context, d
 
 	setEventOrchestrationPathGlobalProps(d, globalPath)
 
+	if conditionsChanged {
+		diags = append(diags, cacheVariableReferenceDiagnostics(ctx, client, pagerduty.CacheVariableTypeGlobal, payload.Parent.ID, eventOrchestrationPathConditionExpressions(d.Get("set")))...)
+	}
+
 	return convertEventOrchestrationPathWarningsToDiagnostics(warnings, diags)
 }
 
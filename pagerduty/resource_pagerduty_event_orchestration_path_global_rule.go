@@ -0,0 +1,75 @@
+package pagerduty
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/heimweh/go-pagerduty/pagerduty"
+)
+
+// resourcePagerDutyEventOrchestrationPathGlobalRule manages a single rule of
+// the Global Orchestration of an Event Orchestration, leaving the other rules
+// alone, so several Terraform configurations can each own some of its rules.
+func resourcePagerDutyEventOrchestrationPathGlobalRule() *schema.Resource {
+	s := eventOrchestrationPathRuleSchema(eventOrchestrationPathGlobalRuleActionsSchema)
+	s["set"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Optional: true,
+		ForceNew: true,
+		Default:  "start",
+	}
+
+	return &schema.Resource{
+		ReadContext:   resourcePagerDutyEventOrchestrationPathGlobalRuleRead,
+		CreateContext: resourcePagerDutyEventOrchestrationPathGlobalRuleCreate,
+		UpdateContext: resourcePagerDutyEventOrchestrationPathGlobalRuleUpdate,
+		DeleteContext: resourcePagerDutyEventOrchestrationPathGlobalRuleDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourcePagerDutyEventOrchestrationPathGlobalRuleImport,
+		},
+		CustomizeDiff: func(ctx context.Context, diff *schema.ResourceDiff, i interface{}) error {
+			return checkExtractionAttributes(diff, "actions.0.extraction")
+		},
+		Schema: s,
+	}
+}
+
+func resourcePagerDutyEventOrchestrationPathGlobalRuleCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	diags := createEventOrchestrationPathRule(ctx, d, meta, pagerduty.PathTypeGlobal, d.Get("set").(string))
+	if diags.HasError() {
+		return diags
+	}
+	return append(diags, globalRuleCacheVariableReferenceDiagnostics(ctx, d, meta)...)
+}
+
+func resourcePagerDutyEventOrchestrationPathGlobalRuleRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return readEventOrchestrationPathRule(ctx, d, meta, pagerduty.PathTypeGlobal)
+}
+
+func resourcePagerDutyEventOrchestrationPathGlobalRuleUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conditionsChanged := d.HasChange("condition")
+	diags := updateEventOrchestrationPathRule(ctx, d, meta, pagerduty.PathTypeGlobal)
+	if diags.HasError() || !conditionsChanged {
+		return diags
+	}
+	return append(diags, globalRuleCacheVariableReferenceDiagnostics(ctx, d, meta)...)
+}
+
+// globalRuleCacheVariableReferenceDiagnostics warns about the conditions of
+// the rule referring to cache variables which don't exist.
+func globalRuleCacheVariableReferenceDiagnostics(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, err := meta.(*Config).Client()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	return cacheVariableReferenceDiagnostics(ctx, client, pagerduty.CacheVariableTypeGlobal, d.Get("event_orchestration").(string), eventOrchestrationConditionExpressions(d.Get("condition")))
+}
+
+func resourcePagerDutyEventOrchestrationPathGlobalRuleDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return deleteEventOrchestrationPathRule(ctx, d, meta, pagerduty.PathTypeGlobal)
+}
+
+func resourcePagerDutyEventOrchestrationPathGlobalRuleImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	return importEventOrchestrationPathRule(ctx, d, meta, pagerduty.PathTypeGlobal)
+}
@@ -0,0 +1,121 @@
+package pagerduty
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/heimweh/go-pagerduty/pagerduty"
+)
+
+func TestAccPagerDutyEventOrchestrationPathGlobalRule_Basic(t *testing.T) {
+	team := fmt.Sprintf("tf-name-%s", acctest.RandString(5))
+	escalationPolicy := fmt.Sprintf("tf-%s", acctest.RandString(5))
+	service := fmt.Sprintf("tf-%s", acctest.RandString(5))
+	orchestration := fmt.Sprintf("tf-orchestration-%s", acctest.RandString(5))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckPagerDutyEventOrchestrationGlobalRuleDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccCheckPagerDutyEventOrchestrationGlobalRuleConfig(team, escalationPolicy, service, orchestration, `extraction { target = "event.summary" }`),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("regex and template cannot both be null"),
+			},
+			{
+				Config: testAccCheckPagerDutyEventOrchestrationGlobalRuleConfig(team, escalationPolicy, service, orchestration, `severity = "critical"`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("pagerduty_event_orchestration_global_rule.start", "set", "start"),
+					resource.TestCheckResourceAttr("pagerduty_event_orchestration_global_rule.start", "actions.0.route_to", "enrich"),
+					resource.TestCheckResourceAttr("pagerduty_event_orchestration_global_rule.enrich", "set", "enrich"),
+					resource.TestCheckResourceAttr("pagerduty_event_orchestration_global_rule.enrich", "actions.0.severity", "critical"),
+					testAccCheckPagerDutyEventOrchestrationGlobalRuleExists("pagerduty_event_orchestration_global_rule.enrich", "enrich"),
+				),
+			},
+			{
+				Config: testAccCheckPagerDutyEventOrchestrationGlobalRuleConfig(team, escalationPolicy, service, orchestration, `annotate = "Enriched by Terraform"`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("pagerduty_event_orchestration_global_rule.enrich", "actions.0.severity", ""),
+					resource.TestCheckResourceAttr("pagerduty_event_orchestration_global_rule.enrich", "actions.0.annotate", "Enriched by Terraform"),
+					testAccCheckPagerDutyEventOrchestrationGlobalRuleExists("pagerduty_event_orchestration_global_rule.enrich", "enrich"),
+				),
+			},
+			{
+				ResourceName:      "pagerduty_event_orchestration_global_rule.enrich",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: testAccCheckPagerDutyEventOrchestrationRuleImportID("pagerduty_event_orchestration_global_rule.enrich"),
+			},
+		},
+	})
+}
+
+func testAccCheckPagerDutyEventOrchestrationGlobalRuleDestroy(s *terraform.State) error {
+	client, _ := testAccProvider.Meta().(*Config).Client()
+	for _, r := range s.RootModule().Resources {
+		if r.Type != "pagerduty_event_orchestration_global_rule" {
+			continue
+		}
+		path, _, err := client.EventOrchestrationPaths.Get(r.Primary.Attributes["event_orchestration"], pagerduty.PathTypeGlobal)
+		if err != nil {
+			// The orchestration is gone along with its rules.
+			continue
+		}
+		if _, _, rule := findEventOrchestrationPathRule(path, r.Primary.ID); rule != nil {
+			return fmt.Errorf("Event Orchestration Global rule %s still exists", r.Primary.ID)
+		}
+	}
+	return nil
+}
+
+func testAccCheckPagerDutyEventOrchestrationGlobalRuleExists(rn, setID string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		r, ok := s.RootModule().Resources[rn]
+		if !ok {
+			return fmt.Errorf("Not found: %s", rn)
+		}
+
+		client, _ := testAccProvider.Meta().(*Config).Client()
+		path, _, err := client.EventOrchestrationPaths.Get(r.Primary.Attributes["event_orchestration"], pagerduty.PathTypeGlobal)
+		if err != nil {
+			return err
+		}
+		set, _, rule := findEventOrchestrationPathRule(path, r.Primary.ID)
+		if rule == nil {
+			return fmt.Errorf("Event Orchestration Global rule %s not found", r.Primary.ID)
+		}
+		if set.ID != setID {
+			return fmt.Errorf("Expected Event Orchestration Global rule %s in set %q, found it in %q", r.Primary.ID, setID, set.ID)
+		}
+		return nil
+	}
+}
+
+func testAccCheckPagerDutyEventOrchestrationGlobalRuleConfig(t, ep, s, o, enrichActions string) string {
+	return fmt.Sprintf("%s%s", createBaseGlobalOrchConfig(t, ep, s, o),
+		fmt.Sprintf(`resource "pagerduty_event_orchestration_global_rule" "enrich" {
+			event_orchestration = pagerduty_event_orchestration.orch.id
+			set = "enrich"
+			label = "enrich"
+			actions {
+				%s
+			}
+		}
+
+		resource "pagerduty_event_orchestration_global_rule" "start" {
+			event_orchestration = pagerduty_event_orchestration.orch.id
+			label = "start"
+			actions {
+				route_to = pagerduty_event_orchestration_global_rule.enrich.set
+			}
+			condition {
+				expression = "event.summary matches part 'database'"
+			}
+		}
+	`, enrichActions))
+}
@@ -11,9 +11,46 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/heimweh/go-pagerduty/pagerduty"
 )
 
+var eventOrchestrationPathRouterRuleActionsSchema = map[string]*schema.Schema{
+	"dynamic_route_to": {
+		Type:     schema.TypeList,
+		Optional: true,
+		MaxItems: 1, // the fallbacks of a Dynamic Routing rule are the rules following it
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"lookup_by": {
+					Type:         schema.TypeString,
+					Required:     true,
+					ValidateFunc: validation.StringInSlice([]string{"service_id", "service_name"}, false),
+				},
+				"regex": {
+					Type:     schema.TypeString,
+					Required: true,
+				},
+				"source": {
+					Type:     schema.TypeString,
+					Required: true,
+				},
+			},
+		},
+	},
+	"route_to": {
+		Type:     schema.TypeString,
+		Optional: true,
+		ValidateFunc: func(v interface{}, key string) (warns []string, errs []error) {
+			value := v.(string)
+			if value == "unrouted" {
+				errs = append(errs, fmt.Errorf("route_to within a set's rule has to be a Service ID. Got: %q", v))
+			}
+			return
+		},
+	},
+}
+
 func resourcePagerDutyEventOrchestrationPathRouter() *schema.Resource {
 	return &schema.Resource{
 		ReadContext:   resourcePagerDutyEventOrchestrationPathRouterRead,
@@ -64,39 +101,7 @@ func resourcePagerDutyEventOrchestrationPathRouter() *schema.Resource {
 										Required: true,
 										MaxItems: 1, // there can only be one action for router
 										Elem: &schema.Resource{
-											Schema: map[string]*schema.Schema{
-												"dynamic_route_to": {
-													Type:     schema.TypeList,
-													Optional: true,
-													Elem: &schema.Resource{
-														Schema: map[string]*schema.Schema{
-															"lookup_by": {
-																Type:     schema.TypeString,
-																Required: true,
-															},
-															"regex": {
-																Type:     schema.TypeString,
-																Required: true,
-															},
-															"source": {
-																Type:     schema.TypeString,
-																Required: true,
-															},
-														},
-													},
-												},
-												"route_to": {
-													Type:     schema.TypeString,
-													Optional: true,
-													ValidateFunc: func(v interface{}, key string) (warns []string, errs []error) {
-														value := v.(string)
-														if value == "unrouted" {
-															errs = append(errs, fmt.Errorf("route_to within a set's rule has to be a Service ID. Got: %q", v))
-														}
-														return
-													},
-												},
-											},
+											Schema: eventOrchestrationPathRouterRuleActionsSchema,
 										},
 									},
 									"disabled": {
@@ -176,7 +181,6 @@ func checkDynamicRoutingRule(context context.Context, diff *schema.ResourceDiff,
 			errorMsgs = append(errorMsgs, fmt.Sprintf("Dynamic Routing rules cannot have the `route_to` action"))
 		}
 	}
-
 	if len(errorMsgs) > 0 {
 		return fmt.Errorf("Invalid Dynamic Routing rule configuration:\n- %s", strings.Join(errorMsgs, "\n- "))
 	}
@@ -302,9 +306,38 @@ func resourcePagerDutyEventOrchestrationPathRouterUpdate(ctx context.Context, d
 		return diag.FromErr(retryErr)
 	}
 
+	diags = append(diags, unreachableRouterRulesDiagnostics(routerPath.Sets)...)
 	return convertEventOrchestrationPathWarningsToDiagnostics(warnings, diags)
 }
 
+// unreachableRouterRulesDiagnostics warns about the rules of a Router with a
+// Dynamic Routing rule which are never evaluated, because a rule before them
+// routes every event. The events the Dynamic Routing rule can't route fall
+// back to the rules following it, so a rule without conditions is meant to be
+// the last one, if not the `catch_all`.
+func unreachableRouterRulesDiagnostics(sets []*pagerduty.EventOrchestrationPathSet) diag.Diagnostics {
+	if len(sets) == 0 || len(sets[0].Rules) == 0 {
+		return nil
+	}
+	rules := sets[0].Rules
+	if rules[0].Actions == nil || rules[0].Actions.DynamicRouteTo == nil {
+		return nil
+	}
+
+	var diags diag.Diagnostics
+	for ri, rule := range rules[:len(rules)-1] {
+		if len(rule.Conditions) > 0 || rule.Disabled || rule.Actions == nil || rule.Actions.DynamicRouteTo != nil {
+			continue
+		}
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("Router rule %d routes every event", ri),
+			Detail:   fmt.Sprintf("Rule %d has no conditions, so the rules following it are never evaluated. Use the catch_all as the static default of the Dynamic Routing rule instead.", ri),
+		})
+	}
+	return diags
+}
+
 func buildRouterPathStructForUpdate(d *schema.ResourceData) *pagerduty.EventOrchestrationPath {
 	orchPath := &pagerduty.EventOrchestrationPath{
 		Parent: &pagerduty.EventOrchestrationPathReference{
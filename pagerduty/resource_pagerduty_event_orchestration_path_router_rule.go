@@ -0,0 +1,65 @@
+package pagerduty
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/heimweh/go-pagerduty/pagerduty"
+)
+
+// resourcePagerDutyEventOrchestrationPathRouterRule manages a single rule of
+// the Router of an Event Orchestration, leaving the other rules alone, so
+// several Terraform configurations can each own some of its rules.
+func resourcePagerDutyEventOrchestrationPathRouterRule() *schema.Resource {
+	return &schema.Resource{
+		ReadContext:   resourcePagerDutyEventOrchestrationPathRouterRuleRead,
+		CreateContext: resourcePagerDutyEventOrchestrationPathRouterRuleCreate,
+		UpdateContext: resourcePagerDutyEventOrchestrationPathRouterRuleUpdate,
+		DeleteContext: resourcePagerDutyEventOrchestrationPathRouterRuleDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourcePagerDutyEventOrchestrationPathRouterRuleImport,
+		},
+		CustomizeDiff: checkRouterRuleDynamicRoutingPlacement,
+		Schema:        eventOrchestrationPathRuleSchema(eventOrchestrationPathRouterRuleActionsSchema),
+	}
+}
+
+// checkRouterRuleDynamicRoutingPlacement rejects a Dynamic Routing rule
+// configured to be placed anywhere but at the top of the Router. Rules
+// appended to the Router are checked once the other rules are known.
+func checkRouterRuleDynamicRoutingPlacement(ctx context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+	if !isNonEmptyList(diff.Get("actions.0.dynamic_route_to")) {
+		return nil
+	}
+	if _, ok := diff.GetOk("after_rule_id"); ok || !diff.NewValueKnown("after_rule_id") {
+		return fmt.Errorf("The Dynamic Routing rule must be the first rule of the Router, so it can't have an `after_rule_id`; set `position` to 0 instead")
+	}
+	raw := diff.GetRawConfig()
+	if !raw.IsNull() && raw.IsKnown() && !raw.GetAttr("position").IsNull() && diff.Get("position").(int) != 0 {
+		return fmt.Errorf("The Dynamic Routing rule must be the first rule of the Router, got position %d", diff.Get("position").(int))
+	}
+	return nil
+}
+
+func resourcePagerDutyEventOrchestrationPathRouterRuleCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	// The Router can only have the "start" set.
+	return createEventOrchestrationPathRule(ctx, d, meta, pagerduty.PathTypeRouter, "start")
+}
+
+func resourcePagerDutyEventOrchestrationPathRouterRuleRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return readEventOrchestrationPathRule(ctx, d, meta, pagerduty.PathTypeRouter)
+}
+
+func resourcePagerDutyEventOrchestrationPathRouterRuleUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return updateEventOrchestrationPathRule(ctx, d, meta, pagerduty.PathTypeRouter)
+}
+
+func resourcePagerDutyEventOrchestrationPathRouterRuleDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return deleteEventOrchestrationPathRule(ctx, d, meta, pagerduty.PathTypeRouter)
+}
+
+func resourcePagerDutyEventOrchestrationPathRouterRuleImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	return importEventOrchestrationPathRule(ctx, d, meta, pagerduty.PathTypeRouter)
+}
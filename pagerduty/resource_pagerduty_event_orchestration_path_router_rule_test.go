@@ -0,0 +1,438 @@
+package pagerduty
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/PagerDuty/terraform-provider-pagerduty/util/mockapi"
+	"github.com/hashicorp/go-cty/cty"
+	sdkterraform "github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/heimweh/go-pagerduty/pagerduty"
+)
+
+func TestAccPagerDutyEventOrchestrationPathRouterRule_Basic(t *testing.T) {
+	team := fmt.Sprintf("tf-name-%s", acctest.RandString(5))
+	escalationPolicy := fmt.Sprintf("tf-%s", acctest.RandString(5))
+	service := fmt.Sprintf("tf-%s", acctest.RandString(5))
+	orchestration := fmt.Sprintf("tf-orchestration-%s", acctest.RandString(5))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckPagerDutyEventOrchestrationRouterRuleDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckPagerDutyEventOrchestrationRouterRuleConfig(team, escalationPolicy, service, orchestration, ""),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("pagerduty_event_orchestration_router_rule.database", "position", "0"),
+					resource.TestCheckResourceAttr("pagerduty_event_orchestration_router_rule.web", "position", "1"),
+					resource.TestCheckResourceAttrPair("pagerduty_event_orchestration_router_rule.web", "after_rule_id", "pagerduty_event_orchestration_router_rule.database", "id"),
+					testAccCheckPagerDutyEventOrchestrationRouterRuleOrder("pagerduty_event_orchestration_router_rule.database", "pagerduty_event_orchestration_router_rule.web"),
+				),
+			},
+			// Moving a rule to the top of the set.
+			{
+				Config: testAccCheckPagerDutyEventOrchestrationRouterRuleConfig(team, escalationPolicy, service, orchestration, "position = 0"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("pagerduty_event_orchestration_router_rule.other", "position", "0"),
+					testAccCheckPagerDutyEventOrchestrationRouterRuleOrder(
+						"pagerduty_event_orchestration_router_rule.other",
+						"pagerduty_event_orchestration_router_rule.database",
+						"pagerduty_event_orchestration_router_rule.web",
+					),
+				),
+			},
+			{
+				ResourceName:            "pagerduty_event_orchestration_router_rule.database",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateIdFunc:       testAccCheckPagerDutyEventOrchestrationRuleImportID("pagerduty_event_orchestration_router_rule.database"),
+				ImportStateVerifyIgnore: []string{"after_rule_id"},
+			},
+		},
+	})
+}
+
+func testAccCheckPagerDutyEventOrchestrationRouterRuleDestroy(s *terraform.State) error {
+	client, _ := testAccProvider.Meta().(*Config).Client()
+	for _, r := range s.RootModule().Resources {
+		if r.Type != "pagerduty_event_orchestration_router_rule" {
+			continue
+		}
+		path, _, err := client.EventOrchestrationPaths.Get(r.Primary.Attributes["event_orchestration"], pagerduty.PathTypeRouter)
+		if err != nil {
+			// The orchestration is gone along with its rules.
+			continue
+		}
+		if _, _, rule := findEventOrchestrationPathRule(path, r.Primary.ID); rule != nil {
+			return fmt.Errorf("Event Orchestration Router rule %s still exists", r.Primary.ID)
+		}
+	}
+	return nil
+}
+
+// testAccCheckPagerDutyEventOrchestrationRouterRuleOrder checks that the
+// rules `rns` are in this order at the start of the Router.
+func testAccCheckPagerDutyEventOrchestrationRouterRuleOrder(rns ...string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client, _ := testAccProvider.Meta().(*Config).Client()
+		first := s.RootModule().Resources[rns[0]]
+		path, _, err := client.EventOrchestrationPaths.Get(first.Primary.Attributes["event_orchestration"], pagerduty.PathTypeRouter)
+		if err != nil {
+			return err
+		}
+
+		rules := path.Sets[0].Rules
+		if len(rules) < len(rns) {
+			return fmt.Errorf("Expected at least %d rules in the Router, found %d", len(rns), len(rules))
+		}
+		for i, rn := range rns {
+			if id := s.RootModule().Resources[rn].Primary.ID; rules[i].ID != id {
+				return fmt.Errorf("Expected %s (%s) at position %d of the Router, found %s", rn, id, i, rules[i].ID)
+			}
+		}
+		return nil
+	}
+}
+
+func testAccCheckPagerDutyEventOrchestrationRuleImportID(rn string) resource.ImportStateIdFunc {
+	return func(s *terraform.State) (string, error) {
+		r := s.RootModule().Resources[rn]
+		return fmt.Sprintf("%s:%s", r.Primary.Attributes["event_orchestration"], r.Primary.ID), nil
+	}
+}
+
+func testAccCheckPagerDutyEventOrchestrationRouterRuleConfig(t, ep, s, o, otherPlacement string) string {
+	other := ""
+	if otherPlacement != "" {
+		other = fmt.Sprintf(`
+		resource "pagerduty_event_orchestration_router_rule" "other" {
+			event_orchestration = pagerduty_event_orchestration.orch.id
+			label = "owned by another team"
+			%s
+			actions {
+				route_to = pagerduty_service.bar.id
+			}
+			condition {
+				expression = "event.source matches part 'other'"
+			}
+			depends_on = [pagerduty_event_orchestration_router_rule.web]
+		}`, otherPlacement)
+	}
+
+	return fmt.Sprintf("%s%s", createBaseConfig(t, ep, s, o),
+		fmt.Sprintf(`resource "pagerduty_event_orchestration_router_rule" "database" {
+			event_orchestration = pagerduty_event_orchestration.orch.id
+			label = "database"
+			actions {
+				route_to = pagerduty_service.bar.id
+			}
+			condition {
+				expression = "event.summary matches part 'database'"
+			}
+		}
+
+		resource "pagerduty_event_orchestration_router_rule" "web" {
+			event_orchestration = pagerduty_event_orchestration.orch.id
+			label = "web"
+			after_rule_id = pagerduty_event_orchestration_router_rule.database.id
+			actions {
+				route_to = pagerduty_service.bar.id
+			}
+			condition {
+				expression = "event.summary matches part 'web'"
+			}
+		}
+		%s
+	`, other))
+}
+
+func TestInsertEventOrchestrationPathRule(t *testing.T) {
+	rules := func(ids ...string) []*pagerduty.EventOrchestrationPathRule {
+		var r []*pagerduty.EventOrchestrationPathRule
+		for _, id := range ids {
+			r = append(r, &pagerduty.EventOrchestrationPathRule{ID: id})
+		}
+		return r
+	}
+
+	cases := []struct {
+		name        string
+		position    int
+		afterRuleID string
+		want        []string
+		wantErr     bool
+	}{
+		{name: "at the end", position: -1, want: []string{"a", "b", "new"}},
+		{name: "at the top", position: 0, want: []string{"new", "a", "b"}},
+		{name: "in the middle", position: 1, want: []string{"a", "new", "b"}},
+		{name: "last position", position: 2, want: []string{"a", "b", "new"}},
+		{name: "out of range", position: 3, wantErr: true},
+		{name: "after a rule", position: -1, afterRuleID: "a", want: []string{"a", "new", "b"}},
+		{name: "after a missing rule", position: -1, afterRuleID: "c", wantErr: true},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			set := &pagerduty.EventOrchestrationPathSet{ID: "start", Rules: rules("a", "b")}
+			_, err := insertEventOrchestrationPathRule(set, &pagerduty.EventOrchestrationPathRule{ID: "new"}, c.position, c.afterRuleID)
+			if c.wantErr {
+				if err == nil {
+					t.Errorf("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got []string
+			for _, r := range set.Rules {
+				got = append(got, r.ID)
+			}
+			if fmt.Sprint(got) != fmt.Sprint(c.want) {
+				t.Errorf("got %v, want %v", got, c.want)
+			}
+		})
+	}
+}
+
+func TestUpdateEventOrchestrationPathRulesKeepsOtherRules(t *testing.T) {
+	server := mockapi.NewServer()
+	defer server.Close()
+	client, err := pagerduty.NewClient(&pagerduty.Config{BaseURL: server.URL, Token: "foo"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+
+	orchestration, _, err := client.EventOrchestrations.Create(&pagerduty.EventOrchestration{Name: "foo"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Rules added concurrently, as Terraform does for independent resources.
+	var wg sync.WaitGroup
+	errs := make(chan error, 5)
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_, err := updateEventOrchestrationPathRules(ctx, client, orchestration.ID, pagerduty.PathTypeRouter, func(path, _ *pagerduty.EventOrchestrationPath) error {
+				rule := &pagerduty.EventOrchestrationPathRule{
+					Label:   fmt.Sprintf("rule %d", i),
+					Actions: &pagerduty.EventOrchestrationPathRuleActions{RouteTo: "PSERVICE"},
+				}
+				_, err := insertEventOrchestrationPathRule(path.Sets[0], rule, -1, "")
+				return err
+			})
+			errs <- err
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	path, _, err := client.EventOrchestrationPaths.Get(orchestration.ID, pagerduty.PathTypeRouter)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := len(path.Sets[0].Rules); n != 5 {
+		t.Fatalf("expected the 5 rules to be kept; got %d", n)
+	}
+
+	removed := path.Sets[0].Rules[2].ID
+	_, err = updateEventOrchestrationPathRules(ctx, client, orchestration.ID, pagerduty.PathTypeRouter, func(path, _ *pagerduty.EventOrchestrationPath) error {
+		removeEventOrchestrationPathRule(path, removed)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	path, _, err = client.EventOrchestrationPaths.Get(orchestration.ID, pagerduty.PathTypeRouter)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := len(path.Sets[0].Rules); n != 4 {
+		t.Errorf("expected 4 rules left; got %d", n)
+	}
+	if _, _, rule := findEventOrchestrationPathRule(path, removed); rule != nil {
+		t.Errorf("expected rule %s to be removed", removed)
+	}
+}
+
+func TestCheckDynamicRoutingRulePlacement(t *testing.T) {
+	static := func(id string) *pagerduty.EventOrchestrationPathRule {
+		return &pagerduty.EventOrchestrationPathRule{ID: id, Actions: &pagerduty.EventOrchestrationPathRuleActions{RouteTo: "PSERVICE"}}
+	}
+	dynamic := func(id string) *pagerduty.EventOrchestrationPathRule {
+		return &pagerduty.EventOrchestrationPathRule{ID: id, Actions: &pagerduty.EventOrchestrationPathRuleActions{
+			DynamicRouteTo: &pagerduty.EventOrchestrationPathDynamicRouteTo{Source: "event.custom_details.service", Regex: "(.*)", LookupBy: "service_name"},
+		}}
+	}
+
+	cases := []struct {
+		name    string
+		rules   []*pagerduty.EventOrchestrationPathRule
+		index   int
+		wantErr bool
+	}{
+		{name: "dynamic rule first", rules: []*pagerduty.EventOrchestrationPathRule{dynamic("new"), static("a")}, index: 0},
+		{name: "static rule anywhere", rules: []*pagerduty.EventOrchestrationPathRule{dynamic("a"), static("new")}, index: 1},
+		{name: "dynamic rule appended", rules: []*pagerduty.EventOrchestrationPathRule{static("a"), dynamic("new")}, index: 1, wantErr: true},
+		{name: "second dynamic rule", rules: []*pagerduty.EventOrchestrationPathRule{dynamic("a"), dynamic("new")}, index: 1, wantErr: true},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			set := &pagerduty.EventOrchestrationPathSet{ID: "start", Rules: c.rules}
+			err := checkDynamicRoutingRulePlacement(set, c.index)
+			if c.wantErr != (err != nil) {
+				t.Errorf("got error %v, want an error: %t", err, c.wantErr)
+			}
+		})
+	}
+}
+
+func TestCheckRouterRuleDynamicRoutingPlacement(t *testing.T) {
+	res := resourcePagerDutyEventOrchestrationPathRouterRule()
+	ctx := context.Background()
+	dynamicRouteTo := []interface{}{map[string]interface{}{
+		"dynamic_route_to": []interface{}{map[string]interface{}{
+			"source":    "event.custom_details.service",
+			"regex":     "(.*)",
+			"lookup_by": "service_name",
+		}},
+	}}
+
+	cases := []struct {
+		name    string
+		extra   map[string]interface{}
+		wantErr bool
+	}{
+		{name: "appended"},
+		{name: "at the top", extra: map[string]interface{}{"position": 0}},
+		{name: "below the top", extra: map[string]interface{}{"position": 1}, wantErr: true},
+		{name: "after a rule", extra: map[string]interface{}{"after_rule_id": "a"}, wantErr: true},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			raw := map[string]interface{}{
+				"event_orchestration": "PORCH",
+				"actions":             dynamicRouteTo,
+			}
+			rawAttrs := map[string]cty.Value{}
+			for k, v := range c.extra {
+				raw[k] = v
+				if position, ok := v.(int); ok {
+					rawAttrs[k] = cty.NumberIntVal(int64(position))
+				}
+			}
+			state := &sdkterraform.InstanceState{RawConfig: testRawConfig(res, rawAttrs)}
+			_, err := res.Diff(ctx, state, sdkterraform.NewResourceConfigRaw(raw), &Config{})
+			if c.wantErr != (err != nil) {
+				t.Errorf("got error %v, want an error: %t", err, c.wantErr)
+			}
+		})
+	}
+}
+
+// testStaleRouterWriter serves the fake API of `server`, overwriting the Router
+// right after the first update made through it with the version read before,
+// plus a rule of its own, as another Terraform configuration would.
+func testStaleRouterWriter(t *testing.T, server *mockapi.Server) string {
+	var once sync.Once
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut || !strings.HasSuffix(r.URL.Path, "/router") {
+			server.Config.Handler.ServeHTTP(w, r)
+			return
+		}
+
+		var stale []byte
+		once.Do(func() {
+			rec := httptest.NewRecorder()
+			server.Config.Handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, r.URL.Path, nil))
+			var payload pagerduty.EventOrchestrationPathPayload
+			if err := json.Unmarshal(rec.Body.Bytes(), &payload); err != nil {
+				t.Error(err)
+				return
+			}
+			set := payload.OrchestrationPath.Sets[0]
+			set.Rules = append(set.Rules, &pagerduty.EventOrchestrationPathRule{
+				Label:   "other configuration",
+				Actions: &pagerduty.EventOrchestrationPathRuleActions{RouteTo: "POTHER"},
+			})
+			stale, _ = json.Marshal(payload)
+		})
+
+		server.Config.Handler.ServeHTTP(w, r)
+		if stale != nil {
+			rec := httptest.NewRecorder()
+			server.Config.Handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPut, r.URL.Path, bytes.NewReader(stale)))
+		}
+	}))
+	t.Cleanup(ts.Close)
+	return ts.URL
+}
+
+func TestEventOrchestrationPathRouterRuleConcurrentWriter(t *testing.T) {
+	server := mockapi.NewServer()
+	defer server.Close()
+	client, err := (&Config{ApiUrl: server.URL, Token: "foo", SkipCredsValidation: true}).Client()
+	if err != nil {
+		t.Fatal(err)
+	}
+	orchestration, _, err := client.EventOrchestrations.Create(&pagerduty.EventOrchestration{Name: "foo"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	meta := &Config{ApiUrl: testStaleRouterWriter(t, server), Token: "foo", SkipCredsValidation: true}
+	res := resourcePagerDutyEventOrchestrationPathRouterRule()
+	raw := map[string]interface{}{
+		"event_orchestration": orchestration.ID,
+		"label":               "ours",
+		"actions":             []interface{}{map[string]interface{}{"route_to": "PSERVICE"}},
+	}
+	state := &sdkterraform.InstanceState{RawConfig: testRawConfig(res, nil)}
+	diff, err := res.Diff(ctx, state, sdkterraform.NewResourceConfigRaw(raw), meta)
+	if err != nil {
+		t.Fatal(err)
+	}
+	state, diags := res.Apply(ctx, state, diff, meta)
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	path, _, err := client.EventOrchestrationPaths.Get(orchestration.ID, pagerduty.PathTypeRouter)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var labels []string
+	for _, rule := range path.Sets[0].Rules {
+		labels = append(labels, rule.Label)
+	}
+	if !reflect.DeepEqual(labels, []string{"other configuration", "ours"}) {
+		t.Errorf("expected the rule to be written again next to the other configuration's; got %q", labels)
+	}
+	if _, _, rule := findEventOrchestrationPathRule(path, state.ID); rule == nil || rule.Label != "ours" {
+		t.Errorf("expected the state to track rule %s; got %v", state.ID, rule)
+	}
+}
@@ -3,9 +3,13 @@ package pagerduty
 import (
 	"context"
 	"fmt"
+	"reflect"
 	"regexp"
+	"strings"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	sdkterraform "github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
@@ -119,6 +123,27 @@ func TestAccPagerDutyEventOrchestrationPathRouter_Basic(t *testing.T) {
 						"pagerduty_event_orchestration_router.router", "unrouted", true),
 				),
 			},
+			// Fall back to a static rule when the Dynamic Routing rule finds
+			// no service:
+			{
+				Config: testAccCheckPagerDutyEventOrchestrationRouterDynamicRoutingFallbackConfig(team, escalationPolicy, service, orchestration, dynamicRouteToByIDInput),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPagerDutyEventOrchestrationRouterExists("pagerduty_event_orchestration_router.router"),
+					testAccCheckPagerDutyEventOrchestrationRouterPathDynamicRouteToMatch("pagerduty_event_orchestration_router.router", dynamicRouteToByIDInput),
+					resource.TestCheckResourceAttr(
+						"pagerduty_event_orchestration_router.router", "set.0.rule.#", "2"),
+					resource.TestCheckResourceAttr(
+						"pagerduty_event_orchestration_router.router", "set.0.rule.0.actions.0.dynamic_route_to.#", "1"),
+					resource.TestCheckResourceAttr(
+						"pagerduty_event_orchestration_router.router", "set.0.rule.1.condition.0.expression", "event.summary matches part 'database'"),
+				),
+			},
+			// Invalid Dynamic Routing rule: more than one strategy:
+			{
+				Config:      testAccCheckPagerDutyEventOrchestrationRouterDynamicRoutingFallbackConfig(team, escalationPolicy, service, orchestration, dynamicRouteToByIDInput, dynamicRouteToByNameInput),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("Too many dynamic_route_to blocks"),
+			},
 			// Delete the Dynamic Routing rule:
 			{
 				Config: testAccCheckPagerDutyEventOrchestrationRouterConfigWithConditions(team, escalationPolicy, service, orchestration),
@@ -215,6 +240,31 @@ func TestAccPagerDutyEventOrchestrationPathRouter_EnableRoutingRule(t *testing.T
 	})
 }
 
+func TestAccPagerDutyEventOrchestrationPathRouter_InvalidCondition(t *testing.T) {
+	team := fmt.Sprintf("tf-name-%s", acctest.RandString(5))
+	escalationPolicy := fmt.Sprintf("tf-%s", acctest.RandString(5))
+	service := fmt.Sprintf("tf-%s", acctest.RandString(5))
+	orchestration := fmt.Sprintf("tf-orchestration-%s", acctest.RandString(5))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				// Unknown fields are only warned about.
+				Config:             testAccCheckPagerDutyEventOrchestrationRouterConfigWithCondition(team, escalationPolicy, service, orchestration, "event.sumary matches part 'database'"),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config:      testAccCheckPagerDutyEventOrchestrationRouterConfigWithCondition(team, escalationPolicy, service, orchestration, "event.summary matches part database"),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`Error at column 28 of the condition`),
+			},
+		},
+	})
+}
+
 func testAccCheckPagerDutyEventOrchestrationRouterDestroy(s *terraform.State) error {
 	client, _ := testAccProvider.Meta().(*Config).Client()
 	for _, r := range s.RootModule().Resources {
@@ -477,9 +527,56 @@ func testAccCheckPagerDutyEventOrchestrationRouterDynamicRouteToConfig(t, ep, s,
 	return fmt.Sprintf("%s%s", createBaseConfig(t, ep, s, o), routerConfig)
 }
 
+func testAccCheckPagerDutyEventOrchestrationRouterDynamicRoutingFallbackConfig(t, ep, s, o string, strategies ...*pagerduty.EventOrchestrationPathDynamicRouteTo) string {
+	dynamicRouteTo := ""
+	for _, dr := range strategies {
+		dynamicRouteTo += fmt.Sprintf(`
+						dynamic_route_to {
+							lookup_by = "%s"
+							regex = "%s"
+							source = "%s"
+						}`, dr.LookupBy, dr.Regex, dr.Source)
+	}
+
+	routerConfig := fmt.Sprintf(
+		`resource "pagerduty_event_orchestration_router" "router" {
+			event_orchestration = pagerduty_event_orchestration.orch.id
+
+			catch_all {
+				actions {
+					route_to = pagerduty_service.bar.id
+				}
+			}
+			set {
+				id = "start"
+				rule {
+					label = "dynamic routing rule"
+					actions {%s
+					}
+				}
+				rule {
+					label = "static routing rule"
+					condition {
+						expression = "event.summary matches part 'database'"
+					}
+					actions {
+						route_to = pagerduty_service.bar.id
+					}
+				}
+			}
+		}
+	`, dynamicRouteTo)
+
+	return fmt.Sprintf("%s%s", createBaseConfig(t, ep, s, o), routerConfig)
+}
+
 func testAccCheckPagerDutyEventOrchestrationRouterConfigWithConditions(t, ep, s, o string) string {
+	return testAccCheckPagerDutyEventOrchestrationRouterConfigWithCondition(t, ep, s, o, "event.summary matches part 'database'")
+}
+
+func testAccCheckPagerDutyEventOrchestrationRouterConfigWithCondition(t, ep, s, o, expression string) string {
 	return fmt.Sprintf("%s%s", createBaseConfig(t, ep, s, o),
-		`resource "pagerduty_event_orchestration_router" "router" {
+		fmt.Sprintf(`resource "pagerduty_event_orchestration_router" "router" {
 			event_orchestration = pagerduty_event_orchestration.orch.id
 
 			catch_all {
@@ -496,12 +593,12 @@ func testAccCheckPagerDutyEventOrchestrationRouterConfigWithConditions(t, ep, s,
 						route_to = pagerduty_service.bar.id
 					}
 					condition {
-						expression = "event.summary matches part 'database'"
+						expression = %q
 					}
 				}
 			}
 		}
-	`)
+	`, expression))
 }
 
 func testAccCheckPagerDutyEventOrchestrationRouterConfigWithMultipleRules(t, ep, s, o string) string {
@@ -733,3 +830,147 @@ func testAccCheckPagerDutyEventOrchestrationRouterPathDynamicRouteToMatch(router
 		return nil
 	}
 }
+
+func TestCheckDynamicRoutingRule(t *testing.T) {
+	dynamicRule := func(strategies ...map[string]interface{}) map[string]interface{} {
+		dra := []interface{}{}
+		for _, s := range strategies {
+			dra = append(dra, s)
+		}
+		return map[string]interface{}{
+			"label":   "dynamic",
+			"actions": []interface{}{map[string]interface{}{"dynamic_route_to": dra}},
+		}
+	}
+	staticRule := func(expression string) map[string]interface{} {
+		rule := map[string]interface{}{
+			"label":   "static",
+			"actions": []interface{}{map[string]interface{}{"route_to": "PSVC"}},
+		}
+		if expression != "" {
+			rule["condition"] = []interface{}{map[string]interface{}{"expression": expression}}
+		}
+		return rule
+	}
+	byID := map[string]interface{}{"lookup_by": "service_id", "regex": "(.*)", "source": "event.custom_details.pd_service_id"}
+	byName := map[string]interface{}{"lookup_by": "service_name", "regex": "(.*)", "source": "event.custom_details.pd_service_name"}
+	byEmail := map[string]interface{}{"lookup_by": "email", "regex": "(.*)", "source": "event.custom_details.email"}
+
+	cases := []struct {
+		name    string
+		rules   []interface{}
+		wantErr string
+	}{
+		{
+			name:  "fallback to static rules",
+			rules: []interface{}{dynamicRule(byID), staticRule("event.summary matches part 'db'"), staticRule("")},
+		},
+		{
+			name:    "several strategies",
+			rules:   []interface{}{dynamicRule(byID, byName)},
+			wantErr: "Too many list items",
+		},
+		{
+			name:    "unknown lookup",
+			rules:   []interface{}{dynamicRule(byEmail)},
+			wantErr: "expected set.0.rule.0.actions.0.dynamic_route_to.0.lookup_by to be one of",
+		},
+		{
+			// Only warned about when applied.
+			name:  "unreachable fallback",
+			rules: []interface{}{dynamicRule(byID), staticRule(""), staticRule("event.summary matches part 'db'")},
+		},
+		{
+			name:    "not first",
+			rules:   []interface{}{staticRule("event.summary matches part 'db'"), dynamicRule(byID)},
+			wantErr: "The Dynamic Routing rule must be the first rule",
+		},
+	}
+
+	res := resourcePagerDutyEventOrchestrationPathRouter()
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			raw := map[string]interface{}{
+				"event_orchestration": "PORCH",
+				"set":                 []interface{}{map[string]interface{}{"id": "start", "rule": c.rules}},
+				"catch_all":           []interface{}{map[string]interface{}{"actions": []interface{}{map[string]interface{}{"route_to": "unrouted"}}}},
+			}
+			config := sdkterraform.NewResourceConfigRaw(raw)
+
+			var err error
+			if diags := res.Validate(config); diags.HasError() {
+				for _, d := range diags {
+					err = fmt.Errorf("%s: %s", d.Summary, d.Detail)
+				}
+			} else {
+				_, err = res.Diff(context.Background(), nil, config, nil)
+			}
+
+			if c.wantErr == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %s", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), c.wantErr) {
+				t.Errorf("expected error %q, got %v", c.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestUnreachableRouterRulesDiagnostics(t *testing.T) {
+	dynamic := &pagerduty.EventOrchestrationPathRule{Actions: &pagerduty.EventOrchestrationPathRuleActions{
+		DynamicRouteTo: &pagerduty.EventOrchestrationPathDynamicRouteTo{LookupBy: "service_id", Regex: "(.*)", Source: "event.custom_details.pd_service_id"},
+	}}
+	static := func(expression string, disabled bool) *pagerduty.EventOrchestrationPathRule {
+		rule := &pagerduty.EventOrchestrationPathRule{
+			Disabled: disabled,
+			Actions:  &pagerduty.EventOrchestrationPathRuleActions{RouteTo: "PSVC"},
+		}
+		if expression != "" {
+			rule.Conditions = []*pagerduty.EventOrchestrationPathRuleCondition{{Expression: expression}}
+		}
+		return rule
+	}
+
+	cases := []struct {
+		name  string
+		rules []*pagerduty.EventOrchestrationPathRule
+		want  []string
+	}{
+		{
+			name:  "static default last",
+			rules: []*pagerduty.EventOrchestrationPathRule{dynamic, static("event.summary matches part 'db'", false), static("", false)},
+		},
+		{
+			name:  "disabled rule without conditions",
+			rules: []*pagerduty.EventOrchestrationPathRule{dynamic, static("", true), static("event.summary matches part 'db'", false)},
+		},
+		{
+			name:  "without dynamic routing",
+			rules: []*pagerduty.EventOrchestrationPathRule{static("", false), static("event.summary matches part 'db'", false)},
+		},
+		{
+			name:  "unreachable fallback",
+			rules: []*pagerduty.EventOrchestrationPathRule{dynamic, static("", false), static("event.summary matches part 'db'", false)},
+			want:  []string{"Router rule 1 routes every event"},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			diags := unreachableRouterRulesDiagnostics([]*pagerduty.EventOrchestrationPathSet{{ID: "start", Rules: c.rules}})
+			var got []string
+			for _, d := range diags {
+				if d.Severity != diag.Warning {
+					t.Errorf("expected a warning, got %v", d)
+				}
+				got = append(got, d.Summary)
+			}
+			if !reflect.DeepEqual(got, c.want) {
+				t.Errorf("got %q, want %q", got, c.want)
+			}
+		})
+	}
+}
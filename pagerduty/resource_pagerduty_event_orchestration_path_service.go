@@ -271,6 +271,7 @@ func resourcePagerDutyEventOrchestrationPathServiceCreate(ctx context.Context, d
 
 func resourcePagerDutyEventOrchestrationPathServiceUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
+	conditionsChanged := d.IsNewResource() || d.HasChange("set")
 
 	client, err := meta.(*Config).Client()
 	if err != nil {
@@ -336,6 +337,10 @@ func resourcePagerDutyEventOrchestrationPathServiceUpdate(ctx context.Context, d
 		d.Set("enable_event_orchestration_for_service", enableEOForService)
 	}
 
+	if conditionsChanged {
+		diags = append(diags, cacheVariableReferenceDiagnostics(ctx, client, pagerduty.CacheVariableTypeService, serviceID, eventOrchestrationPathConditionExpressions(d.Get("set")))...)
+	}
+
 	return convertEventOrchestrationPathWarningsToDiagnostics(warnings, diags)
 }
 
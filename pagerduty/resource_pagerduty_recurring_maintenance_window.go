@@ -0,0 +1,348 @@
+package pagerduty
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/PagerDuty/terraform-provider-pagerduty/util"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/id"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/heimweh/go-pagerduty/pagerduty"
+)
+
+func resourcePagerDutyRecurringMaintenanceWindow() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourcePagerDutyRecurringMaintenanceWindowCreate,
+		ReadContext:   resourcePagerDutyRecurringMaintenanceWindowRead,
+		UpdateContext: resourcePagerDutyRecurringMaintenanceWindowUpdate,
+		DeleteContext: resourcePagerDutyRecurringMaintenanceWindowDelete,
+		CustomizeDiff: customizeRecurringMaintenanceWindowDiff,
+		Schema: map[string]*schema.Schema{
+			"recurrence": {
+				Type:     schema.TypeString,
+				Required: true,
+				ValidateFunc: func(v interface{}, k string) (ws []string, errs []error) {
+					if _, err := parseRecurrence(v.(string)); err != nil {
+						errs = append(errs, fmt.Errorf("%s: %w", k, err))
+					}
+					return
+				},
+			},
+			"start_time": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateFunc:     validateRFC3339,
+				DiffSuppressFunc: suppressRFC3339Diff,
+			},
+			"time_zone": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: util.ValidateTZValueDiagFunc,
+			},
+			"duration": {
+				Type:     schema.TypeString,
+				Required: true,
+				ValidateFunc: func(v interface{}, k string) (ws []string, errs []error) {
+					if d, err := time.ParseDuration(v.(string)); err != nil || d < time.Minute {
+						errs = append(errs, fmt.Errorf("%s must be a duration of at least a minute, such as 90m or 4h, got %q", k, v))
+					}
+					return
+				},
+			},
+			"horizon_days": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      28,
+				ValidateFunc: validation.IntBetween(1, 365),
+			},
+			"services": {
+				Type:     schema.TypeSet,
+				Required: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Set:      schema.HashString,
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "Managed by Terraform",
+			},
+			"windows": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"start_time": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"end_time": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// recurringMaintenanceWindowOccurrences returns the start times of the
+// occurrences within the horizon of the recurring maintenance window, along
+// with their duration.
+func recurringMaintenanceWindowOccurrences(get func(string) interface{}, now time.Time) ([]time.Time, time.Duration, error) {
+	r, err := parseRecurrence(get("recurrence").(string))
+	if err != nil {
+		return nil, 0, err
+	}
+	loc, err := time.LoadLocation(get("time_zone").(string))
+	if err != nil {
+		return nil, 0, err
+	}
+	start, err := time.Parse(time.RFC3339, get("start_time").(string))
+	if err != nil {
+		return nil, 0, err
+	}
+	duration, err := time.ParseDuration(get("duration").(string))
+	if err != nil {
+		return nil, 0, err
+	}
+
+	horizon := now.AddDate(0, 0, get("horizon_days").(int))
+	return r.occurrences(start.In(loc), duration, now, horizon), duration, nil
+}
+
+func expandRecurringMaintenanceWindows(v interface{}) []recurringMaintenanceWindow {
+	var windows []recurringMaintenanceWindow
+	for _, raw := range v.([]interface{}) {
+		w := raw.(map[string]interface{})
+		start, err := time.Parse(time.RFC3339, w["start_time"].(string))
+		if err != nil {
+			continue
+		}
+		end, err := time.Parse(time.RFC3339, w["end_time"].(string))
+		if err != nil {
+			continue
+		}
+		windows = append(windows, recurringMaintenanceWindow{id: w["id"].(string), start: start, end: end})
+	}
+	return windows
+}
+
+func flattenRecurringMaintenanceWindows(windows []recurringMaintenanceWindow) []map[string]interface{} {
+	sort.Slice(windows, func(i, j int) bool { return windows[i].start.Before(windows[j].start) })
+
+	var flattened []map[string]interface{}
+	for _, w := range windows {
+		flattened = append(flattened, map[string]interface{}{
+			"id":         w.id,
+			"start_time": w.start.Format(time.RFC3339),
+			"end_time":   w.end.Format(time.RFC3339),
+		})
+	}
+	return flattened
+}
+
+// customizeRecurringMaintenanceWindowDiff plans changes to the windows when
+// the recurrence changes, or when the horizon moved forward so that
+// occurrences are missing windows.
+func customizeRecurringMaintenanceWindowDiff(ctx context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+	if diff.Id() == "" {
+		return nil
+	}
+	for _, k := range []string{"recurrence", "start_time", "time_zone", "duration", "horizon_days"} {
+		if !diff.NewValueKnown(k) {
+			return diff.SetNewComputed("windows")
+		}
+	}
+
+	now := time.Now()
+	occurrences, duration, err := recurringMaintenanceWindowOccurrences(diff.Get, now)
+	if err != nil {
+		return err
+	}
+	_, remove, create := planRecurringMaintenanceWindows(expandRecurringMaintenanceWindows(diff.Get("windows")), occurrences, duration, now)
+	if len(remove) > 0 || len(create) > 0 {
+		log.Printf("[INFO] Recurring maintenance window %s needs %d windows to be created and %d to be deleted", diff.Id(), len(create), len(remove))
+		return diff.SetNewComputed("windows")
+	}
+	return nil
+}
+
+// syncRecurringMaintenanceWindows creates, updates and deletes maintenance
+// windows so that there's one for every occurrence within the horizon.
+func syncRecurringMaintenanceWindows(d *schema.ResourceData, client *pagerduty.Client) error {
+	now := time.Now()
+	occurrences, duration, err := recurringMaintenanceWindowOccurrences(d.Get, now)
+	if err != nil {
+		return err
+	}
+	// The windows are planned as computed whenever they change, so only the
+	// state knows about the existing ones.
+	existing, _ := d.GetChange("windows")
+	keep, remove, create := planRecurringMaintenanceWindows(expandRecurringMaintenanceWindows(existing), occurrences, duration, now)
+
+	windows := keep
+	// Save the windows handled so far, even when failing.
+	defer func() {
+		d.Set("windows", flattenRecurringMaintenanceWindows(windows))
+	}()
+
+	services := expandServices(d.Get("services").(*schema.Set))
+	description := d.Get("description").(string)
+
+	for i, w := range remove {
+		log.Printf("[INFO] Deleting PagerDuty maintenance window %s of recurring maintenance window %s", w.id, d.Id())
+		if _, err := client.MaintenanceWindows.Delete(w.id); err != nil && !isErrCode(err, http.StatusNotFound) && !isErrCode(err, http.StatusMethodNotAllowed) {
+			windows = append(windows, remove[i:]...)
+			return err
+		}
+	}
+
+	if d.HasChanges("services", "description") {
+		for _, w := range keep {
+			log.Printf("[INFO] Updating PagerDuty maintenance window %s of recurring maintenance window %s", w.id, d.Id())
+			_, _, err := client.MaintenanceWindows.Update(w.id, &pagerduty.MaintenanceWindow{
+				StartTime:   w.start.Format(time.RFC3339),
+				EndTime:     w.end.Format(time.RFC3339),
+				Services:    services,
+				Description: description,
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, start := range create {
+		log.Printf("[INFO] Creating PagerDuty maintenance window starting at %s for recurring maintenance window %s", start.Format(time.RFC3339), d.Id())
+		window, _, err := client.MaintenanceWindows.Create(&pagerduty.MaintenanceWindow{
+			StartTime:   start.Format(time.RFC3339),
+			EndTime:     start.Add(duration).Format(time.RFC3339),
+			Services:    services,
+			Description: description,
+		})
+		if err != nil {
+			return err
+		}
+		windows = append(windows, recurringMaintenanceWindow{id: window.ID, start: start, end: start.Add(duration)})
+	}
+
+	return nil
+}
+
+func resourcePagerDutyRecurringMaintenanceWindowCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, err := meta.(*Config).Client()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Printf("[INFO] Creating PagerDuty recurring maintenance window")
+
+	d.SetId(id.UniqueId())
+	if err := syncRecurringMaintenanceWindows(d, client); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourcePagerDutyRecurringMaintenanceWindowRead(ctx, d, meta)
+}
+
+func resourcePagerDutyRecurringMaintenanceWindowRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, err := meta.(*Config).Client()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Printf("[INFO] Reading PagerDuty recurring maintenance window %s", d.Id())
+
+	now := time.Now()
+	var windows []recurringMaintenanceWindow
+	for _, w := range expandRecurringMaintenanceWindows(d.Get("windows")) {
+		var window *pagerduty.MaintenanceWindow
+		err := retry.RetryContext(ctx, 2*time.Minute, func() *retry.RetryError {
+			var err error
+			window, _, err = client.MaintenanceWindows.Get(w.id)
+			if err != nil {
+				if isErrCode(err, http.StatusBadRequest) || isErrCode(err, http.StatusNotFound) {
+					return retry.NonRetryableError(err)
+				}
+				time.Sleep(2 * time.Second)
+				return retry.RetryableError(err)
+			}
+			return nil
+		})
+		if err != nil {
+			if isErrCode(err, http.StatusNotFound) {
+				log.Printf("[WARN] Maintenance window %s of recurring maintenance window %s was deleted", w.id, d.Id())
+				continue
+			}
+			return diag.FromErr(err)
+		}
+
+		start, err := time.Parse(time.RFC3339, window.StartTime)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		end, err := time.Parse(time.RFC3339, window.EndTime)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		if !end.After(now) {
+			continue
+		}
+		windows = append(windows, recurringMaintenanceWindow{id: window.ID, start: start, end: end})
+	}
+
+	d.Set("windows", flattenRecurringMaintenanceWindows(windows))
+
+	return nil
+}
+
+func resourcePagerDutyRecurringMaintenanceWindowUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, err := meta.(*Config).Client()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Printf("[INFO] Updating PagerDuty recurring maintenance window %s", d.Id())
+
+	if err := syncRecurringMaintenanceWindows(d, client); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourcePagerDutyRecurringMaintenanceWindowRead(ctx, d, meta)
+}
+
+func resourcePagerDutyRecurringMaintenanceWindowDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, err := meta.(*Config).Client()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Printf("[INFO] Deleting PagerDuty recurring maintenance window %s", d.Id())
+
+	now := time.Now()
+	for _, w := range expandRecurringMaintenanceWindows(d.Get("windows")) {
+		// Windows in progress are left to end on their own.
+		if !w.start.After(now) {
+			continue
+		}
+		if _, err := client.MaintenanceWindows.Delete(w.id); err != nil && !isErrCode(err, http.StatusNotFound) && !isErrCode(err, http.StatusMethodNotAllowed) {
+			return diag.FromErr(err)
+		}
+	}
+
+	d.SetId("")
+
+	return nil
+}
@@ -0,0 +1,205 @@
+package pagerduty
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/PagerDuty/terraform-provider-pagerduty/util/mockapi"
+	sdkterraform "github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/heimweh/go-pagerduty/pagerduty"
+)
+
+func TestAccPagerDutyRecurringMaintenanceWindow_Basic(t *testing.T) {
+	window := fmt.Sprintf("tf-%s", acctest.RandString(5))
+	start := timeNowInAccLoc().Add(24 * time.Hour).Format(time.RFC3339)
+	windowIDs := map[string]bool{}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckPagerDutyRecurringMaintenanceWindowDestroy(windowIDs),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckPagerDutyRecurringMaintenanceWindowConfig(window, "FREQ=DAILY", start),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPagerDutyRecurringMaintenanceWindowExists("pagerduty_recurring_maintenance_window.foo", windowIDs),
+					resource.TestCheckResourceAttr(
+						"pagerduty_recurring_maintenance_window.foo", "windows.#", "3"),
+				),
+			},
+			{
+				Config: testAccCheckPagerDutyRecurringMaintenanceWindowConfig(window, "FREQ=DAILY;INTERVAL=2", start),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPagerDutyRecurringMaintenanceWindowExists("pagerduty_recurring_maintenance_window.foo", windowIDs),
+					resource.TestCheckResourceAttr(
+						"pagerduty_recurring_maintenance_window.foo", "windows.#", "2"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckPagerDutyRecurringMaintenanceWindowDestroy(windowIDs map[string]bool) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client, _ := testAccProvider.Meta().(*Config).Client()
+		for id := range windowIDs {
+			if _, _, err := client.MaintenanceWindows.Get(id); err == nil {
+				return fmt.Errorf("maintenance window %s still exists", id)
+			}
+		}
+		return nil
+	}
+}
+
+func testAccCheckPagerDutyRecurringMaintenanceWindowExists(n string, windowIDs map[string]bool) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		client, _ := testAccProvider.Meta().(*Config).Client()
+		for k, v := range rs.Primary.Attributes {
+			if !strings.HasPrefix(k, "windows.") || !strings.HasSuffix(k, ".id") {
+				continue
+			}
+			found, _, err := client.MaintenanceWindows.Get(v)
+			if err != nil {
+				return err
+			}
+			if found.ID != v {
+				return fmt.Errorf("maintenance window not found: %v - %v", v, found)
+			}
+			windowIDs[v] = true
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckPagerDutyRecurringMaintenanceWindowConfig(name, recurrence, start string) string {
+	return fmt.Sprintf(`
+resource "pagerduty_user" "foo" {
+  name  = "%[1]v"
+  email = "%[1]v@foo.test"
+}
+
+resource "pagerduty_escalation_policy" "foo" {
+  name      = "%[1]v"
+  num_loops = 2
+
+  rule {
+    escalation_delay_in_minutes = 10
+
+    target {
+      type = "user_reference"
+      id   = pagerduty_user.foo.id
+    }
+  }
+}
+
+resource "pagerduty_service" "foo" {
+  name              = "%[1]v"
+  escalation_policy = pagerduty_escalation_policy.foo.id
+}
+
+resource "pagerduty_recurring_maintenance_window" "foo" {
+  description  = "%[1]v"
+  recurrence   = "%[2]v"
+  start_time   = "%[3]v"
+  time_zone    = "America/New_York"
+  duration     = "1h"
+  horizon_days = 3
+  services     = [pagerduty_service.foo.id]
+}
+`, name, recurrence, start)
+}
+
+func TestRecurringMaintenanceWindowUpdateKeepsWindows(t *testing.T) {
+	server := mockapi.NewServer()
+	defer server.Close()
+	ctx := context.Background()
+	meta := &Config{ApiUrl: server.URL, Token: "foo", SkipCredsValidation: true}
+	client, err := meta.Client()
+	if err != nil {
+		t.Fatal(err)
+	}
+	res := resourcePagerDutyRecurringMaintenanceWindow()
+
+	start := time.Now().UTC().Truncate(24 * time.Hour).Add(24 * time.Hour)
+	apply := func(state *sdkterraform.InstanceState, horizonDays int) *sdkterraform.InstanceState {
+		t.Helper()
+		raw := map[string]interface{}{
+			"recurrence":   "FREQ=DAILY",
+			"start_time":   start.Format(time.RFC3339),
+			"time_zone":    "UTC",
+			"duration":     "1h",
+			"horizon_days": horizonDays,
+			"services":     []interface{}{"PSVC"},
+		}
+		diff, err := res.Diff(ctx, state, sdkterraform.NewResourceConfigRaw(raw), meta)
+		if err != nil {
+			t.Fatal(err)
+		}
+		state, diags := res.Apply(ctx, state, diff, meta)
+		if diags.HasError() {
+			t.Fatalf("unexpected error: %v", diags)
+		}
+		return state
+	}
+	windowIDs := func(state *sdkterraform.InstanceState) map[string]bool {
+		ids := map[string]bool{}
+		for k, v := range state.Attributes {
+			if strings.HasPrefix(k, "windows.") && strings.HasSuffix(k, ".id") {
+				ids[v] = true
+			}
+		}
+		return ids
+	}
+	listWindowIDs := func() map[string]bool {
+		t.Helper()
+		resp, _, err := client.MaintenanceWindows.List(&pagerduty.ListMaintenanceWindowsOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids := map[string]bool{}
+		for _, w := range resp.MaintenanceWindows {
+			ids[w.ID] = true
+		}
+		return ids
+	}
+
+	state := apply(nil, 3)
+	created := windowIDs(state)
+	if len(created) == 0 {
+		t.Fatalf("expected windows to be created, got %v", state.Attributes)
+	}
+
+	state = apply(state, 6)
+	updated := windowIDs(state)
+	for id := range created {
+		if !updated[id] {
+			t.Errorf("expected window %s to be kept when extending the horizon, got %v", id, updated)
+		}
+	}
+	if len(updated) <= len(created) {
+		t.Errorf("expected windows to be added when extending the horizon, got %v", updated)
+	}
+	if listed := listWindowIDs(); fmt.Sprint(listed) != fmt.Sprint(updated) {
+		t.Errorf("expected the maintenance windows %v, found %v", updated, listed)
+	}
+
+	state, diags := res.Apply(ctx, state, &sdkterraform.InstanceDiff{Destroy: true}, meta)
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if listed := listWindowIDs(); len(listed) > 0 {
+		t.Errorf("expected every maintenance window to be deleted, found %v", listed)
+	}
+}
@@ -42,7 +42,10 @@ func resourcePagerDutySchedule() *schema.Resource {
 					}
 				}
 			}
-			return nil
+			if err := customizeDefaultTeamsDiff(0)(context, diff, i); err != nil {
+				return err
+			}
+			return customizeScheduleFullCoverageDiff(diff)
 		},
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
@@ -70,6 +73,18 @@ func resourcePagerDutySchedule() *schema.Resource {
 				Default:  "Managed by Terraform",
 			},
 
+			"require_full_coverage": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			"coverage_weeks": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntBetween(1, 52),
+			},
+
 			"layer": {
 				Type:     schema.TypeList,
 				Required: true,
@@ -176,6 +191,9 @@ func resourcePagerDutySchedule() *schema.Resource {
 			"teams": {
 				Type:     schema.TypeList,
 				Optional: true,
+				// Planned as the default teams of the provider when not
+				// configured.
+				Computed: true,
 				Elem: &schema.Schema{
 					Type: schema.TypeString,
 				},
@@ -777,76 +795,56 @@ func removeScheduleFromEP(c *pagerduty.Client, scheduleID string, ep *pagerduty.
 	return nil
 }
 
+// detectUseOfScheduleByEPsWithOneLayer fails the deletion of a Schedule which
+// is the only target of the layers of an Escalation Policy. Terraform applies
+// the changes planned for the dependant Escalation Policies before destroying
+// the Schedule, so `eps`, fetched while deleting it, already reflects them.
 func detectUseOfScheduleByEPsWithOneLayer(scheduleId string, eps []*pagerduty.EscalationPolicy) error {
-	epsFound := []*pagerduty.EscalationPolicy{}
-	for _, ep := range eps {
-		epHasNoLayers := len(ep.EscalationRules) == 0
-		if epHasNoLayers {
-			continue
-		}
-
-		epHasOneLayer := len(ep.EscalationRules) == 1 && len(ep.EscalationRules[0].Targets) == 1
-		epHasMultipleLayersButAllTargetThisSchedule := func() bool {
-			var meetCondition bool
-			if len(ep.EscalationRules) == 1 {
-				return meetCondition
-			}
-			meetConditionMapping := make(map[int]bool)
-			for epli, epLayer := range ep.EscalationRules {
-				meetConditionMapping[epli] = false
-				isTargetingThisSchedule := epLayer.Targets[0].Type == "schedule_reference" && epLayer.Targets[0].ID == scheduleId
-				if len(epLayer.Targets) == 1 && isTargetingThisSchedule {
-					meetConditionMapping[epli] = true
-				}
-			}
-			for _, mc := range meetConditionMapping {
-				if !mc {
-					meetCondition = false
-					break
-				}
-				meetCondition = true
-			}
-
-			return meetCondition
-		}
-
-		if !epHasOneLayer && !epHasMultipleLayersButAllTargetThisSchedule() {
-			continue
-		}
-		epsFound = append(epsFound, ep)
-	}
-
+	epsFound := filterEPsOnlyTargetingSchedule(scheduleId, eps)
 	if len(epsFound) == 0 {
 		return nil
 	}
 
-	tfState, err := getTFStateSnapshot()
-	if err != nil {
-		return err
+	if len(epsFound) == 1 {
+		ep := epsFound[0]
+		return fmt.Errorf(`It is not possible to continue with the destruction of the Schedule %q, because it is being used by the Escalation Policy %q which has only one layer configured. Therefore in order to unblock this resource destruction, We suggest you to first make the Escalation Policy stop targeting this Schedule or destroy it, e.g. by executing "terraform apply (or destroy, please act accordingly) -target=pagerduty_escalation_policy.<Escalation Policy resource name here>" when it's managed by Terraform, or at %s otherwise, and come back for retrying.`, scheduleId, ep.Name, ep.HTMLURL)
 	}
 
-	epsNames := []string{}
+	var epsListMessage string
 	for _, ep := range epsFound {
-		epState := tfState.GetResourceStateById(ep.ID)
+		epsListMessage = fmt.Sprintf("%s\n%q (%s)", epsListMessage, ep.Name, ep.HTMLURL)
+	}
+	return fmt.Errorf(`It is not possible to continue with the destruction of the Schedule %q, because it is being used by multiple Escalation Policies which have only one layer configured. Therefore in order to unblock this resource destruction, We suggest you to first make the following Escalation Policies stop targeting this Schedule or destroy them, e.g. by executing "terraform apply (or destroy, please act accordingly) -target=pagerduty_escalation_policy.<Escalation Policy resource name here>" when they're managed by Terraform, or through their URL otherwise, and come back for retrying...%s`, scheduleId, epsListMessage)
+}
 
-		// To cover the case when the Schedule is used by an Escalation Policy which
-		// is not being managed by the same TF config which is managing this Schedule.
-		if epState == nil {
-			return fmt.Errorf("It is not possible to continue with the destruction of the Schedule %q, because it is being used by Escalation Policy %q which has only one layer configured. Nevertheless, the mentioned Escalation Policy is not managed by this Terraform configuration. So in order to unblock this resource destruction, We suggest you to first make the appropiate changes on the Escalation Policy %s and come back for retrying.", scheduleId, ep.ID, ep.HTMLURL)
+// filterEPsOnlyTargetingSchedule returns the Escalation Policies whose every
+// layer has the Schedule as its only target, which can't be dissociated from
+// it without leaving a layer without targets.
+func filterEPsOnlyTargetingSchedule(scheduleId string, eps []*pagerduty.EscalationPolicy) []*pagerduty.EscalationPolicy {
+	epsFound := []*pagerduty.EscalationPolicy{}
+	for _, ep := range eps {
+		if isEPOnlyTargetingSchedule(scheduleId, ep) {
+			epsFound = append(epsFound, ep)
 		}
-		epsNames = append(epsNames, epState.Name)
 	}
+	return epsFound
+}
 
-	displayError := fmt.Errorf(`It is not possible to continue with the destruction of the Schedule %q, because it is being used by the Escalation Policy %[2]q which has only one layer configured. Therefore in order to unblock this resource destruction, We suggest you to first execute "terraform apply (or destroy, please act accordingly) -target=pagerduty_escalation_policy.%[2]s"`, scheduleId, epsNames[0])
-	if len(epsNames) > 1 {
-		var epsListMessage string
-		for _, ep := range epsNames {
-			epsListMessage = fmt.Sprintf("%s\n%s", epsListMessage, ep)
+func isEPOnlyTargetingSchedule(scheduleId string, ep *pagerduty.EscalationPolicy) bool {
+	if len(ep.EscalationRules) == 0 {
+		return false
+	}
+	for _, epLayer := range ep.EscalationRules {
+		if len(epLayer.Targets) != 1 {
+			return false
+		}
+		target := epLayer.Targets[0]
+		isTargetingThisSchedule := (target.Type == "schedule_reference" || target.Type == "schedule") && target.ID == scheduleId
+		if !isTargetingThisSchedule {
+			return false
 		}
-		displayError = fmt.Errorf(`It is not possible to continue with the destruction of the Schedule %q, because it is being used by multiple Escalation Policies which have only one layer configured. Therefore in order to unblock this resource destruction, We suggest you to first execute "terraform apply (or destroy, please act accordingly) -target=pagerduty_escalation_policy.<Escalation Policy Name here>". e.g: "terraform apply -target=pagerduty_escalation_policy.example". Replacing the example name with the following Escalation Policies which are blocking the deletion of the Schedule...%s`, scheduleId, epsListMessage)
 	}
-
-	return displayError
+	return true
 }
 
 func fetchEPsDataUsingASchedule(eps []string, c *pagerduty.Client) ([]*pagerduty.EscalationPolicy, error) {
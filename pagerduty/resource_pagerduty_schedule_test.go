@@ -3,12 +3,14 @@ package pagerduty
 import (
 	"fmt"
 	"log"
-	"os"
+	"net/http"
 	"regexp"
 	"strings"
 	"testing"
 	"time"
 
+	"github.com/PagerDuty/terraform-provider-pagerduty/util/mockapi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
@@ -141,6 +143,39 @@ func TestAccPagerDutySchedule_Basic(t *testing.T) {
 	})
 }
 
+func TestAccPagerDutySchedule_RequireFullCoverage(t *testing.T) {
+	username := fmt.Sprintf("tf-%s", acctest.RandString(5))
+	email := fmt.Sprintf("%s@foo.test", username)
+	schedule := fmt.Sprintf("tf-%s", acctest.RandString(5))
+	location := "America/New_York"
+	start := timeNowInLoc(location).Add(-24 * time.Hour).Round(1 * time.Hour).Format(time.RFC3339)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckPagerDutyScheduleDestroy,
+		Steps: []resource.TestStep{
+			// Validating that a layer restricted to business hours leaves the
+			// nights uncovered.
+			{
+				Config:      testAccCheckPagerDutyScheduleConfigRequireFullCoverage(username, email, schedule, location, start, true),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("schedule is not fully covered during the next 2 weeks"),
+			},
+			{
+				Config: testAccCheckPagerDutyScheduleConfigRequireFullCoverage(username, email, schedule, location, start, false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPagerDutyScheduleExists("pagerduty_schedule.foo"),
+					resource.TestCheckResourceAttr(
+						"pagerduty_schedule.foo", "require_full_coverage", "true"),
+					resource.TestCheckResourceAttr(
+						"pagerduty_schedule.foo", "coverage_weeks", "2"),
+				),
+			},
+		},
+	})
+}
+
 func TestAccPagerDutyScheduleWithTeams_Basic(t *testing.T) {
 	username := fmt.Sprintf("tf-%s", acctest.RandString(5))
 	email := fmt.Sprintf("%s@foo.test", username)
@@ -288,10 +323,7 @@ func TestAccPagerDutyScheduleWithTeams_EscalationPolicyDependantWithOneLayer(t *
 	escalationPolicy2 := fmt.Sprintf("ts-%s", acctest.RandString(5))
 
 	resource.Test(t, resource.TestCase{
-		PreCheck: func() {
-			testAccPreCheck(t)
-			testAccPreCheckScheduleUsedByEPWithOneLayer(t)
-		},
+		PreCheck:     func() { testAccPreCheck(t) },
 		Providers:    testAccProviders,
 		CheckDestroy: testAccCheckPagerDutyScheduleDestroy,
 		Steps: []resource.TestStep{
@@ -846,6 +878,42 @@ resource "pagerduty_schedule" "foo" {
 `, username, email, schedule, location, start, rotationVirtualStart)
 }
 
+func testAccCheckPagerDutyScheduleConfigRequireFullCoverage(username, email, schedule, location, start string, restricted bool) string {
+	restriction := ""
+	if restricted {
+		restriction = `
+    restriction {
+      type              = "daily_restriction"
+      start_time_of_day = "09:00:00"
+      duration_seconds  = 28800
+    }`
+	}
+
+	return fmt.Sprintf(`
+resource "pagerduty_user" "foo" {
+  name  = "%s"
+  email = "%s"
+}
+
+resource "pagerduty_schedule" "foo" {
+  name      = "%s"
+  time_zone = "%s"
+
+  require_full_coverage = true
+  coverage_weeks        = 2
+
+  layer {
+    name                         = "foo"
+    start                        = "%s"
+    rotation_virtual_start       = "%s"
+    rotation_turn_length_seconds = 86400
+    users                        = [pagerduty_user.foo.id]
+%s
+  }
+}
+`, username, email, schedule, location, start, start, restriction)
+}
+
 func testAccCheckPagerDutyScheduleConfigRestrictionType(username, email, schedule, location, start, rotationVirtualStart string) string {
 	return fmt.Sprintf(`
 resource "pagerduty_user" "foo" {
@@ -2181,8 +2249,161 @@ resource "pagerduty_service" "foo" {
 `, username, email, escalationPolicy, service)
 }
 
-func testAccPreCheckScheduleUsedByEPWithOneLayer(t *testing.T) {
-	if v := os.Getenv("PAGERDUTY_ACC_SCHEDULE_USED_BY_EP_W_1_LAYER"); v == "" {
-		t.Skip("PAGERDUTY_ACC_SCHEDULE_USED_BY_EP_W_1_LAYER not set. Skipping Schedule related test")
+func testScheduleUsedByEP(id string, targets ...[]string) *pagerduty.EscalationPolicy {
+	ep := &pagerduty.EscalationPolicy{
+		ID:      id,
+		Name:    "EP " + id,
+		HTMLURL: "https://example.pagerduty.com/escalation_policies/" + id,
+	}
+	for _, layer := range targets {
+		rule := &pagerduty.EscalationRule{}
+		for _, target := range layer {
+			parts := strings.SplitN(target, ":", 2)
+			rule.Targets = append(rule.Targets, &pagerduty.EscalationTargetReference{Type: parts[0], ID: parts[1]})
+		}
+		ep.EscalationRules = append(ep.EscalationRules, rule)
+	}
+	return ep
+}
+
+func TestDetectUseOfScheduleByEPsWithOneLayer(t *testing.T) {
+	const scheduleID = "PSCHED1"
+	onlyThisSchedule := []string{"schedule_reference:" + scheduleID}
+
+	cases := []struct {
+		name    string
+		eps     []*pagerduty.EscalationPolicy
+		wantErr string
+	}{
+		{
+			name: "escalation policy with other targets",
+			eps: []*pagerduty.EscalationPolicy{
+				testScheduleUsedByEP("PEP1", onlyThisSchedule, []string{"user_reference:PUSER1"}),
+				testScheduleUsedByEP("PEP2", []string{"schedule_reference:" + scheduleID, "user_reference:PUSER1"}),
+				testScheduleUsedByEP("PEP3", []string{}, onlyThisSchedule),
+			},
+		},
+		{
+			name: "escalation policy with one layer",
+			eps: []*pagerduty.EscalationPolicy{
+				testScheduleUsedByEP("PEP1", onlyThisSchedule),
+			},
+			wantErr: `because it is being used by the Escalation Policy "EP PEP1" which has only one layer configured`,
+		},
+		{
+			name: "multiple escalation policies with layers only targeting the schedule",
+			eps: []*pagerduty.EscalationPolicy{
+				testScheduleUsedByEP("PEP1", onlyThisSchedule),
+				testScheduleUsedByEP("PEP2", onlyThisSchedule, onlyThisSchedule),
+			},
+			wantErr: "because it is being used by multiple Escalation Policies which have only one layer configured",
+		},
+		{
+			name: "escalation policy targeting another schedule",
+			eps: []*pagerduty.EscalationPolicy{
+				testScheduleUsedByEP("PEP1", []string{"schedule_reference:PSCHED2"}),
+			},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			err := detectUseOfScheduleByEPsWithOneLayer(scheduleID, c.eps)
+			if c.wantErr == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %s", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), c.wantErr) {
+				t.Errorf("want error containing %q; got %v", c.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestResourcePagerDutyScheduleDeleteUsedByEPs(t *testing.T) {
+	cases := []struct {
+		name    string
+		rules   []*pagerduty.EscalationRule
+		wantErr string
+	}{
+		{
+			name:    "escalation policy with one layer",
+			rules:   []*pagerduty.EscalationRule{{Targets: []*pagerduty.EscalationTargetReference{{Type: "schedule_reference"}}}},
+			wantErr: "which has only one layer configured",
+		},
+		{
+			name: "escalation policy with other layers",
+			rules: []*pagerduty.EscalationRule{
+				{Targets: []*pagerduty.EscalationTargetReference{{Type: "schedule_reference"}}},
+				{Targets: []*pagerduty.EscalationTargetReference{{Type: "user_reference", ID: "PUSER1"}}},
+			},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			server := mockapi.NewServer()
+			defer server.Close()
+			meta := &Config{ApiUrl: server.URL, Token: "foo", SkipCredsValidation: true}
+			client, err := meta.Client()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			schedule, _, err := client.Schedules.Create(&pagerduty.Schedule{
+				Name:     "foo",
+				TimeZone: "UTC",
+				ScheduleLayers: []*pagerduty.ScheduleLayer{{
+					Name:                      "foo",
+					Start:                     "2030-01-01T00:00:00Z",
+					RotationVirtualStart:      "2030-01-01T00:00:00Z",
+					RotationTurnLengthSeconds: 86400,
+					Users:                     []*pagerduty.UserReferenceWrapper{{User: &pagerduty.UserReference{ID: "PUSER1", Type: "user_reference"}}},
+				}},
+			}, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, rule := range c.rules {
+				for _, target := range rule.Targets {
+					if target.Type == "schedule_reference" {
+						target.ID = schedule.ID
+					}
+				}
+			}
+			ep, _, err := client.EscalationPolicies.Create(&pagerduty.EscalationPolicy{Name: "foo", EscalationRules: c.rules})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			d := schema.TestResourceDataRaw(t, resourcePagerDutySchedule().Schema, map[string]interface{}{})
+			d.SetId(schedule.ID)
+			start := time.Now()
+			err = resourcePagerDutyScheduleDelete(d, meta)
+			if c.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), c.wantErr) {
+					t.Errorf("want error containing %q; got %v", c.wantErr, err)
+				}
+				if elapsed := time.Since(start); elapsed > 10*time.Second {
+					t.Errorf("expected the deletion to fail without waiting; took %s", elapsed)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if _, _, err := client.Schedules.Get(schedule.ID, nil); !isErrCode(err, http.StatusNotFound) {
+				t.Errorf("expected the schedule to be deleted; got %v", err)
+			}
+			ep, _, err = client.EscalationPolicies.Get(ep.ID, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(ep.EscalationRules) != 1 || ep.EscalationRules[0].Targets[0].ID != "PUSER1" {
+				t.Errorf("expected the escalation policy to only keep the user layer; got %+v", ep.EscalationRules)
+			}
+		})
 	}
 }
@@ -536,6 +536,9 @@ func resourcePagerDutyServiceCreate(d *schema.ResourceData, meta interface{}) er
 
 func resourcePagerDutyServiceRead(d *schema.ResourceData, meta interface{}) error {
 	log.Printf("[INFO] Reading PagerDuty service %s", d.Id())
+	if service, ok := meta.(*Config).snapshotService(d.Id()); ok {
+		return flattenService(d, service)
+	}
 	return fetchService(d, meta, handleNotFoundError)
 }
 
@@ -544,6 +547,7 @@ func resourcePagerDutyServiceUpdate(d *schema.ResourceData, meta interface{}) er
 	if err != nil {
 		return err
 	}
+	meta.(*Config).forgetSnapshotObject("services", d.Id())
 
 	service, err := buildServiceStruct(d)
 	if err != nil {
@@ -177,6 +177,10 @@ func resourcePagerDutyUserRead(d *schema.ResourceData, meta interface{}) error {
 
 	log.Printf("[INFO] pooh Reading PagerDuty user %s", d.Id())
 
+	if user, ok := meta.(*Config).snapshotUser(d.Id()); ok {
+		return flattenUser(d, user)
+	}
+
 	return retry.Retry(2*time.Minute, func() *retry.RetryError {
 		user, err := client.Users.GetWithLicense(d.Id(), &pagerduty.GetUserOptions{})
 		if err != nil {
@@ -192,35 +196,42 @@ func resourcePagerDutyUserRead(d *schema.ResourceData, meta interface{}) error {
 
 			return nil
 		}
-		// Trimming whitespace on names in case of mistyped spaces
-		d.Set("name", user.Name)
-		d.Set("email", user.Email)
-		d.Set("time_zone", user.TimeZone)
-		d.Set("html_url", user.HTMLURL)
-		d.Set("color", user.Color)
-		d.Set("role", user.Role)
-		d.Set("avatar_url", user.AvatarURL)
-		d.Set("description", user.Description)
-		d.Set("job_title", user.JobTitle)
-		d.Set("license", user.License.ID)
-
-		if err := d.Set("teams", flattenTeams(user.Teams)); err != nil {
-			return retry.NonRetryableError(
-				fmt.Errorf("error setting teams: %s", err),
-			)
-		}
-
-		d.Set("invitation_sent", user.InvitationSent)
 
+		if err := flattenUser(d, user); err != nil {
+			return retry.NonRetryableError(err)
+		}
 		return nil
 	})
 }
 
+func flattenUser(d *schema.ResourceData, user *pagerduty.User) error {
+	// Trimming whitespace on names in case of mistyped spaces
+	d.Set("name", user.Name)
+	d.Set("email", user.Email)
+	d.Set("time_zone", user.TimeZone)
+	d.Set("html_url", user.HTMLURL)
+	d.Set("color", user.Color)
+	d.Set("role", user.Role)
+	d.Set("avatar_url", user.AvatarURL)
+	d.Set("description", user.Description)
+	d.Set("job_title", user.JobTitle)
+	d.Set("license", user.License.ID)
+
+	if err := d.Set("teams", flattenTeams(user.Teams)); err != nil {
+		return fmt.Errorf("error setting teams: %s", err)
+	}
+
+	d.Set("invitation_sent", user.InvitationSent)
+
+	return nil
+}
+
 func resourcePagerDutyUserUpdate(d *schema.ResourceData, meta interface{}) error {
 	client, err := meta.(*Config).Client()
 	if err != nil {
 		return err
 	}
+	meta.(*Config).forgetSnapshotObject("users", d.Id())
 
 	user := buildUserStruct(d)
 
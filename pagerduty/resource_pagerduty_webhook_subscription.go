@@ -1,8 +1,11 @@
 package pagerduty
 
 import (
+	"context"
+	"fmt"
 	"log"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
@@ -19,6 +22,7 @@ func resourcePagerDutyWebhookSubscription() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
+		CustomizeDiff: validateWebhookSubscriptionFilter,
 		Schema: map[string]*schema.Schema{
 			"delivery_method": {
 				Type:     schema.TypeList,
@@ -91,7 +95,8 @@ func resourcePagerDutyWebhookSubscription() *schema.Resource {
 				Type:     schema.TypeList,
 				Required: true,
 				Elem: &schema.Schema{
-					Type: schema.TypeString,
+					Type:             schema.TypeString,
+					ValidateDiagFunc: validateWebhookEventType(),
 				},
 			},
 			"filter": {
@@ -115,10 +120,66 @@ func resourcePagerDutyWebhookSubscription() *schema.Resource {
 					},
 				},
 			},
+			"secret": {
+				Type:      schema.TypeString,
+				Computed:  true,
+				Sensitive: true,
+			},
+			"rotate_secret": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
 		},
 	}
 }
 
+// validateWebhookSubscriptionFilter checks that the filter has an id unless
+// it's an `account_reference` one, and that the service or team it refers to
+// exists.
+func validateWebhookSubscriptionFilter(ctx context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+	filterType := diff.Get("filter.0.type").(string)
+	if !diff.NewValueKnown("filter.0.id") {
+		return nil
+	}
+	id := diff.Get("filter.0.id").(string)
+
+	if filterType == "account_reference" {
+		if id != "" {
+			return fmt.Errorf("filter id must not be set when filter type is account_reference")
+		}
+		return nil
+	}
+	if id == "" {
+		return fmt.Errorf("filter id is required when filter type is %s", filterType)
+	}
+
+	if !diff.HasChange("filter") {
+		return nil
+	}
+
+	client, err := meta.(*Config).Client()
+	if err != nil {
+		return err
+	}
+
+	var getErr error
+	switch filterType {
+	case "service_reference":
+		_, _, getErr = client.Services.Get(id, &pagerduty.GetServiceOptions{})
+	case "team_reference":
+		_, _, getErr = client.Teams.Get(id)
+	}
+	if getErr != nil {
+		if isErrCode(getErr, http.StatusNotFound) {
+			return fmt.Errorf("%s %s of the filter doesn't exist", strings.TrimSuffix(filterType, "_reference"), id)
+		}
+		log.Printf("[WARN] Unable to check that the %s %s of the filter exists: %s", strings.TrimSuffix(filterType, "_reference"), id, getErr)
+	}
+
+	return nil
+}
+
 func buildWebhookSubscriptionStruct(d *schema.ResourceData) *pagerduty.WebhookSubscription {
 	webhook := pagerduty.WebhookSubscription{
 		Type:           d.Get("type").(string),
@@ -150,6 +211,8 @@ func resourcePagerDutyWebhookSubscriptionCreate(d *schema.ResourceData, meta int
 			return retry.NonRetryableError(err)
 		} else if webhook != nil {
 			d.SetId(webhook.ID)
+			// The secret signing the webhooks is only returned on creation.
+			d.Set("secret", webhook.DeliveryMethod.Secret)
 		}
 		return nil
 	})
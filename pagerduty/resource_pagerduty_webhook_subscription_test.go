@@ -1,14 +1,18 @@
 package pagerduty
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strings"
 	"testing"
 
+	"github.com/PagerDuty/terraform-provider-pagerduty/util/mockapi"
+	sdkterraform "github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/heimweh/go-pagerduty/pagerduty"
 )
 
 func init() {
@@ -65,12 +69,133 @@ func TestAccPagerDutyWebhookSubscription_Basic(t *testing.T) {
 						"pagerduty_webhook_subscription.foo", "description", description),
 					resource.TestCheckResourceAttr(
 						"pagerduty_webhook_subscription.foo", "events.#", "13"),
+					resource.TestCheckResourceAttrSet(
+						"pagerduty_webhook_subscription.foo", "secret"),
 				),
 			},
 		},
 	})
 }
 
+func TestAccPagerDutyWebhookSubscription_RotateSecret(t *testing.T) {
+	description := fmt.Sprintf("tf-test-%s", acctest.RandString(5))
+	var secret string
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckPagerDutyWebhookSubscriptionDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckPagerDutyWebhookSubscriptionRotateSecretConfig(description, "1"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPagerDutyWebhookSubscriptionExists("pagerduty_webhook_subscription.foo"),
+					testAccCheckPagerDutyWebhookSubscriptionSecret("pagerduty_webhook_subscription.foo", &secret, false),
+				),
+			},
+			{
+				Config: testAccCheckPagerDutyWebhookSubscriptionRotateSecretConfig(description, "2"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPagerDutyWebhookSubscriptionExists("pagerduty_webhook_subscription.foo"),
+					testAccCheckPagerDutyWebhookSubscriptionSecret("pagerduty_webhook_subscription.foo", &secret, true),
+				),
+			},
+		},
+	})
+}
+
+func TestValidateWebhookSubscription(t *testing.T) {
+	server := mockapi.NewServer()
+	defer server.Close()
+	meta := &Config{ApiUrl: server.URL, Token: "foo", SkipCredsValidation: true}
+	client, err := meta.Client()
+	if err != nil {
+		t.Fatal(err)
+	}
+	service, _, err := client.Services.Create(&pagerduty.Service{Name: "foo"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	team, _, err := client.Teams.Create(&pagerduty.Team{Name: "foo"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		events  []interface{}
+		filter  map[string]interface{}
+		err     string
+		warning string
+	}{
+		{
+			events: []interface{}{"incident.triggered"},
+			filter: map[string]interface{}{"type": "account_reference"},
+		},
+		{
+			events: []interface{}{"incident.triggered", "service.updated"},
+			filter: map[string]interface{}{"type": "service_reference", "id": service.ID},
+		},
+		{
+			events: []interface{}{"incident.triggered"},
+			filter: map[string]interface{}{"type": "team_reference", "id": team.ID},
+		},
+		{
+			events: []interface{}{"incident.triggered"},
+			filter: map[string]interface{}{"type": "account_reference", "id": "PACCOUNT"},
+			err:    "filter id must not be set when filter type is account_reference",
+		},
+		{
+			events: []interface{}{"incident.triggered"},
+			filter: map[string]interface{}{"type": "team_reference"},
+			err:    "filter id is required when filter type is team_reference",
+		},
+		{
+			events: []interface{}{"incident.triggered"},
+			filter: map[string]interface{}{"type": "service_reference", "id": "PMISSING"},
+			err:    "service PMISSING of the filter doesn't exist",
+		},
+		{
+			events: []interface{}{"pagey.ping"},
+			filter: map[string]interface{}{"type": "account_reference"},
+		},
+		{
+			events:  []interface{}{"incident.triggerred"},
+			filter:  map[string]interface{}{"type": "account_reference"},
+			warning: `Unknown webhook event type "incident.triggerred"`,
+		},
+	}
+
+	res := resourcePagerDutyWebhookSubscription()
+	for _, c := range cases {
+		config := sdkterraform.NewResourceConfigRaw(map[string]interface{}{
+			"delivery_method": []interface{}{map[string]interface{}{"url": "https://example.com"}},
+			"events":          c.events,
+			"filter":          []interface{}{c.filter},
+		})
+
+		var err error
+		diags := res.Validate(config)
+		if diags.HasError() {
+			err = fmt.Errorf("%s: %s", diags[0].Summary, diags[0].Detail)
+		} else {
+			_, err = res.Diff(context.Background(), nil, config, meta)
+		}
+
+		if c.err == "" && err != nil {
+			t.Errorf("%v, %v: unexpected error: %s", c.events, c.filter, err)
+		}
+		if c.err != "" && (err == nil || !strings.Contains(err.Error(), c.err)) {
+			t.Errorf("%v, %v: expected error %q, got %v", c.events, c.filter, c.err, err)
+		}
+		if c.warning == "" && len(diags) > 0 {
+			t.Errorf("%v, %v: unexpected diagnostics: %v", c.events, c.filter, diags)
+		}
+		if c.warning != "" && (len(diags) != 1 || diags[0].Summary != c.warning) {
+			t.Errorf("%v, %v: expected warning %q, got %v", c.events, c.filter, c.warning, diags)
+		}
+	}
+}
+
 func testAccCheckPagerDutyWebhookSubscriptionDestroy(s *terraform.State) error {
 	client, _ := testAccProvider.Meta().(*Config).Client()
 	for _, r := range s.RootModule().Resources {
@@ -106,6 +231,24 @@ func testAccCheckPagerDutyWebhookSubscriptionExists(n string) resource.TestCheck
 	}
 }
 
+func testAccCheckPagerDutyWebhookSubscriptionSecret(n string, secret *string, rotated bool) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+		current := rs.Primary.Attributes["secret"]
+		if current == "" {
+			return fmt.Errorf("No secret is set for webhook subscription %s", rs.Primary.ID)
+		}
+		if rotated && current == *secret {
+			return fmt.Errorf("Secret of webhook subscription %s wasn't rotated", rs.Primary.ID)
+		}
+		*secret = current
+		return nil
+	}
+}
+
 func testAccCheckPagerDutyWebhookSubscriptionConfig(username, useremail, escalationPolicy, service, description string) string {
 	return fmt.Sprintf(`
 	resource "pagerduty_user" "foo" {
@@ -175,3 +318,22 @@ func testAccCheckPagerDutyWebhookSubscriptionConfig(username, useremail, escalat
 	}
 	`, username, useremail, escalationPolicy, service, description)
 }
+
+func testAccCheckPagerDutyWebhookSubscriptionRotateSecretConfig(description, rotateSecret string) string {
+	return fmt.Sprintf(`
+	resource "pagerduty_webhook_subscription" "foo" {
+		delivery_method {
+			type = "http_delivery_method"
+			url = "https://example.com/receive_a_pagerduty_webhook"
+		}
+		description = "%s"
+		events = [
+			"incident.triggered"
+		]
+		filter {
+			type = "account_reference"
+		}
+		rotate_secret = "%s"
+	}
+	`, description, rotateSecret)
+}
@@ -0,0 +1,534 @@
+package pagerduty
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/heimweh/go-pagerduty/pagerduty"
+)
+
+// rulesetMigration is the Event Orchestration equivalent of the rules of a
+// ruleset, or of the event rules of a service.
+type rulesetMigration struct {
+	// global and router are set when migrating a ruleset, service when
+	// migrating the event rules of a service. The router is only set when
+	// some rules route events.
+	global  *pagerduty.EventOrchestrationPath
+	router  *pagerduty.EventOrchestrationPath
+	service *pagerduty.EventOrchestrationPath
+
+	rules []*rulesetMigrationRule
+}
+
+// rulesetMigrationRule is a rule of a ruleset, or an event rule of a service,
+// and the rules it was migrated to.
+type rulesetMigrationRule struct {
+	sourceID string
+	catchAll bool
+	// rule is the migrated rule, nil for catch-all rules, whose actions are
+	// migrated to the catch-all of the paths.
+	rule *pagerduty.EventOrchestrationPathRule
+	// routerRule is the migrated rule of the Router, for rules routing
+	// events.
+	routerRule *pagerduty.EventOrchestrationPathRule
+	// notes describe the parts of the rule which couldn't be migrated.
+	notes []string
+}
+
+// eventRuleWithPosition holds the fields rules of rulesets and event rules of
+// services have in common.
+type eventRuleWithPosition struct {
+	id         string
+	position   int
+	disabled   bool
+	catchAll   bool
+	conditions *pagerduty.RuleConditions
+	timeFrame  *pagerduty.RuleTimeFrame
+	variables  []*pagerduty.RuleVariable
+	actions    *pagerduty.RuleActions
+}
+
+var rulesetTemplateVariable = regexp.MustCompile(`\{\{\s*([^{}\s]+)\s*\}\}`)
+
+// hclIdentifier matches valid names of Terraform resources.
+var hclIdentifier = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_-]*$`)
+
+// migrateRulesetRules migrates the rules of a ruleset to a Global
+// Orchestration, along with a Router for the rules routing events.
+func migrateRulesetRules(rules []*pagerduty.RulesetRule) *rulesetMigration {
+	var eventRules []*eventRuleWithPosition
+	for _, r := range rules {
+		eventRules = append(eventRules, &eventRuleWithPosition{
+			id: r.ID, position: intPtrValue(r.Position), disabled: r.Disabled, catchAll: r.CatchAll,
+			conditions: r.Conditions, timeFrame: r.TimeFrame, variables: r.Variables, actions: r.Actions,
+		})
+	}
+
+	m := migrateEventRules(eventRules, true)
+	m.global = &pagerduty.EventOrchestrationPath{
+		Type:     pagerduty.PathTypeGlobal,
+		Sets:     []*pagerduty.EventOrchestrationPathSet{{ID: "start", Rules: []*pagerduty.EventOrchestrationPathRule{}}},
+		CatchAll: &pagerduty.EventOrchestrationPathCatchAll{Actions: &pagerduty.EventOrchestrationPathRuleActions{}},
+	}
+	router := &pagerduty.EventOrchestrationPath{
+		Type:     pagerduty.PathTypeRouter,
+		Sets:     []*pagerduty.EventOrchestrationPathSet{{ID: "start", Rules: []*pagerduty.EventOrchestrationPathRule{}}},
+		CatchAll: &pagerduty.EventOrchestrationPathCatchAll{Actions: &pagerduty.EventOrchestrationPathRuleActions{RouteTo: "unrouted"}},
+	}
+
+	routes := false
+	for _, r := range m.rules {
+		if r.catchAll {
+			m.global.CatchAll.Actions = r.rule.Actions
+			if r.routerRule != nil {
+				router.CatchAll.Actions.RouteTo = r.routerRule.Actions.RouteTo
+				routes = true
+			}
+			r.rule = nil
+			r.routerRule = nil
+			continue
+		}
+		m.global.Sets[0].Rules = append(m.global.Sets[0].Rules, r.rule)
+		if r.routerRule != nil {
+			router.Sets[0].Rules = append(router.Sets[0].Rules, r.routerRule)
+			routes = true
+		}
+	}
+	if routes {
+		m.router = router
+	}
+
+	// In rulesets the first rule matching an event decides where it's
+	// routed, while the Router only knows about the rules routing events.
+	// Events matching a rule which doesn't route them before matching one
+	// which does are routed differently.
+	seenNonRouting := false
+	for _, r := range m.rules {
+		if r.catchAll || r.rule.Disabled {
+			continue
+		}
+		if r.routerRule == nil {
+			seenNonRouting = true
+		} else if seenNonRouting {
+			r.notes = append(r.notes, "this rule routes events but comes after rules which don't: events matching one of those rules were not routed by the ruleset, but the Router may route them according to this rule")
+		}
+	}
+
+	return m
+}
+
+// migrateServiceEventRules migrates the event rules of a service to a Service
+// Orchestration.
+func migrateServiceEventRules(rules []*pagerduty.ServiceEventRule) *rulesetMigration {
+	var eventRules []*eventRuleWithPosition
+	for _, r := range rules {
+		eventRules = append(eventRules, &eventRuleWithPosition{
+			id: r.ID, position: intPtrValue(r.Position), disabled: r.Disabled,
+			conditions: r.Conditions, timeFrame: r.TimeFrame, variables: r.Variables, actions: r.Actions,
+		})
+	}
+
+	m := migrateEventRules(eventRules, false)
+	m.service = &pagerduty.EventOrchestrationPath{
+		Type:     pagerduty.PathTypeService,
+		Sets:     []*pagerduty.EventOrchestrationPathSet{{ID: "start", Rules: []*pagerduty.EventOrchestrationPathRule{}}},
+		CatchAll: &pagerduty.EventOrchestrationPathCatchAll{Actions: &pagerduty.EventOrchestrationPathRuleActions{}},
+	}
+	for _, r := range m.rules {
+		m.service.Sets[0].Rules = append(m.service.Sets[0].Rules, r.rule)
+	}
+	return m
+}
+
+func migrateEventRules(rules []*eventRuleWithPosition, routing bool) *rulesetMigration {
+	sort.SliceStable(rules, func(i, j int) bool {
+		if rules[i].catchAll != rules[j].catchAll {
+			return !rules[i].catchAll
+		}
+		return rules[i].position < rules[j].position
+	})
+
+	m := &rulesetMigration{}
+	for _, r := range rules {
+		m.rules = append(m.rules, migrateEventRule(r, routing))
+	}
+	return m
+}
+
+func migrateEventRule(r *eventRuleWithPosition, routing bool) *rulesetMigrationRule {
+	mr := &rulesetMigrationRule{sourceID: r.id, catchAll: r.catchAll}
+	rule := &pagerduty.EventOrchestrationPathRule{
+		Label:      fmt.Sprintf("Migrated from event rule %s", r.id),
+		Disabled:   r.disabled,
+		Conditions: []*pagerduty.EventOrchestrationPathRuleCondition{},
+		Actions:    &pagerduty.EventOrchestrationPathRuleActions{},
+	}
+	mr.rule = rule
+
+	conditionsMigrated := true
+	if !r.catchAll {
+		var notes []string
+		rule.Conditions, notes = migrateEventRuleConditions(r.conditions)
+		mr.notes = append(mr.notes, notes...)
+		conditionsMigrated = len(notes) == 0
+	}
+	if r.timeFrame != nil && (r.timeFrame.ScheduledWeekly != nil || r.timeFrame.ActiveBetween != nil) {
+		mr.notes = append(mr.notes, "its time frame can't be migrated, Event Orchestration rules are always active; consider adding a `now in` condition")
+		conditionsMigrated = false
+	}
+	if !conditionsMigrated && !rule.Disabled {
+		// Rules missing some of their conditions would match more events
+		// than before, so they're left disabled until they're reviewed.
+		rule.Disabled = true
+		mr.notes = append(mr.notes, "the migrated rule is disabled since it would match more events than the original rule")
+	}
+
+	variables := map[string]bool{}
+	for _, v := range r.variables {
+		if v == nil {
+			continue
+		}
+		if v.Type != "regex" || v.Parameters == nil {
+			mr.notes = append(mr.notes, fmt.Sprintf("variable %q of type %q can't be migrated, only regex variables can", v.Name, v.Type))
+			continue
+		}
+		variables[v.Name] = true
+		rule.Actions.Variables = append(rule.Actions.Variables, &pagerduty.EventOrchestrationPathActionVariables{
+			Name:  v.Name,
+			Path:  rulesetPathToPCL(v.Parameters.Path),
+			Type:  "regex",
+			Value: v.Parameters.Value,
+		})
+	}
+
+	if a := r.actions; a != nil {
+		if a.Severity != nil {
+			rule.Actions.Severity = a.Severity.Value
+		}
+		if a.Priority != nil {
+			rule.Actions.Priority = a.Priority.Value
+		}
+		if a.Annotate != nil {
+			rule.Actions.Annotate = a.Annotate.Value
+		}
+		if a.EventAction != nil {
+			rule.Actions.EventAction = a.EventAction.Value
+		}
+		if a.Suspend != nil && a.Suspend.Value > 0 {
+			rule.Actions.Suspend = intTypeToIntPtr(a.Suspend.Value)
+		}
+		if a.Suppress != nil && a.Suppress.Value {
+			if a.Suppress.ThresholdValue > 0 || a.Suppress.ThresholdTimeAmount > 0 {
+				mr.notes = append(mr.notes, "its suppression threshold can't be migrated, the migrated rule doesn't suppress alerts")
+			} else {
+				rule.Actions.Suppress = true
+			}
+		}
+		for _, e := range a.Extractions {
+			if e == nil {
+				continue
+			}
+			extraction := &pagerduty.EventOrchestrationPathActionExtractions{
+				Target: rulesetPathToPCL(e.Target),
+				Regex:  e.Regex,
+			}
+			if e.Template != "" {
+				var notes []string
+				extraction.Template, notes = migrateEventRuleTemplate(e.Template, variables)
+				mr.notes = append(mr.notes, notes...)
+			}
+			if e.Source != "" {
+				extraction.Source = rulesetPathToPCL(e.Source)
+			}
+			rule.Actions.Extractions = append(rule.Actions.Extractions, extraction)
+		}
+		if a.Route != nil && a.Route.Value != "" {
+			if routing {
+				mr.routerRule = &pagerduty.EventOrchestrationPathRule{
+					Label:      rule.Label,
+					Disabled:   rule.Disabled,
+					Conditions: rule.Conditions,
+					Actions:    &pagerduty.EventOrchestrationPathRuleActions{RouteTo: a.Route.Value},
+				}
+			} else {
+				mr.notes = append(mr.notes, "its route action can't be migrated to a Service Orchestration")
+			}
+		}
+	}
+
+	return mr
+}
+
+// migrateEventRuleConditions returns the PCL conditions equivalent to
+// `conditions`, and notes about the sub-conditions which couldn't be
+// migrated. Sub-conditions joined with `or` become separate conditions, as
+// rules match events matching any of their conditions.
+func migrateEventRuleConditions(conditions *pagerduty.RuleConditions) ([]*pagerduty.EventOrchestrationPathRuleCondition, []string) {
+	result := []*pagerduty.EventOrchestrationPathRuleCondition{}
+	if conditions == nil || len(conditions.RuleSubconditions) == 0 {
+		return result, nil
+	}
+
+	var expressions, notes []string
+	for _, sc := range conditions.RuleSubconditions {
+		if sc == nil || sc.Parameters == nil {
+			continue
+		}
+		expression, err := rulesetSubconditionToPCL(sc)
+		if err != nil {
+			notes = append(notes, err.Error())
+			continue
+		}
+		expressions = append(expressions, expression)
+	}
+
+	if strings.EqualFold(conditions.Operator, "or") {
+		for _, e := range expressions {
+			result = append(result, &pagerduty.EventOrchestrationPathRuleCondition{Expression: e})
+		}
+	} else if len(expressions) > 0 {
+		result = append(result, &pagerduty.EventOrchestrationPathRuleCondition{Expression: strings.Join(expressions, " and ")})
+	}
+	return result, notes
+}
+
+func rulesetSubconditionToPCL(sc *pagerduty.RuleSubcondition) (string, error) {
+	path := rulesetPathToPCL(sc.Parameters.Path)
+	value := pclQuote(sc.Parameters.Value)
+
+	var expression string
+	switch sc.Operator {
+	case "exists":
+		expression = fmt.Sprintf("%s exists", path)
+	case "nexists":
+		expression = fmt.Sprintf("not %s exists", path)
+	case "equals":
+		expression = fmt.Sprintf("%s matches %s", path, value)
+	case "nequals":
+		expression = fmt.Sprintf("not %s matches %s", path, value)
+	case "contains":
+		expression = fmt.Sprintf("%s matches part %s", path, value)
+	case "ncontains":
+		expression = fmt.Sprintf("not %s matches part %s", path, value)
+	case "matches":
+		expression = fmt.Sprintf("%s matches regex %s", path, value)
+	case "nmatches":
+		expression = fmt.Sprintf("not %s matches regex %s", path, value)
+	default:
+		return "", fmt.Errorf("its sub-condition on %q with operator %q can't be migrated", sc.Parameters.Path, sc.Operator)
+	}
+
+	if _, err := parsePCLCondition(expression); err != nil {
+		return "", fmt.Errorf("its sub-condition on %q can't be migrated: %s", sc.Parameters.Path, err.Message)
+	}
+	return expression, nil
+}
+
+// rulesetPathToPCL converts the path of a field of events in rulesets, such
+// as `payload.summary` or `details.host`, to a PCL field path.
+func rulesetPathToPCL(path string) string {
+	p := strings.TrimPrefix(path, "payload.")
+	if p == "details" || strings.HasPrefix(p, "details.") {
+		p = "custom_details" + strings.TrimPrefix(p, "details")
+	}
+	if _, ok := pclEventFields[splitPCLFieldPath(p)[0]]; ok {
+		return "event." + p
+	}
+	return "raw_event." + path
+}
+
+// migrateEventRuleTemplate converts a template of an extraction of an event
+// rule, where variables are referred to as `{{name}}`, to a template of Event
+// Orchestrations, where they are referred to as `{{variables.name}}`.
+func migrateEventRuleTemplate(template string, variables map[string]bool) (string, []string) {
+	var notes []string
+	result := rulesetTemplateVariable.ReplaceAllStringFunc(template, func(m string) string {
+		name := rulesetTemplateVariable.FindStringSubmatch(m)[1]
+		if variables[name] {
+			return fmt.Sprintf("{{variables.%s}}", name)
+		}
+		notes = append(notes, fmt.Sprintf("the reference to %q in the template %q was kept as is, check that it refers to a valid field", name, template))
+		return m
+	})
+	return result, notes
+}
+
+// pclQuote quotes `s` as a PCL string.
+func pclQuote(s string) string {
+	return "'" + strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(s) + "'"
+}
+
+func intPtrValue(v *int) int {
+	if v == nil {
+		return 0
+	}
+	return *v
+}
+
+// renderHCL returns the Terraform configuration of the migrated paths.
+// `orchestration` is the expression referring to the Event Orchestration of
+// the Global Orchestration and Router, `service` the one referring to the
+// service of the Service Orchestration, and `name` the name of the
+// resources.
+func (m *rulesetMigration) renderHCL(orchestration, service, name string) string {
+	notes := map[*pagerduty.EventOrchestrationPathRule][]string{}
+	var catchAllNotes []string
+	for _, r := range m.rules {
+		if r.catchAll {
+			catchAllNotes = r.notes
+		} else {
+			notes[r.rule] = r.notes
+		}
+	}
+
+	var b strings.Builder
+	if m.global != nil {
+		b.WriteString(renderEventOrchestrationPathHCL("pagerduty_event_orchestration_global", name, "event_orchestration", orchestration, m.global, notes, catchAllNotes))
+	}
+	if m.router != nil {
+		b.WriteString("\n")
+		b.WriteString(renderEventOrchestrationPathHCL("pagerduty_event_orchestration_router", name, "event_orchestration", orchestration, m.router, nil, nil))
+	}
+	if m.service != nil {
+		b.WriteString(renderEventOrchestrationPathHCL("pagerduty_event_orchestration_service", name, "service", service, m.service, notes, nil))
+	}
+	return b.String()
+}
+
+func renderEventOrchestrationPathHCL(resourceType, name, parentAttr, parent string, path *pagerduty.EventOrchestrationPath, notes map[*pagerduty.EventOrchestrationPathRule][]string, catchAllNotes []string) string {
+	w := &hclWriter{}
+	w.open(fmt.Sprintf("resource %q %q", resourceType, name))
+	w.attr(parentAttr, parent)
+	if resourceType == "pagerduty_event_orchestration_service" {
+		w.attr("enable_event_orchestration_for_service", "true")
+	}
+
+	for _, set := range path.Sets {
+		w.open("set")
+		w.attr("id", strconv.Quote(set.ID))
+		for _, rule := range set.Rules {
+			w.open("rule")
+			for _, note := range notes[rule] {
+				w.comment("TODO: " + note)
+			}
+			w.attr("label", hclQuote(rule.Label))
+			if rule.Disabled {
+				w.attr("disabled", "true")
+			}
+			for _, c := range rule.Conditions {
+				w.open("condition")
+				w.attr("expression", hclQuote(c.Expression))
+				w.close()
+			}
+			w.actions(rule.Actions)
+			w.close()
+		}
+		w.close()
+	}
+
+	w.open("catch_all")
+	for _, note := range catchAllNotes {
+		w.comment("TODO: " + note)
+	}
+	w.actions(path.CatchAll.Actions)
+	w.close()
+
+	w.close()
+	return w.String()
+}
+
+// hclWriter writes Terraform configuration.
+type hclWriter struct {
+	strings.Builder
+	depth int
+}
+
+func (w *hclWriter) line(s string) {
+	w.WriteString(strings.Repeat("  ", w.depth) + s + "\n")
+}
+
+func (w *hclWriter) open(header string) {
+	w.line(header + " {")
+	w.depth++
+}
+
+func (w *hclWriter) close() {
+	w.depth--
+	w.line("}")
+}
+
+func (w *hclWriter) attr(name, expression string) {
+	w.line(fmt.Sprintf("%s = %s", name, expression))
+}
+
+func (w *hclWriter) comment(s string) {
+	w.line("# " + s)
+}
+
+func (w *hclWriter) actions(a *pagerduty.EventOrchestrationPathRuleActions) {
+	w.open("actions")
+	if a.RouteTo != "" {
+		w.attr("route_to", hclQuote(a.RouteTo))
+	}
+	if a.Severity != "" {
+		w.attr("severity", hclQuote(a.Severity))
+	}
+	if a.Priority != "" {
+		w.attr("priority", hclQuote(a.Priority))
+	}
+	if a.Annotate != "" {
+		w.attr("annotate", hclQuote(a.Annotate))
+	}
+	if a.EventAction != "" {
+		w.attr("event_action", hclQuote(a.EventAction))
+	}
+	if a.Suppress {
+		w.attr("suppress", "true")
+	}
+	if a.Suspend != nil {
+		w.attr("suspend", strconv.Itoa(*a.Suspend))
+	}
+	for _, v := range a.Variables {
+		w.open("variable")
+		w.attr("name", hclQuote(v.Name))
+		w.attr("path", hclQuote(v.Path))
+		w.attr("type", hclQuote(v.Type))
+		w.attr("value", hclQuote(v.Value))
+		w.close()
+	}
+	for _, e := range a.Extractions {
+		w.open("extraction")
+		w.attr("target", hclQuote(e.Target))
+		if e.Regex != "" {
+			w.attr("regex", hclQuote(e.Regex))
+		}
+		if e.Source != "" {
+			w.attr("source", hclQuote(e.Source))
+		}
+		if e.Template != "" {
+			w.attr("template", hclQuote(e.Template))
+		}
+		w.close()
+	}
+	w.close()
+}
+
+// hclQuote quotes `s` as a Terraform string, escaping its template
+// sequences.
+func hclQuote(s string) string {
+	s = strings.NewReplacer("${", "$${", "%{", "%%{").Replace(s)
+	return strconv.Quote(s)
+}
+
+// eventOrchestrationPathJSON returns the JSON of `path`, or an empty string when it's nil.
+func eventOrchestrationPathJSON(path *pagerduty.EventOrchestrationPath) string {
+	if path == nil {
+		return ""
+	}
+	b, _ := json.Marshal(path)
+	return string(b)
+}
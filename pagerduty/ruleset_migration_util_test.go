@@ -0,0 +1,227 @@
+package pagerduty
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/heimweh/go-pagerduty/pagerduty"
+)
+
+func TestRulesetPathToPCL(t *testing.T) {
+	cases := map[string]string{
+		"payload.summary":              "event.summary",
+		"payload.custom_details.host":  "event.custom_details.host",
+		"details.host":                 "event.custom_details.host",
+		"dedup_key":                    "event.dedup_key",
+		"payload.component":            "event.component",
+		"client_url":                   "event.client_url",
+		"agent.queued_by":              "raw_event.agent.queued_by",
+		"payload.unknown_field.subkey": "raw_event.payload.unknown_field.subkey",
+	}
+	for path, want := range cases {
+		if got := rulesetPathToPCL(path); got != want {
+			t.Errorf("%s: got %q, want %q", path, got, want)
+		}
+	}
+}
+
+func TestMigrateEventRuleConditions(t *testing.T) {
+	subconditions := []*pagerduty.RuleSubcondition{
+		{Operator: "contains", Parameters: &pagerduty.ConditionParameter{Path: "payload.summary", Value: "it's down"}},
+		{Operator: "nexists", Parameters: &pagerduty.ConditionParameter{Path: "details.host"}},
+		{Operator: "matches", Parameters: &pagerduty.ConditionParameter{Path: "payload.source", Value: `^db-\d+$`}},
+	}
+
+	conditions, notes := migrateEventRuleConditions(&pagerduty.RuleConditions{Operator: "and", RuleSubconditions: subconditions})
+	want := `event.summary matches part 'it\'s down' and not event.custom_details.host exists and event.source matches regex '^db-\\d+$'`
+	if len(notes) != 0 || len(conditions) != 1 || conditions[0].Expression != want {
+		t.Errorf("unexpected conditions %+v, notes %v", conditions, notes)
+	}
+
+	conditions, notes = migrateEventRuleConditions(&pagerduty.RuleConditions{Operator: "or", RuleSubconditions: subconditions})
+	if len(notes) != 0 || len(conditions) != 3 {
+		t.Errorf("expected a condition per sub-condition; got %+v, notes %v", conditions, notes)
+	}
+
+	// The regex must still match what it matched in the ruleset once
+	// unescaped by PCL.
+	condition, err := parsePCLCondition(conditions[2].Expression)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := newTestEventOrchestrationSimulation(t, `{"payload": {"source": "db-42"}}`)
+	if !s.evaluate(condition) {
+		t.Errorf("expected %s to match", conditions[2].Expression)
+	}
+
+	_, notes = migrateEventRuleConditions(&pagerduty.RuleConditions{
+		Operator: "and",
+		RuleSubconditions: []*pagerduty.RuleSubcondition{
+			{Operator: "greater_than", Parameters: &pagerduty.ConditionParameter{Path: "payload.summary", Value: "1"}},
+		},
+	})
+	if len(notes) != 1 {
+		t.Errorf("expected a note about the unknown operator; got %v", notes)
+	}
+}
+
+func testRulesetRules() []*pagerduty.RulesetRule {
+	return []*pagerduty.RulesetRule{
+		{
+			ID:       "CATCHALL",
+			CatchAll: true,
+			Actions: &pagerduty.RuleActions{
+				Route:    &pagerduty.RuleActionParameter{Value: "PDEFAULT"},
+				Suppress: &pagerduty.RuleActionSuppress{Value: true},
+			},
+		},
+		{
+			ID:       "ROUTE",
+			Position: intTypeToIntPtr(1),
+			Conditions: &pagerduty.RuleConditions{
+				Operator: "and",
+				RuleSubconditions: []*pagerduty.RuleSubcondition{
+					{Operator: "equals", Parameters: &pagerduty.ConditionParameter{Path: "payload.source", Value: "db-01"}},
+				},
+			},
+			Actions: &pagerduty.RuleActions{
+				Route:    &pagerduty.RuleActionParameter{Value: "PDB"},
+				Severity: &pagerduty.RuleActionParameter{Value: "critical"},
+			},
+		},
+		{
+			ID:       "EXTRACT",
+			Position: intTypeToIntPtr(0),
+			Conditions: &pagerduty.RuleConditions{
+				Operator: "and",
+				RuleSubconditions: []*pagerduty.RuleSubcondition{
+					{Operator: "exists", Parameters: &pagerduty.ConditionParameter{Path: "details.host"}},
+				},
+			},
+			TimeFrame: &pagerduty.RuleTimeFrame{
+				ActiveBetween: &pagerduty.ActiveBetween{StartTime: 1, EndTime: 2},
+			},
+			Variables: []*pagerduty.RuleVariable{
+				{Name: "host", Type: "regex", Parameters: &pagerduty.RuleVariableParameter{Path: "details.host", Value: `(\w+)\.prod`}},
+			},
+			Actions: &pagerduty.RuleActions{
+				Suppress: &pagerduty.RuleActionSuppress{Value: true, ThresholdValue: 4, ThresholdTimeUnit: "minutes", ThresholdTimeAmount: 5},
+				Extractions: []*pagerduty.RuleActionExtraction{
+					{Target: "summary", Template: "${host}: {{host}} {{unknown}}"},
+				},
+			},
+		},
+	}
+}
+
+func TestMigrateRulesetRules(t *testing.T) {
+	m := migrateRulesetRules(testRulesetRules())
+
+	var ids []string
+	for _, r := range m.rules {
+		ids = append(ids, r.sourceID)
+	}
+	if !reflect.DeepEqual(ids, []string{"EXTRACT", "ROUTE", "CATCHALL"}) {
+		t.Fatalf("expected rules to be sorted by position with the catch-all last; got %v", ids)
+	}
+
+	global := m.global.Sets[0].Rules
+	if len(global) != 2 {
+		t.Fatalf("expected 2 rules in the Global Orchestration; got %d", len(global))
+	}
+	extract := global[0]
+	if !extract.Disabled {
+		t.Errorf("expected the rule with a time frame to be disabled")
+	}
+	if extract.Actions.Suppress {
+		t.Errorf("expected the suppression threshold not to be migrated")
+	}
+	if got := extract.Actions.Variables[0].Path; got != "event.custom_details.host" {
+		t.Errorf("unexpected variable path %q", got)
+	}
+	if got := extract.Actions.Extractions[0]; got.Target != "event.summary" || got.Template != "${host}: {{variables.host}} {{unknown}}" {
+		t.Errorf("unexpected extraction %+v", got)
+	}
+	if n := len(m.rules[0].notes); n != 4 {
+		t.Errorf("expected 4 notes about the time frame, the disabled rule, the threshold and the template; got %v", m.rules[0].notes)
+	}
+
+	if global[1].Actions.Severity != "critical" || global[1].Actions.RouteTo != "" {
+		t.Errorf("unexpected actions of the Global Orchestration rule %+v", global[1].Actions)
+	}
+	if !m.global.CatchAll.Actions.Suppress {
+		t.Errorf("expected the catch-all of the Global Orchestration to suppress alerts")
+	}
+
+	if m.router == nil {
+		t.Fatal("expected a Router")
+	}
+	router := m.router.Sets[0].Rules
+	if len(router) != 1 || router[0].Actions.RouteTo != "PDB" || router[0].Conditions[0].Expression != "event.source matches 'db-01'" {
+		t.Errorf("unexpected Router rules %+v", router)
+	}
+	if m.router.CatchAll.Actions.RouteTo != "PDEFAULT" {
+		t.Errorf("unexpected Router catch-all %+v", m.router.CatchAll.Actions)
+	}
+
+	// The first rule is disabled, so it doesn't change how events are
+	// routed.
+	if len(m.rules[1].notes) != 0 {
+		t.Errorf("unexpected notes %v", m.rules[1].notes)
+	}
+}
+
+func TestMigrateServiceEventRules(t *testing.T) {
+	m := migrateServiceEventRules([]*pagerduty.ServiceEventRule{
+		{
+			ID: "RULE",
+			Conditions: &pagerduty.RuleConditions{
+				Operator: "or",
+				RuleSubconditions: []*pagerduty.RuleSubcondition{
+					{Operator: "equals", Parameters: &pagerduty.ConditionParameter{Path: "payload.severity", Value: "critical"}},
+				},
+			},
+			Variables: []*pagerduty.RuleVariable{{Name: "count", Type: "counter"}},
+			Actions: &pagerduty.RuleActions{
+				Route:    &pagerduty.RuleActionParameter{Value: "POTHER"},
+				Priority: &pagerduty.RuleActionParameter{Value: "P1"},
+				Suspend:  &pagerduty.RuleActionIntParameter{Value: 60},
+			},
+		},
+	})
+
+	if m.global != nil || m.router != nil {
+		t.Errorf("expected only a Service Orchestration")
+	}
+	rule := m.service.Sets[0].Rules[0]
+	if rule.Disabled || rule.Actions.Priority != "P1" || *rule.Actions.Suspend != 60 {
+		t.Errorf("unexpected rule %+v", rule)
+	}
+	if len(m.rules[0].notes) != 2 {
+		t.Errorf("expected notes about the variable and the route; got %v", m.rules[0].notes)
+	}
+}
+
+func TestRulesetMigrationRenderHCL(t *testing.T) {
+	m := migrateRulesetRules(testRulesetRules())
+	config := m.renderHCL("pagerduty_event_orchestration.migrated.id", "", "migrated")
+
+	if _, diags := hclsyntax.ParseConfig([]byte(config), "migrated.tf", hcl.InitialPos); diags.HasErrors() {
+		t.Fatalf("invalid configuration: %s\n%s", diags, config)
+	}
+	for _, want := range []string{
+		`resource "pagerduty_event_orchestration_global" "migrated" {`,
+		`resource "pagerduty_event_orchestration_router" "migrated" {`,
+		`template = "$${host}: {{variables.host}} {{unknown}}"`,
+		`expression = "event.source matches 'db-01'"`,
+		`# TODO: its time frame can't be migrated`,
+		`route_to = "PDEFAULT"`,
+	} {
+		if !strings.Contains(config, want) {
+			t.Errorf("expected the configuration to contain %q:\n%s", want, config)
+		}
+	}
+}
@@ -0,0 +1,218 @@
+package pagerduty
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/PagerDuty/terraform-provider-pagerduty/util"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/heimweh/go-pagerduty/pagerduty"
+)
+
+// defaultScheduleCoverageWeeks is the amount of weeks checked by
+// `require_full_coverage` when `coverage_weeks` isn't set.
+const defaultScheduleCoverageWeeks = 4
+
+// maxReportedUncoveredIntervals limits the intervals listed in the error
+// returned when a schedule isn't fully covered.
+const maxReportedUncoveredIntervals = 20
+
+// customizeScheduleFullCoverageDiff fails the plan of a schedule which opted
+// in `require_full_coverage` when its layers leave someone off call at any
+// moment of the next `coverage_weeks` weeks, or when a layer's
+// rotation_virtual_start falls after the layer's end.
+func customizeScheduleFullCoverageDiff(diff *schema.ResourceDiff) error {
+	if !diff.Get("require_full_coverage").(bool) {
+		return nil
+	}
+
+	if !isScheduleCoverageKnown(diff) {
+		log.Printf("[INFO] Skipping coverage validation of schedule %q, its layers are not known yet", diff.Id())
+		return nil
+	}
+
+	loc, err := time.LoadLocation(diff.Get("time_zone").(string))
+	if err != nil {
+		return fmt.Errorf("unable to validate schedule coverage: %w", err)
+	}
+
+	layers, err := expandScheduleLayersForCoverage(diff.Get("layer").([]interface{}))
+	if err != nil {
+		return err
+	}
+
+	weeks := diff.Get("coverage_weeks").(int)
+	if weeks == 0 {
+		weeks = defaultScheduleCoverageWeeks
+	}
+	now := time.Now().In(loc).Truncate(time.Minute)
+	window := util.TimeInterval{Start: now, End: now.AddDate(0, 0, 7*weeks)}
+
+	gaps := uncoveredScheduleIntervals(layers, window, loc)
+	if len(gaps) == 0 {
+		return nil
+	}
+
+	return fmt.Errorf("schedule is not fully covered during the next %d weeks and require_full_coverage is set, nobody is on call during the following windows (%s):%s", weeks, loc, formatUncoveredIntervals(gaps, loc))
+}
+
+func isScheduleCoverageKnown(diff *schema.ResourceDiff) bool {
+	if !diff.NewValueKnown("time_zone") || !diff.NewValueKnown("layer") {
+		return false
+	}
+	ln := diff.Get("layer.#").(int)
+	for li := 0; li < ln; li++ {
+		for _, k := range []string{"start", "end", "rotation_virtual_start", "restriction"} {
+			if !diff.NewValueKnown(fmt.Sprintf("layer.%d.%s", li, k)) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// scheduleLayerCoverage holds the parts of a schedule layer which determine
+// when somebody is on call on it.
+type scheduleLayerCoverage struct {
+	start        time.Time
+	end          time.Time // zero when the layer never ends
+	restrictions []*pagerduty.Restriction
+}
+
+func expandScheduleLayersForCoverage(v []interface{}) ([]scheduleLayerCoverage, error) {
+	var layers []scheduleLayerCoverage
+
+	for i, raw := range v {
+		rsl := raw.(map[string]interface{})
+
+		start, err := time.Parse(time.RFC3339, rsl["start"].(string))
+		if err != nil {
+			return nil, fmt.Errorf("unable to validate coverage of layer %d: %w", i, err)
+		}
+		layer := scheduleLayerCoverage{start: start}
+
+		rvs, err := time.Parse(time.RFC3339, rsl["rotation_virtual_start"].(string))
+		if err != nil {
+			return nil, fmt.Errorf("unable to validate coverage of layer %d: %w", i, err)
+		}
+		if rvs.Before(start) {
+			return nil, fmt.Errorf("rotation_virtual_start %q of layer %d must not be before the layer start %q", rsl["rotation_virtual_start"], i, rsl["start"])
+		}
+
+		if endStr := rsl["end"].(string); endStr != "" {
+			end, err := time.Parse(time.RFC3339, endStr)
+			if err != nil {
+				return nil, fmt.Errorf("unable to validate coverage of layer %d: %w", i, err)
+			}
+			layer.end = end
+
+			if !rvs.Before(end) {
+				return nil, fmt.Errorf("rotation_virtual_start %q of layer %d must be before the layer end %q", rsl["rotation_virtual_start"], i, endStr)
+			}
+		}
+
+		for _, slr := range rsl["restriction"].([]interface{}) {
+			rslr := slr.(map[string]interface{})
+			layer.restrictions = append(layer.restrictions, &pagerduty.Restriction{
+				Type:            rslr["type"].(string),
+				StartTimeOfDay:  rslr["start_time_of_day"].(string),
+				StartDayOfWeek:  rslr["start_day_of_week"].(int),
+				DurationSeconds: rslr["duration_seconds"].(int),
+			})
+		}
+
+		layers = append(layers, layer)
+	}
+
+	return layers, nil
+}
+
+// uncoveredScheduleIntervals computes the intervals of `window` where none of
+// the layers has anybody on call. Every layer has at least one user, so a
+// layer covers the time between its start and end, limited to its
+// restrictions if it has any. Restrictions are evaluated in the schedule's time
+// zone.
+func uncoveredScheduleIntervals(layers []scheduleLayerCoverage, window util.TimeInterval, loc *time.Location) []util.TimeInterval {
+	var covered []util.TimeInterval
+
+	for _, layer := range layers {
+		active := util.TimeInterval{Start: layer.start, End: layer.end}
+		if layer.end.IsZero() || layer.end.After(window.End) {
+			active.End = window.End
+		}
+		if active.Start.Before(window.Start) {
+			active.Start = window.Start
+		}
+		if !active.End.After(active.Start) {
+			continue
+		}
+
+		if len(layer.restrictions) == 0 {
+			covered = append(covered, active)
+			continue
+		}
+
+		for _, r := range layer.restrictions {
+			for _, occurrence := range restrictionOccurrences(r, active, loc) {
+				covered = append(covered, intersectTimeIntervals(occurrence, active))
+			}
+		}
+	}
+
+	return util.UncoveredTimeIntervals(window, covered)
+}
+
+// restrictionOccurrences lists the intervals defined by a restriction which
+// overlap `within`.
+func restrictionOccurrences(r *pagerduty.Restriction, within util.TimeInterval, loc *time.Location) []util.TimeInterval {
+	var h, m, s int
+	if _, err := fmt.Sscanf(r.StartTimeOfDay, "%d:%d:%d", &h, &m, &s); err != nil {
+		log.Printf("[WARN] Ignoring restriction with start_time_of_day %q: %s", r.StartTimeOfDay, err)
+		return nil
+	}
+	duration := time.Duration(r.DurationSeconds) * time.Second
+
+	var occurrences []util.TimeInterval
+	// Restrictions can last up to a week, so the ones starting the week before
+	// may still be ongoing.
+	first := within.Start.In(loc).AddDate(0, 0, -7)
+	for day := time.Date(first.Year(), first.Month(), first.Day(), 0, 0, 0, 0, loc); day.Before(within.End); day = day.AddDate(0, 0, 1) {
+		// PagerDuty numbers days of the week from 1 (Monday) to 7 (Sunday)
+		if r.Type == "weekly_restriction" && int(day.Weekday()) != r.StartDayOfWeek%7 {
+			continue
+		}
+		start := time.Date(day.Year(), day.Month(), day.Day(), h, m, s, 0, loc)
+		occurrence := util.TimeInterval{Start: start, End: start.Add(duration)}
+		if occurrence.End.After(within.Start) && occurrence.Start.Before(within.End) {
+			occurrences = append(occurrences, occurrence)
+		}
+	}
+	return occurrences
+}
+
+func intersectTimeIntervals(a, b util.TimeInterval) util.TimeInterval {
+	i := a
+	if b.Start.After(i.Start) {
+		i.Start = b.Start
+	}
+	if b.End.Before(i.End) {
+		i.End = b.End
+	}
+	return i
+}
+
+func formatUncoveredIntervals(gaps []util.TimeInterval, loc *time.Location) string {
+	const layout = "Mon, 02 Jan 2006 15:04 MST"
+
+	var b strings.Builder
+	for i, g := range gaps {
+		if i == maxReportedUncoveredIntervals {
+			fmt.Fprintf(&b, "\n  ... and %d more", len(gaps)-i)
+			break
+		}
+		fmt.Fprintf(&b, "\n  - %s to %s (%s)", g.Start.In(loc).Format(layout), g.End.In(loc).Format(layout), g.Duration())
+	}
+	return b.String()
+}
@@ -0,0 +1,162 @@
+package pagerduty
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/PagerDuty/terraform-provider-pagerduty/util"
+	"github.com/heimweh/go-pagerduty/pagerduty"
+)
+
+func TestPagerDutySchedule_UncoveredScheduleIntervals(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("time zone database not available: %s", err)
+	}
+	// Monday
+	monday := time.Date(2025, 1, 6, 0, 0, 0, 0, loc)
+	at := func(days, hours int) time.Time {
+		return time.Date(monday.Year(), monday.Month(), monday.Day()+days, hours, 0, 0, 0, loc)
+	}
+	window := util.TimeInterval{Start: monday, End: at(7, 0)}
+
+	cases := []struct {
+		name   string
+		layers []scheduleLayerCoverage
+		want   []util.TimeInterval
+	}{
+		{
+			name: "layer without restrictions",
+			layers: []scheduleLayerCoverage{
+				{start: at(-30, 0)},
+			},
+			want: []util.TimeInterval{},
+		},
+		{
+			name: "layer starting in the middle of the window",
+			layers: []scheduleLayerCoverage{
+				{start: at(2, 0)},
+			},
+			want: []util.TimeInterval{{Start: at(0, 0), End: at(2, 0)}},
+		},
+		{
+			name: "layer ending in the middle of the window",
+			layers: []scheduleLayerCoverage{
+				{start: at(-30, 0), end: at(5, 0)},
+			},
+			want: []util.TimeInterval{{Start: at(5, 0), End: at(7, 0)}},
+		},
+		{
+			name: "complementary daily restrictions",
+			layers: []scheduleLayerCoverage{
+				{
+					start: at(-30, 0),
+					restrictions: []*pagerduty.Restriction{
+						{Type: "daily_restriction", StartTimeOfDay: "08:00:00", DurationSeconds: 12 * 3600},
+					},
+				},
+				{
+					start: at(-30, 0),
+					restrictions: []*pagerduty.Restriction{
+						{Type: "daily_restriction", StartTimeOfDay: "20:00:00", DurationSeconds: 12 * 3600},
+					},
+				},
+			},
+			want: []util.TimeInterval{},
+		},
+		{
+			name: "weekdays only restriction leaves the weekend uncovered",
+			layers: []scheduleLayerCoverage{
+				{
+					start: at(-30, 0),
+					restrictions: []*pagerduty.Restriction{
+						{Type: "weekly_restriction", StartDayOfWeek: 1, StartTimeOfDay: "00:00:00", DurationSeconds: 5 * 24 * 3600},
+					},
+				},
+			},
+			want: []util.TimeInterval{{Start: at(5, 0), End: at(7, 0)}},
+		},
+		{
+			name: "weekly restriction starting on sunday and ongoing at the beginning of the window",
+			layers: []scheduleLayerCoverage{
+				{
+					start: at(-30, 0),
+					restrictions: []*pagerduty.Restriction{
+						{Type: "weekly_restriction", StartDayOfWeek: 7, StartTimeOfDay: "12:00:00", DurationSeconds: 3 * 24 * 3600},
+					},
+				},
+			},
+			want: []util.TimeInterval{{Start: at(2, 12), End: at(6, 12)}},
+		},
+		{
+			name: "business hours leave nights uncovered",
+			layers: []scheduleLayerCoverage{
+				{
+					start: at(-30, 0),
+					restrictions: []*pagerduty.Restriction{
+						{Type: "daily_restriction", StartTimeOfDay: "09:00:00", DurationSeconds: 8 * 3600},
+					},
+				},
+				{
+					start: at(-30, 0),
+					restrictions: []*pagerduty.Restriction{
+						{Type: "daily_restriction", StartTimeOfDay: "17:00:00", DurationSeconds: 14 * 3600},
+					},
+				},
+			},
+			want: []util.TimeInterval{
+				{Start: at(0, 7), End: at(0, 9)},
+				{Start: at(1, 7), End: at(1, 9)},
+				{Start: at(2, 7), End: at(2, 9)},
+				{Start: at(3, 7), End: at(3, 9)},
+				{Start: at(4, 7), End: at(4, 9)},
+				{Start: at(5, 7), End: at(5, 9)},
+				{Start: at(6, 7), End: at(6, 9)},
+			},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got := uncoveredScheduleIntervals(c.layers, window, loc)
+			if !reflect.DeepEqual(got, c.want) {
+				t.Errorf("want %v; got %v", c.want, got)
+			}
+		})
+	}
+}
+
+func TestPagerDutySchedule_ExpandScheduleLayersForCoverage(t *testing.T) {
+	cases := []struct {
+		name                 string
+		end                  string
+		rotationVirtualStart string
+		wantErr              bool
+	}{
+		{name: "at the start", end: "2025-02-06T00:00:00Z", rotationVirtualStart: "2025-01-06T00:00:00Z"},
+		{name: "within the layer", end: "2025-02-06T00:00:00Z", rotationVirtualStart: "2025-01-13T00:00:00Z"},
+		{name: "before the start", end: "2025-02-06T00:00:00Z", rotationVirtualStart: "2024-12-30T00:00:00Z", wantErr: true},
+		{name: "after the end", end: "2025-02-06T00:00:00Z", rotationVirtualStart: "2025-03-06T00:00:00Z", wantErr: true},
+		{name: "before the start of a layer without end", rotationVirtualStart: "2024-12-30T00:00:00Z", wantErr: true},
+		{name: "after the start of a layer without end", rotationVirtualStart: "2025-03-06T00:00:00Z"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			layer := map[string]interface{}{
+				"start":                  "2025-01-06T00:00:00Z",
+				"end":                    c.end,
+				"rotation_virtual_start": c.rotationVirtualStart,
+				"restriction":            []interface{}{},
+			}
+			_, err := expandScheduleLayersForCoverage([]interface{}{layer})
+			if c.wantErr && err == nil {
+				t.Errorf("expected an error")
+			}
+			if !c.wantErr && err != nil {
+				t.Errorf("unexpected error: %s", err)
+			}
+		})
+	}
+}
@@ -2,19 +2,19 @@ package pagerduty
 
 import (
 	"context"
-	"crypto/tls"
 	"fmt"
 	"log"
 	"net/http"
-	"os"
-	"path/filepath"
 	"sync"
 	"time"
 
 	"github.com/PagerDuty/go-pagerduty"
 	"github.com/PagerDuty/terraform-provider-pagerduty/util"
+	"github.com/PagerDuty/terraform-provider-pagerduty/util/apiutil"
+	"github.com/PagerDuty/terraform-provider-pagerduty/util/cache"
+	"github.com/PagerDuty/terraform-provider-pagerduty/util/httpclient"
+	"github.com/PagerDuty/terraform-provider-pagerduty/util/oauthtoken"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/logging"
 )
 
 // Config defines the configuration options for the PagerDuty client
@@ -48,15 +48,38 @@ type Config struct {
 	// Do not verify TLS certs for HTTPS requests - useful if you're behind a corporate proxy
 	InsecureTls bool
 
+	// Amount of times a request is retried when rate limited or failing
+	MaxRetries int
+
+	// Timeout of every attempt of a request, defaults to
+	// httpclient.DefaultRequestTimeout seconds when zero
+	RequestTimeout time.Duration
+
+	// Limit of requests sent to the API per minute
+	MaxRequestsPerMinute int
+
 	// Parameters for fine-grained access control
 	AppOauthScopedToken *AppOauthScopedToken
 
+	// Cache of the reads of the objects, nil when disabled
+	Cache *cache.Cache
+
+	// Fill the cache when the client is initialized
+	CachePrefill bool
+
 	// API wrapper
 	client *pagerduty.Client
 }
 
 type AppOauthScopedToken struct {
 	ClientID, ClientSecret, Subdomain string
+
+	// Scopes the token is requested with, as returned by oauthtoken.Scopes
+	Scopes []string
+
+	// Directory the token is cached in, defaults to
+	// oauthtoken.DefaultCacheDir when empty
+	TokenCacheDir string
 }
 
 const invalidCreds = `
@@ -75,23 +98,23 @@ func (c *Config) Client(ctx context.Context) (*pagerduty.Client, error) {
 		return c.client, nil
 	}
 
-	httpClient := http.DefaultClient
-	httpClient.Timeout = 30 * time.Second
-
-	transport := http.DefaultTransport.(*http.Transport).Clone()
-	if c.InsecureTls {
-		transport.TLSClientConfig = &tls.Config{InsecureSkipVerify: true}
+	requestTimeout := c.RequestTimeout
+	if requestTimeout == 0 {
+		requestTimeout = httpclient.DefaultRequestTimeout * time.Second
 	}
-	httpClient.Transport = logging.NewTransport("PagerDuty", transport)
+	httpClient := httpclient.Shared(httpclient.Options{
+		MaxRetries:           c.MaxRetries,
+		RequestTimeout:       requestTimeout,
+		MaxRequestsPerMinute: c.MaxRequestsPerMinute,
+		InsecureTLS:          c.InsecureTls,
+		Cache:                c.Cache,
+	})
 
 	apiURL := c.APIURL
 	if c.APIURLOverride != "" {
 		apiURL = c.APIURLOverride
 	}
 
-	maxRetries := 1
-	retryInterval := 60 // seconds
-
 	userAgentVersion := c.TerraformVersion
 	if util.UserAgentAppend != "" {
 		userAgentVersion += " " + util.UserAgentAppend
@@ -101,21 +124,19 @@ func (c *Config) Client(ctx context.Context) (*pagerduty.Client, error) {
 		WithHTTPClient(httpClient),
 		pagerduty.WithAPIEndpoint(apiURL),
 		pagerduty.WithTerraformProvider(userAgentVersion),
-		pagerduty.WithRetryPolicy(maxRetries, retryInterval),
+		// Retries are handled by the shared HTTP client
+		pagerduty.WithRetryPolicy(0, 0),
 	}
 
 	if c.AppOauthScopedToken != nil {
-		tokenFile := getTokenFilepath()
-		account := fmt.Sprintf("as_account-%s.%s", c.ServiceRegion, c.AppOauthScopedToken.Subdomain)
-		accountAndScopes := []string{account}
-		accountAndScopes = append(accountAndScopes, availableOauthScopes()...)
-		opt := pagerduty.WithScopedOAuthAppTokenSource(pagerduty.NewFileTokenSource(
-			ctx,
-			c.AppOauthScopedToken.ClientID,
-			c.AppOauthScopedToken.ClientSecret,
-			accountAndScopes,
-			tokenFile,
-		))
+		opt := pagerduty.WithScopedOAuthAppTokenSource(oauthtoken.NewTokenSource(oauthtoken.Options{
+			ClientID:     c.AppOauthScopedToken.ClientID,
+			ClientSecret: c.AppOauthScopedToken.ClientSecret,
+			Region:       c.ServiceRegion,
+			Subdomain:    c.AppOauthScopedToken.Subdomain,
+			Scopes:       c.AppOauthScopedToken.Scopes,
+			CacheDir:     c.AppOauthScopedToken.TokenCacheDir,
+		}))
 		clientOpts = append(clientOpts, opt)
 	}
 
@@ -132,6 +153,19 @@ func (c *Config) Client(ctx context.Context) (*pagerduty.Client, error) {
 			return nil, fmt.Errorf(fmt.Sprintf("%s\n%s", err, invalidCreds))
 		}
 	}
+	// The cache is only prefilled with API tokens, the App OAuth token
+	// being only known by the client. The SDKv2 half of the provider
+	// prefills it otherwise.
+	if c.CachePrefill && c.AppOauthScopedToken == nil {
+		header := http.Header{
+			"Accept":        []string{"application/vnd.pagerduty+json;version=2"},
+			"Authorization": []string{"Token token=" + c.Token},
+		}
+		if err := c.Cache.Prefill(ctx, httpClient, apiURL, header); err != nil {
+			log.Printf("[WARN] %s", err)
+		}
+	}
+
 	c.client = client
 
 	log.Printf("[INFO] PagerDuty plugin client configured")
@@ -147,75 +181,17 @@ func WithHTTPClient(httpClient pagerduty.HTTPClient) pagerduty.ClientOptions {
 	}
 }
 
-func getTokenFilepath() string {
-	dir, err := os.UserHomeDir()
-	if err == nil {
-		dir = filepath.Join(dir, ".pagerduty")
-		if _, err := os.Stat(dir); os.IsNotExist(err) {
-			os.Mkdir(dir, os.ModeDir|0o755)
-		}
-	} else {
-		dir = ""
-	}
-	return filepath.Join(dir, "token.json")
-}
+// providerResourceData is the data the provider passes on to resources,
+// along with its client.
+type providerResourceData struct {
+	client *pagerduty.Client
 
-func availableOauthScopes() []string {
-	return []string{
-		"abilities.read",
-		"addons.read",
-		"addons.write",
-		"analytics.read",
-		"audit_records.read",
-		"change_events.read",
-		"change_events.write",
-		"custom_fields.read",
-		"custom_fields.write",
-		"escalation_policies.read",
-		"escalation_policies.write",
-		"event_orchestrations.read",
-		"event_orchestrations.write",
-		"event_rules.read",
-		"event_rules.write",
-		"extension_schemas.read",
-		"extensions.read",
-		"extensions.write",
-		"incident_workflows.read",
-		"incident_workflows.write",
-		"incident_workflows:instances.write",
-		"incidents.read",
-		"incidents.write",
-		"licenses.read",
-		"notifications.read",
-		"oncalls.read",
-		"priorities.read",
-		"response_plays.read",
-		"response_plays.write",
-		"schedules.read",
-		"schedules.write",
-		"services.read",
-		"services.write",
-		"standards.read",
-		"standards.write",
-		"status_dashboards.read",
-		"status_pages.read",
-		"status_pages.write",
-		"subscribers.read",
-		"subscribers.write",
-		"tags.read",
-		"tags.write",
-		"teams.read",
-		"teams.write",
-		"templates.read",
-		"templates.write",
-		"users.read",
-		"users.write",
-		"users:contact_methods.read",
-		"users:contact_methods.write",
-		"users:sessions.read",
-		"users:sessions.write",
-		"vendors.read",
-	}
+	// Teams assigned to the objects which don't configure their team
+	defaultTeams []string
+
+	// Snapshots the objects are read from, nil unless `bulk_refresh` is
+	// enabled
+	snapshots *apiutil.Snapshots
 }
 
 // ConfigurePagerdutyClient sets a pagerduty API client in a pointer `dst` to
@@ -226,6 +202,9 @@ func ConfigurePagerdutyClient(dst **pagerduty.Client, providerData any) diag.Dia
 	if providerData == nil {
 		return diags
 	}
+	if data, ok := providerData.(*providerResourceData); ok {
+		providerData = data.client
+	}
 	client, ok := providerData.(*pagerduty.Client)
 	if !ok {
 		diags.AddError(
@@ -248,3 +227,20 @@ func ConfigurePagerdutyClient(dst **pagerduty.Client, providerData any) diag.Dia
 	*dst = client
 	return diags
 }
+
+// ConfigureDefaultTeams sets the default teams of the provider in a pointer
+// `dst` to the property of any resource struct assigning them.
+func ConfigureDefaultTeams(dst *[]string, providerData any) {
+	if data, ok := providerData.(*providerResourceData); ok {
+		*dst = data.defaultTeams
+	}
+}
+
+// ConfigureSnapshots sets the snapshots of the provider in a pointer `dst` to
+// the property of any resource struct reading from them, leaving it nil
+// unless `bulk_refresh` is enabled.
+func ConfigureSnapshots(dst **apiutil.Snapshots, providerData any) {
+	if data, ok := providerData.(*providerResourceData); ok {
+		*dst = data.snapshots
+	}
+}
@@ -0,0 +1,388 @@
+package pagerduty
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/PagerDuty/go-pagerduty"
+	"github.com/PagerDuty/terraform-provider-pagerduty/util"
+	"github.com/PagerDuty/terraform-provider-pagerduty/util/apiutil"
+	"github.com/PagerDuty/terraform-provider-pagerduty/util/validate"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/id"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
+)
+
+type dataSourceAuditRecords struct{ client *pagerduty.Client }
+
+var _ datasource.DataSourceWithConfigure = (*dataSourceAuditRecords)(nil)
+
+func (*dataSourceAuditRecords) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = "pagerduty_audit_records"
+}
+
+func (*dataSourceAuditRecords) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{Computed: true},
+			"root_resource_types": schema.ListAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			"root_resource_id": schema.StringAttribute{Optional: true},
+			"actor_id": schema.StringAttribute{
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot("actor_type")),
+				},
+			},
+			"actor_type": schema.StringAttribute{
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("user_reference", "api_key_reference", "app_reference"),
+				},
+			},
+			"method_type": schema.StringAttribute{
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("browser", "oauth", "api_token", "identity_provider", "other"),
+				},
+			},
+			"method_truncated_token": schema.StringAttribute{
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot("method_type")),
+				},
+			},
+			"actions": schema.ListAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			"since": schema.StringAttribute{
+				Optional:   true,
+				Validators: []validator.String{validate.IsRFC3339()},
+			},
+			"until": schema.StringAttribute{
+				Optional:   true,
+				Validators: []validator.String{validate.IsRFC3339()},
+			},
+			"records": schema.ListAttribute{
+				Computed:    true,
+				ElementType: auditRecordObjectType,
+			},
+		},
+	}
+}
+
+func (d *dataSourceAuditRecords) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	resp.Diagnostics.Append(ConfigurePagerdutyClient(&d.client, req.ProviderData)...)
+}
+
+func (d *dataSourceAuditRecords) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var model dataSourceAuditRecordsModel
+	log.Println("[INFO] Reading PagerDuty audit records")
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	opts := pagerduty.ListAuditRecordsOptions{
+		ActorID:              model.ActorID.ValueString(),
+		ActorType:            model.ActorType.ValueString(),
+		MethodType:           model.MethodType.ValueString(),
+		MethodTruncatedToken: model.MethodTruncatedToken.ValueString(),
+		Since:                model.Since.ValueString(),
+		Until:                model.Until.ValueString(),
+		Limit:                apiutil.Limit,
+	}
+	resp.Diagnostics.Append(model.RootResourceTypes.ElementsAs(ctx, &opts.RootResourcesTypes, true)...)
+	resp.Diagnostics.Append(model.Actions.ElementsAs(ctx, &opts.Actions, true)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var records []pagerduty.AuditRecord
+	var err error
+	if model.RootResourceID.IsNull() {
+		records, err = listAuditRecords(ctx, d.client, opts)
+	} else {
+		if len(opts.RootResourcesTypes) != 1 || !isAuditedResourceType(opts.RootResourcesTypes[0]) {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("root_resource_types"),
+				"Invalid root_resource_types",
+				fmt.Sprintf("root_resource_id requires root_resource_types to hold the type of the object, one of %s", strings.Join(auditedResourceTypes, ", ")),
+			)
+			return
+		}
+		records, err = listResourceAuditRecords(ctx, d.client, opts.RootResourcesTypes[0], model.RootResourceID.ValueString(), opts)
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading PagerDuty audit records",
+			err.Error(),
+		)
+		return
+	}
+
+	elements := make([]attr.Value, 0, len(records))
+	for _, r := range records {
+		e := flattenAuditRecord(r, &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		elements = append(elements, e)
+	}
+
+	model.ID = types.StringValue(id.UniqueId())
+	model.Records = types.ListValueMust(auditRecordObjectType, elements)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
+
+// listAuditRecords requests all the pages of audit records matching `opts`.
+// ListAuditRecordsPaginated isn't used since it fails to handle the last page,
+// which has no `next_cursor`.
+func listAuditRecords(ctx context.Context, client *pagerduty.Client, opts pagerduty.ListAuditRecordsOptions) ([]pagerduty.AuditRecord, error) {
+	var records []pagerduty.AuditRecord
+	for {
+		var response pagerduty.ListAuditRecordsResponse
+		err := retry.RetryContext(ctx, 2*time.Minute, func() *retry.RetryError {
+			var err error
+			response, err = client.ListAuditRecords(ctx, opts)
+			if err != nil {
+				if util.IsBadRequestError(err) {
+					return retry.NonRetryableError(err)
+				}
+				return retry.RetryableError(err)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		records = append(records, response.Records...)
+		if response.NextCursor == nil || *response.NextCursor == "" {
+			return records, nil
+		}
+		opts.Cursor = *response.NextCursor
+	}
+}
+
+// auditedResourceTypes are the types of objects with their own audit records
+// endpoint, as in `/services/{id}/audit/records`.
+var auditedResourceTypes = []string{"users", "teams", "schedules", "escalation_policies", "services"}
+
+func isAuditedResourceType(t string) bool {
+	return containsString(auditedResourceTypes, t)
+}
+
+func containsString(values []string, s string) bool {
+	for _, v := range values {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+// listResourceAuditRecords requests all the pages of audit records of the
+// object `id` of type `resourceType` matching `opts`. The endpoint of an
+// object only filters by date, so the records are filtered by the other
+// options here.
+func listResourceAuditRecords(ctx context.Context, client *pagerduty.Client, resourceType, id string, opts pagerduty.ListAuditRecordsOptions) ([]pagerduty.AuditRecord, error) {
+	// The client only knows about the `/audit/records` endpoint, so its
+	// requests are sent to the object's one.
+	c := *client
+	c.HTTPClient = &auditRecordsPathRewriter{HTTPClient: client.HTTPClient, path: "/" + resourceType + "/" + id + "/audit/records"}
+
+	records, err := listAuditRecords(ctx, &c, pagerduty.ListAuditRecordsOptions{
+		Since: opts.Since,
+		Until: opts.Until,
+		Limit: opts.Limit,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	matching := records[:0]
+	for _, r := range records {
+		if auditRecordMatches(r, opts) {
+			matching = append(matching, r)
+		}
+	}
+	return matching, nil
+}
+
+func auditRecordMatches(r pagerduty.AuditRecord, opts pagerduty.ListAuditRecordsOptions) bool {
+	if len(opts.Actions) > 0 && !containsString(opts.Actions, r.Action) {
+		return false
+	}
+	if opts.ActorType != "" {
+		found := false
+		for _, a := range r.Actors {
+			if a.Type == opts.ActorType && (opts.ActorID == "" || a.ID == opts.ActorID) {
+				found = true
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	if opts.MethodType != "" && r.Method.Type != opts.MethodType {
+		return false
+	}
+	if opts.MethodTruncatedToken != "" && r.Method.TruncatedToken != opts.MethodTruncatedToken {
+		return false
+	}
+	return true
+}
+
+// auditRecordsPathRewriter is a pagerduty.HTTPClient sending the requests to
+// the `/audit/records` endpoint to `path` instead.
+type auditRecordsPathRewriter struct {
+	pagerduty.HTTPClient
+	path string
+}
+
+func (r *auditRecordsPathRewriter) Do(req *http.Request) (*http.Response, error) {
+	if base, ok := strings.CutSuffix(req.URL.Path, "/audit/records"); ok {
+		req.URL.Path = base + r.path
+		req.URL.RawPath = ""
+	}
+	return r.HTTPClient.Do(req)
+}
+
+type dataSourceAuditRecordsModel struct {
+	ID                   types.String `tfsdk:"id"`
+	RootResourceTypes    types.List   `tfsdk:"root_resource_types"`
+	RootResourceID       types.String `tfsdk:"root_resource_id"`
+	ActorID              types.String `tfsdk:"actor_id"`
+	ActorType            types.String `tfsdk:"actor_type"`
+	MethodType           types.String `tfsdk:"method_type"`
+	MethodTruncatedToken types.String `tfsdk:"method_truncated_token"`
+	Actions              types.List   `tfsdk:"actions"`
+	Since                types.String `tfsdk:"since"`
+	Until                types.String `tfsdk:"until"`
+	Records              types.List   `tfsdk:"records"`
+}
+
+func flattenAuditRecord(r pagerduty.AuditRecord, diags *diag.Diagnostics) attr.Value {
+	actors := make([]attr.Value, 0, len(r.Actors))
+	for _, a := range r.Actors {
+		actors = append(actors, flattenAuditRecordReference(a, diags))
+	}
+
+	fields := make([]attr.Value, 0, len(r.Details.Fields))
+	for _, f := range r.Details.Fields {
+		v, d := types.ObjectValue(auditRecordFieldObjectType.AttrTypes, map[string]attr.Value{
+			"name":         types.StringValue(f.Name),
+			"description":  types.StringValue(f.Description),
+			"value":        types.StringValue(f.Value),
+			"before_value": types.StringValue(f.BeforeValue),
+		})
+		diags.Append(d...)
+		fields = append(fields, v)
+	}
+
+	references := make([]attr.Value, 0, len(r.Details.References))
+	for _, ref := range r.Details.References {
+		added := make([]attr.Value, 0, len(ref.Added))
+		for _, a := range ref.Added {
+			added = append(added, flattenAuditRecordReference(a, diags))
+		}
+		removed := make([]attr.Value, 0, len(ref.Removed))
+		for _, a := range ref.Removed {
+			removed = append(removed, flattenAuditRecordReference(a, diags))
+		}
+		v, d := types.ObjectValue(auditRecordChangedReferenceObjectType.AttrTypes, map[string]attr.Value{
+			"name":        types.StringValue(ref.Name),
+			"description": types.StringValue(ref.Description),
+			"added":       types.ListValueMust(auditRecordReferenceObjectType, added),
+			"removed":     types.ListValueMust(auditRecordReferenceObjectType, removed),
+		})
+		diags.Append(d...)
+		references = append(references, v)
+	}
+
+	v, d := types.ObjectValue(auditRecordObjectType.AttrTypes, map[string]attr.Value{
+		"id":                     types.StringValue(r.ID),
+		"execution_time":         types.StringValue(r.ExecutionTime),
+		"action":                 types.StringValue(r.Action),
+		"root_resource":          flattenAuditRecordReference(r.RootResource, diags),
+		"resource":               flattenAuditRecordReference(r.Details.Resource, diags),
+		"actors":                 types.ListValueMust(auditRecordReferenceObjectType, actors),
+		"method_type":            types.StringValue(r.Method.Type),
+		"method_description":     types.StringValue(r.Method.Description),
+		"method_truncated_token": types.StringValue(r.Method.TruncatedToken),
+		"remote_address":         types.StringValue(r.ExecutionContext.RemoteAddress),
+		"fields":                 types.ListValueMust(auditRecordFieldObjectType, fields),
+		"references":             types.ListValueMust(auditRecordChangedReferenceObjectType, references),
+	})
+	diags.Append(d...)
+	return v
+}
+
+func flattenAuditRecordReference(o pagerduty.APIObject, diags *diag.Diagnostics) attr.Value {
+	v, d := types.ObjectValue(auditRecordReferenceObjectType.AttrTypes, map[string]attr.Value{
+		"id":      types.StringValue(o.ID),
+		"type":    types.StringValue(o.Type),
+		"summary": types.StringValue(o.Summary),
+	})
+	diags.Append(d...)
+	return v
+}
+
+var auditRecordReferenceObjectType = types.ObjectType{
+	AttrTypes: map[string]attr.Type{
+		"id":      types.StringType,
+		"type":    types.StringType,
+		"summary": types.StringType,
+	},
+}
+
+var auditRecordFieldObjectType = types.ObjectType{
+	AttrTypes: map[string]attr.Type{
+		"name":         types.StringType,
+		"description":  types.StringType,
+		"value":        types.StringType,
+		"before_value": types.StringType,
+	},
+}
+
+var auditRecordChangedReferenceObjectType = types.ObjectType{
+	AttrTypes: map[string]attr.Type{
+		"name":        types.StringType,
+		"description": types.StringType,
+		"added":       types.ListType{ElemType: auditRecordReferenceObjectType},
+		"removed":     types.ListType{ElemType: auditRecordReferenceObjectType},
+	},
+}
+
+var auditRecordObjectType = types.ObjectType{
+	AttrTypes: map[string]attr.Type{
+		"id":                     types.StringType,
+		"execution_time":         types.StringType,
+		"action":                 types.StringType,
+		"root_resource":          auditRecordReferenceObjectType,
+		"resource":               auditRecordReferenceObjectType,
+		"actors":                 types.ListType{ElemType: auditRecordReferenceObjectType},
+		"method_type":            types.StringType,
+		"method_description":     types.StringType,
+		"method_truncated_token": types.StringType,
+		"remote_address":         types.StringType,
+		"fields":                 types.ListType{ElemType: auditRecordFieldObjectType},
+		"references":             types.ListType{ElemType: auditRecordChangedReferenceObjectType},
+	},
+}
@@ -0,0 +1,194 @@
+package pagerduty
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/PagerDuty/go-pagerduty"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func TestAccDataSourcePagerDutyAuditRecords_Basic(t *testing.T) {
+	team := fmt.Sprintf("tf-%s", acctest.RandString(5))
+	since := testAccTimeNow().Add(-time.Hour).Format(time.RFC3339)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourcePagerDutyAuditRecordsTeamConfig(team),
+			},
+			{
+				// Audit records take a few seconds to be available.
+				PreConfig: func() { time.Sleep(30 * time.Second) },
+				Config:    testAccDataSourcePagerDutyAuditRecordsConfig(team, since),
+				Check: resource.ComposeTestCheckFunc(
+					testAccDataSourcePagerDutyAuditRecords("data.pagerduty_audit_records.foo"),
+					resource.TestCheckResourceAttr("data.pagerduty_audit_records.foo", "records.0.action", "create"),
+					resource.TestCheckResourceAttrPair("data.pagerduty_audit_records.foo", "records.0.root_resource.id", "pagerduty_team.foo", "id"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDataSourcePagerDutyAuditRecords_ActorIDRequiresType(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: `
+data "pagerduty_audit_records" "foo" {
+  actor_id = "PUSER01"
+}
+`,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`Attribute "actor_type" must be specified when "actor_id" is specified`),
+			},
+		},
+	})
+}
+
+func testAccDataSourcePagerDutyAuditRecords(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		r := s.RootModule().Resources[n]
+		a := r.Primary.Attributes
+
+		if val, ok := a["records.#"]; !ok || val == "0" {
+			return fmt.Errorf("Expected %s to have at least 1 audit record", n)
+		}
+
+		testAtts := []string{"id", "execution_time", "action", "root_resource.type", "actors.#", "method_type", "fields.#"}
+		for _, att := range testAtts {
+			if _, ok := a[fmt.Sprintf("records.0.%s", att)]; !ok {
+				return fmt.Errorf("Expected the required attribute records.0.%s to exist", att)
+			}
+		}
+
+		return nil
+	}
+}
+
+func testAccDataSourcePagerDutyAuditRecordsTeamConfig(team string) string {
+	return fmt.Sprintf(`
+resource "pagerduty_team" "foo" {
+  name = "%s"
+}
+`, team)
+}
+
+func testAccDataSourcePagerDutyAuditRecordsConfig(team, since string) string {
+	return fmt.Sprintf(`
+%s
+
+data "pagerduty_audit_records" "foo" {
+  root_resource_types = ["teams"]
+  root_resource_id    = pagerduty_team.foo.id
+  actions             = ["create"]
+  since               = "%s"
+}
+`, testAccDataSourcePagerDutyAuditRecordsTeamConfig(team), since)
+}
+
+func TestListAuditRecords(t *testing.T) {
+	var cursors []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/audit/records" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		cursor := r.URL.Query().Get("cursor")
+		cursors = append(cursors, cursor)
+
+		response := map[string]interface{}{
+			"records": []map[string]interface{}{{
+				"id":            "R" + cursor,
+				"action":        "update",
+				"root_resource": map[string]string{"id": "PSERVICE", "type": "service_reference"},
+				"actors":        []map[string]string{{"id": "PUSER01", "type": "user_reference", "summary": "Alice"}},
+				"method":        map[string]string{"type": "browser"},
+				"details": map[string]interface{}{
+					"fields": []map[string]string{{"name": "name", "value": "new", "before_value": "old"}},
+				},
+			}},
+			"next_cursor": nil,
+		}
+		if cursor == "" {
+			response["next_cursor"] = "second"
+		}
+		json.NewEncoder(w).Encode(response)
+	}))
+	defer server.Close()
+
+	client := pagerduty.NewClient("foo", pagerduty.WithAPIEndpoint(server.URL))
+	records, err := listAuditRecords(context.Background(), client, pagerduty.ListAuditRecordsOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if fmt.Sprint(cursors) != "[ second]" {
+		t.Errorf("unexpected cursors requested: %q", cursors)
+	}
+	if len(records) != 2 || records[0].ID != "R" || records[1].ID != "Rsecond" {
+		t.Fatalf("unexpected records: %+v", records)
+	}
+
+	var diags diag.Diagnostics
+	v := flattenAuditRecord(records[0], &diags)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	fields := v.(types.Object).Attributes()["fields"].(types.List).Elements()
+	if len(fields) != 1 {
+		t.Fatalf("expected a field change; got %v", fields)
+	}
+	before := fields[0].(types.Object).Attributes()["before_value"].(types.String).ValueString()
+	if before != "old" {
+		t.Errorf("expected the value before the change to be %q; got %q", "old", before)
+	}
+}
+
+func TestListResourceAuditRecords(t *testing.T) {
+	var paths []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		if r.URL.Path != "/teams/PTEAM01/audit/records" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if r.URL.Query().Has("actions[]") {
+			t.Errorf("unexpected filter sent to the team's endpoint: %s", r.URL.RawQuery)
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"records": []map[string]interface{}{
+				{"id": "RCREATE", "action": "create", "root_resource": map[string]string{"id": "PTEAM01", "type": "team_reference"}},
+				{"id": "RUPDATE", "action": "update", "root_resource": map[string]string{"id": "PTEAM01", "type": "team_reference"}},
+			},
+			"next_cursor": nil,
+		})
+	}))
+	defer server.Close()
+
+	client := pagerduty.NewClient("foo", pagerduty.WithAPIEndpoint(server.URL))
+	records, err := listResourceAuditRecords(context.Background(), client, "teams", "PTEAM01", pagerduty.ListAuditRecordsOptions{Actions: []string{"create"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if fmt.Sprint(paths) != "[/teams/PTEAM01/audit/records]" {
+		t.Errorf("unexpected paths requested: %q", paths)
+	}
+	if len(records) != 1 || records[0].ID != "RCREATE" {
+		t.Fatalf("expected only the create record; got %+v", records)
+	}
+}
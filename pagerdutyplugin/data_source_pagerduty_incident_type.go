@@ -8,9 +8,11 @@ import (
 
 	"github.com/PagerDuty/go-pagerduty"
 	"github.com/PagerDuty/terraform-provider-pagerduty/util"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
 )
@@ -26,12 +28,18 @@ func (*dataSourceIncidentType) Metadata(ctx context.Context, req datasource.Meta
 func (*dataSourceIncidentType) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Attributes: map[string]schema.Attribute{
-			"id":           schema.StringAttribute{Computed: true},
-			"name":         schema.StringAttribute{Computed: true},
+			"id": schema.StringAttribute{Computed: true},
+			"name": schema.StringAttribute{
+				Optional: true,
+				Computed: true,
+				Validators: []validator.String{
+					stringvalidator.ExactlyOneOf(path.MatchRoot("name"), path.MatchRoot("display_name")),
+				},
+			},
 			"type":         schema.StringAttribute{Computed: true},
-			"display_name": schema.StringAttribute{Required: true},
+			"display_name": schema.StringAttribute{Optional: true, Computed: true},
 			"description":  schema.StringAttribute{Computed: true},
-			"parent_type":  schema.StringAttribute{Computed: true},
+			"parent_type":  schema.StringAttribute{Optional: true, Computed: true},
 			"enabled":      schema.BoolAttribute{Computed: true},
 		},
 	}
@@ -44,55 +52,59 @@ func (d *dataSourceIncidentType) Configure(ctx context.Context, req datasource.C
 func (d *dataSourceIncidentType) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
 	log.Println("[INFO] Reading PagerDuty incident type")
 
-	var searchName types.String
-	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("display_name"), &searchName)...)
+	var config dataSourceIncidentTypeModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	var found *pagerduty.IncidentType
-	err := retry.RetryContext(ctx, 2*time.Minute, func() *retry.RetryError {
-		response, err := d.client.ListIncidentTypes(ctx, pagerduty.ListIncidentTypesOptions{Filter: "all"})
-		if err != nil {
-			if util.IsBadRequestError(err) {
-				return retry.NonRetryableError(err)
-			}
-			return retry.RetryableError(err)
-		}
-		for _, it := range response.IncidentTypes {
-			if it.DisplayName == searchName.ValueString() {
-				found = &it
-				break
-			}
-		}
-		return nil
-	})
+	search := config.DisplayName.ValueString()
+	if !config.Name.IsNull() {
+		search = config.Name.ValueString()
+	}
+
+	incidentTypes, err := fetchIncidentTypes(ctx, d.client, "all")
 	if err != nil {
 		resp.Diagnostics.AddError(
-			fmt.Sprintf("Error reading PagerDuty incident type %s", searchName),
+			fmt.Sprintf("Error reading PagerDuty incident type %s", search),
 			err.Error(),
 		)
 		return
 	}
 
-	if found == nil {
+	var found []pagerduty.IncidentType
+	for _, it := range incidentTypes {
+		if !config.Name.IsNull() && it.Name != search {
+			continue
+		}
+		if !config.DisplayName.IsNull() && it.DisplayName != search {
+			continue
+		}
+		if !config.ParentType.IsNull() && !isIncidentTypeChildOf(it, config.ParentType.ValueString(), incidentTypes) {
+			continue
+		}
+		found = append(found, it)
+	}
+
+	if len(found) == 0 {
 		resp.Diagnostics.AddError(
-			fmt.Sprintf("Unable to locate any incident type with the name: %s", searchName),
+			fmt.Sprintf("Unable to locate any incident type with the name: %s", search),
 			"",
 		)
 		return
 	}
-
-	model := dataSourceIncidentTypeModel{
-		ID:          types.StringValue(found.ID),
-		Name:        types.StringValue(found.Name),
-		Type:        types.StringValue(found.Type),
-		DisplayName: types.StringValue(found.DisplayName),
-		Description: types.StringValue(found.Description),
-		Enabled:     types.BoolValue(found.Enabled),
+	if len(found) > 1 {
+		resp.Diagnostics.AddError(
+			fmt.Sprintf("Found %d incident types with the name: %s", len(found), search),
+			"Use the parent_type argument to narrow down the search.",
+		)
+		return
 	}
-	if found.Parent != nil {
-		model.ParentType = types.StringValue(found.Parent.ID)
+
+	model := flattenDataSourceIncidentType(found[0])
+	if !config.ParentType.IsNull() {
+		// The parent can be searched by name, which is kept as configured.
+		model.ParentType = config.ParentType
 	}
 	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
 }
@@ -106,3 +118,55 @@ type dataSourceIncidentTypeModel struct {
 	ParentType  types.String `tfsdk:"parent_type"`
 	Enabled     types.Bool   `tfsdk:"enabled"`
 }
+
+func flattenDataSourceIncidentType(it pagerduty.IncidentType) dataSourceIncidentTypeModel {
+	model := dataSourceIncidentTypeModel{
+		ID:          types.StringValue(it.ID),
+		Name:        types.StringValue(it.Name),
+		Type:        types.StringValue(it.Type),
+		DisplayName: types.StringValue(it.DisplayName),
+		Description: types.StringValue(it.Description),
+		ParentType:  types.StringNull(),
+		Enabled:     types.BoolValue(it.Enabled),
+	}
+	if it.Parent != nil {
+		model.ParentType = types.StringValue(it.Parent.ID)
+	}
+	return model
+}
+
+// fetchIncidentTypes lists the incident types of the account matching
+// `filter`, which is one of "enabled", "disabled" or "all".
+func fetchIncidentTypes(ctx context.Context, client *pagerduty.Client, filter string) ([]pagerduty.IncidentType, error) {
+	var incidentTypes []pagerduty.IncidentType
+	err := retry.RetryContext(ctx, 2*time.Minute, func() *retry.RetryError {
+		response, err := client.ListIncidentTypes(ctx, pagerduty.ListIncidentTypesOptions{Filter: filter})
+		if err != nil {
+			if util.IsBadRequestError(err) {
+				return retry.NonRetryableError(err)
+			}
+			return retry.RetryableError(err)
+		}
+		incidentTypes = response.IncidentTypes
+		return nil
+	})
+	return incidentTypes, err
+}
+
+// isIncidentTypeChildOf reports whether the parent of `it` is identified by
+// `parent`, which can be its ID, name or display name. The parent is looked
+// up by ID within `incidentTypes`.
+func isIncidentTypeChildOf(it pagerduty.IncidentType, parent string, incidentTypes []pagerduty.IncidentType) bool {
+	if it.Parent == nil {
+		return false
+	}
+	if it.Parent.ID == parent {
+		return true
+	}
+	for _, p := range incidentTypes {
+		if p.ID == it.Parent.ID {
+			return p.Name == parent || p.DisplayName == parent
+		}
+	}
+	return false
+}
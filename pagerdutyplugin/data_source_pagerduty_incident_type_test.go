@@ -0,0 +1,93 @@
+package pagerduty
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/PagerDuty/go-pagerduty"
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccDataSourcePagerDutyIncidentType_Basic(t *testing.T) {
+	name := fmt.Sprintf("tf_%s", acctest.RandString(5))
+	displayName := fmt.Sprintf("Terraform Test Incident Type %s", acctest.RandString(5))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourcePagerDutyIncidentTypeConfig(name, displayName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(
+						"data.pagerduty_incident_type.by_display_name", "id",
+						"pagerduty_incident_type.test", "id"),
+					resource.TestCheckResourceAttr(
+						"data.pagerduty_incident_type.by_display_name", "name", name),
+					resource.TestCheckResourceAttrPair(
+						"data.pagerduty_incident_type.by_name", "id",
+						"pagerduty_incident_type.test", "id"),
+					resource.TestCheckResourceAttr(
+						"data.pagerduty_incident_type.by_name", "display_name", displayName),
+					resource.TestCheckResourceAttr(
+						"data.pagerduty_incident_type.by_name", "parent_type", "Base Incident"),
+					resource.TestCheckResourceAttr(
+						"data.pagerduty_incident_type.base", "name", "incident_default"),
+					resource.TestCheckResourceAttr(
+						"data.pagerduty_incident_type.base", "enabled", "true"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourcePagerDutyIncidentTypeConfig(name, displayName string) string {
+	return fmt.Sprintf(`
+resource "pagerduty_incident_type" "test" {
+  name         = "%s"
+  display_name = "%s"
+  parent_type  = "incident_default"
+}
+
+data "pagerduty_incident_type" "by_display_name" {
+  display_name = pagerduty_incident_type.test.display_name
+}
+
+data "pagerduty_incident_type" "by_name" {
+  name        = pagerduty_incident_type.test.name
+  parent_type = "Base Incident"
+}
+
+data "pagerduty_incident_type" "base" {
+  name = "incident_default"
+}
+`, name, displayName)
+}
+
+func TestIsIncidentTypeChildOf(t *testing.T) {
+	incidentTypes := []pagerduty.IncidentType{
+		{ID: "PBASE", Name: "incident_default", DisplayName: "Base Incident"},
+		{ID: "PSEC", Name: "security", DisplayName: "Security", Parent: &pagerduty.APIReference{ID: "PBASE"}},
+		{ID: "PFRAUD", Name: "fraud", DisplayName: "Fraud", Parent: &pagerduty.APIReference{ID: "PSEC"}},
+	}
+
+	cases := []struct {
+		it     pagerduty.IncidentType
+		parent string
+		want   bool
+	}{
+		{incidentTypes[1], "PBASE", true},
+		{incidentTypes[1], "incident_default", true},
+		{incidentTypes[1], "Base Incident", true},
+		{incidentTypes[2], "incident_default", false},
+		{incidentTypes[2], "Security", true},
+		{incidentTypes[0], "incident_default", false},
+	}
+
+	for _, c := range cases {
+		if got := isIncidentTypeChildOf(c.it, c.parent, incidentTypes); got != c.want {
+			t.Errorf("isIncidentTypeChildOf(%s, %q) = %v, want %v", c.it.Name, c.parent, got, c.want)
+		}
+	}
+}
@@ -0,0 +1,123 @@
+package pagerduty
+
+import (
+	"context"
+	"log"
+
+	"github.com/PagerDuty/go-pagerduty"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/id"
+)
+
+type dataSourceIncidentTypes struct{ client *pagerduty.Client }
+
+var _ datasource.DataSourceWithConfigure = (*dataSourceIncidentTypes)(nil)
+
+func (*dataSourceIncidentTypes) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = "pagerduty_incident_types"
+}
+
+func (*dataSourceIncidentTypes) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id":          schema.StringAttribute{Computed: true},
+			"enabled":     schema.BoolAttribute{Optional: true},
+			"parent_type": schema.StringAttribute{Optional: true},
+			"incident_types": schema.ListAttribute{
+				Computed:    true,
+				ElementType: incidentTypeObjectType,
+			},
+		},
+	}
+}
+
+func (d *dataSourceIncidentTypes) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	resp.Diagnostics.Append(ConfigurePagerdutyClient(&d.client, req.ProviderData)...)
+}
+
+func (d *dataSourceIncidentTypes) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	log.Println("[INFO] Reading PagerDuty incident types")
+
+	var model dataSourceIncidentTypesModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// All incident types are listed, since the parents of the ones matching
+	// the filters may be needed to find them by name.
+	incidentTypes, err := fetchIncidentTypes(ctx, d.client, "all")
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading PagerDuty incident types",
+			err.Error(),
+		)
+		return
+	}
+
+	filtered := filterIncidentTypes(incidentTypes, model.Enabled, model.ParentType)
+	model.ID = types.StringValue(id.UniqueId())
+	model.IncidentTypes = flattenIncidentTypes(filtered, &resp.Diagnostics)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
+
+type dataSourceIncidentTypesModel struct {
+	ID            types.String `tfsdk:"id"`
+	Enabled       types.Bool   `tfsdk:"enabled"`
+	ParentType    types.String `tfsdk:"parent_type"`
+	IncidentTypes types.List   `tfsdk:"incident_types"`
+}
+
+// filterIncidentTypes returns the incident types matching `enabled` and
+// `parent`, which are ignored when null.
+func filterIncidentTypes(incidentTypes []pagerduty.IncidentType, enabled types.Bool, parent types.String) []pagerduty.IncidentType {
+	var filtered []pagerduty.IncidentType
+	for _, it := range incidentTypes {
+		if !enabled.IsNull() && it.Enabled != enabled.ValueBool() {
+			continue
+		}
+		if !parent.IsNull() && !isIncidentTypeChildOf(it, parent.ValueString(), incidentTypes) {
+			continue
+		}
+		filtered = append(filtered, it)
+	}
+	return filtered
+}
+
+func flattenIncidentTypes(incidentTypes []pagerduty.IncidentType, diags *diag.Diagnostics) types.List {
+	elements := make([]attr.Value, 0, len(incidentTypes))
+	for _, it := range incidentTypes {
+		model := flattenDataSourceIncidentType(it)
+		e, d := types.ObjectValue(incidentTypeObjectType.AttrTypes, map[string]attr.Value{
+			"id":           model.ID,
+			"name":         model.Name,
+			"type":         model.Type,
+			"display_name": model.DisplayName,
+			"description":  model.Description,
+			"parent_type":  model.ParentType,
+			"enabled":      model.Enabled,
+		})
+		diags.Append(d...)
+		if d.HasError() {
+			continue
+		}
+		elements = append(elements, e)
+	}
+	return types.ListValueMust(incidentTypeObjectType, elements)
+}
+
+var incidentTypeObjectType = types.ObjectType{
+	AttrTypes: map[string]attr.Type{
+		"id":           types.StringType,
+		"name":         types.StringType,
+		"type":         types.StringType,
+		"display_name": types.StringType,
+		"description":  types.StringType,
+		"parent_type":  types.StringType,
+		"enabled":      types.BoolType,
+	},
+}
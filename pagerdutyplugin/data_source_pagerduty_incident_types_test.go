@@ -0,0 +1,122 @@
+package pagerduty
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/PagerDuty/go-pagerduty"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func TestAccDataSourcePagerDutyIncidentTypes_Basic(t *testing.T) {
+	name := fmt.Sprintf("tf_%s", acctest.RandString(5))
+	displayName := fmt.Sprintf("Terraform Test Incident Type %s", acctest.RandString(5))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourcePagerDutyIncidentTypesConfig(name, displayName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDataSourcePagerDutyIncidentTypesContains("data.pagerduty_incident_types.all", name, true),
+					testAccCheckDataSourcePagerDutyIncidentTypesContains("data.pagerduty_incident_types.children", name, true),
+					testAccCheckDataSourcePagerDutyIncidentTypesContains("data.pagerduty_incident_types.enabled", name, false),
+					testAccCheckDataSourcePagerDutyIncidentTypesContains("data.pagerduty_incident_types.disabled", name, true),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckDataSourcePagerDutyIncidentTypesContains(n, name string, want bool) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		r, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+		a := r.Primary.Attributes
+
+		found := false
+		for i := 0; ; i++ {
+			v, ok := a[fmt.Sprintf("incident_types.%d.name", i)]
+			if !ok {
+				break
+			}
+			found = found || v == name
+		}
+		if found != want {
+			return fmt.Errorf("Expected %s to list incident type %q: %v, got %v", n, name, want, found)
+		}
+		return nil
+	}
+}
+
+func testAccDataSourcePagerDutyIncidentTypesConfig(name, displayName string) string {
+	return fmt.Sprintf(`
+resource "pagerduty_incident_type" "test" {
+  name         = "%s"
+  display_name = "%s"
+  parent_type  = "incident_default"
+  enabled      = false
+}
+
+data "pagerduty_incident_types" "all" {
+  depends_on = [pagerduty_incident_type.test]
+}
+
+data "pagerduty_incident_types" "children" {
+  parent_type = "incident_default"
+  depends_on  = [pagerduty_incident_type.test]
+}
+
+data "pagerduty_incident_types" "enabled" {
+  enabled    = true
+  depends_on = [pagerduty_incident_type.test]
+}
+
+data "pagerduty_incident_types" "disabled" {
+  enabled     = false
+  parent_type = "Base Incident"
+  depends_on  = [pagerduty_incident_type.test]
+}
+`, name, displayName)
+}
+
+func TestFilterIncidentTypes(t *testing.T) {
+	incidentTypes := []pagerduty.IncidentType{
+		{ID: "PBASE", Name: "incident_default", DisplayName: "Base Incident", Enabled: true},
+		{ID: "PSEC", Name: "security", Enabled: true, Parent: &pagerduty.APIReference{ID: "PBASE"}},
+		{ID: "POLD", Name: "old", Enabled: false, Parent: &pagerduty.APIReference{ID: "PBASE"}},
+		{ID: "PFRAUD", Name: "fraud", Enabled: true, Parent: &pagerduty.APIReference{ID: "PSEC"}},
+	}
+
+	cases := []struct {
+		name    string
+		enabled types.Bool
+		parent  types.String
+		want    []string
+	}{
+		{"no filters", types.BoolNull(), types.StringNull(), []string{"incident_default", "security", "old", "fraud"}},
+		{"enabled", types.BoolValue(true), types.StringNull(), []string{"incident_default", "security", "fraud"}},
+		{"disabled", types.BoolValue(false), types.StringNull(), []string{"old"}},
+		{"parent by name", types.BoolNull(), types.StringValue("incident_default"), []string{"security", "old"}},
+		{"enabled children", types.BoolValue(true), types.StringValue("Base Incident"), []string{"security"}},
+		{"parent by id", types.BoolNull(), types.StringValue("PSEC"), []string{"fraud"}},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			var got []string
+			for _, it := range filterIncidentTypes(incidentTypes, c.enabled, c.parent) {
+				got = append(got, it.Name)
+			}
+			if fmt.Sprint(got) != fmt.Sprint(c.want) {
+				t.Errorf("got %v, want %v", got, c.want)
+			}
+		})
+	}
+}
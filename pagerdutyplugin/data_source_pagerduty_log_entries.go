@@ -0,0 +1,164 @@
+package pagerduty
+
+import (
+	"context"
+	"log"
+
+	"github.com/PagerDuty/go-pagerduty"
+	"github.com/PagerDuty/terraform-provider-pagerduty/util/apiutil"
+	"github.com/PagerDuty/terraform-provider-pagerduty/util/validate"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/id"
+)
+
+type dataSourceLogEntries struct{ client *pagerduty.Client }
+
+var _ datasource.DataSourceWithConfigure = (*dataSourceLogEntries)(nil)
+
+func (*dataSourceLogEntries) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = "pagerduty_log_entries"
+}
+
+func (*dataSourceLogEntries) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id":          schema.StringAttribute{Computed: true},
+			"incident_id": schema.StringAttribute{Optional: true},
+			"team_ids": schema.ListAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Validators: []validator.List{
+					listvalidator.ConflictsWith(path.MatchRoot("incident_id")),
+				},
+			},
+			"since": schema.StringAttribute{
+				Optional:   true,
+				Validators: []validator.String{validate.IsRFC3339()},
+			},
+			"until": schema.StringAttribute{
+				Optional:   true,
+				Validators: []validator.String{validate.IsRFC3339()},
+			},
+			"is_overview": schema.BoolAttribute{Optional: true},
+			"log_entries": schema.ListAttribute{
+				Computed:    true,
+				ElementType: logEntryObjectType,
+			},
+		},
+	}
+}
+
+func (d *dataSourceLogEntries) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	resp.Diagnostics.Append(ConfigurePagerdutyClient(&d.client, req.ProviderData)...)
+}
+
+func (d *dataSourceLogEntries) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var model dataSourceLogEntriesModel
+	log.Println("[INFO] Reading PagerDuty log entries")
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var teamIDs []string
+	resp.Diagnostics.Append(model.TeamIDs.ElementsAs(ctx, &teamIDs, true)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var logEntries []pagerduty.LogEntry
+	err := apiutil.All(ctx, func(offset int) (bool, error) {
+		if !model.IncidentID.IsNull() {
+			list, err := d.client.ListIncidentLogEntriesWithContext(ctx, model.IncidentID.ValueString(), pagerduty.ListIncidentLogEntriesOptions{
+				Limit:      apiutil.Limit,
+				Offset:     uint(offset),
+				Since:      model.Since.ValueString(),
+				Until:      model.Until.ValueString(),
+				IsOverview: model.IsOverview.ValueBool(),
+			})
+			if err != nil {
+				return false, err
+			}
+			logEntries = append(logEntries, list.LogEntries...)
+			return list.More, nil
+		}
+
+		list, err := d.client.ListLogEntriesWithContext(ctx, pagerduty.ListLogEntriesOptions{
+			Limit:      apiutil.Limit,
+			Offset:     uint(offset),
+			Since:      model.Since.ValueString(),
+			Until:      model.Until.ValueString(),
+			IsOverview: model.IsOverview.ValueBool(),
+			TeamIDs:    teamIDs,
+		})
+		if err != nil {
+			return false, err
+		}
+		logEntries = append(logEntries, list.LogEntries...)
+		return list.More, nil
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading PagerDuty log entries",
+			err.Error(),
+		)
+		return
+	}
+
+	elements := make([]attr.Value, 0, len(logEntries))
+	for _, le := range logEntries {
+		e, diags := types.ObjectValue(logEntryObjectType.AttrTypes, map[string]attr.Value{
+			"id":            types.StringValue(le.ID),
+			"type":          types.StringValue(le.Type),
+			"summary":       types.StringValue(le.Summary),
+			"created_at":    types.StringValue(le.CreatedAt),
+			"channel":       types.StringValue(le.Channel.Type),
+			"agent_id":      types.StringValue(le.Agent.ID),
+			"agent_type":    types.StringValue(le.Agent.Type),
+			"agent_summary": types.StringValue(le.Agent.Summary),
+			"incident_id":   types.StringValue(le.Incident.ID),
+			"service_id":    types.StringValue(le.Service.ID),
+		})
+		resp.Diagnostics.Append(diags...)
+		if diags.HasError() {
+			continue
+		}
+		elements = append(elements, e)
+	}
+
+	model.ID = types.StringValue(id.UniqueId())
+	model.LogEntries = types.ListValueMust(logEntryObjectType, elements)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
+
+type dataSourceLogEntriesModel struct {
+	ID         types.String `tfsdk:"id"`
+	IncidentID types.String `tfsdk:"incident_id"`
+	TeamIDs    types.List   `tfsdk:"team_ids"`
+	Since      types.String `tfsdk:"since"`
+	Until      types.String `tfsdk:"until"`
+	IsOverview types.Bool   `tfsdk:"is_overview"`
+	LogEntries types.List   `tfsdk:"log_entries"`
+}
+
+var logEntryObjectType = types.ObjectType{
+	AttrTypes: map[string]attr.Type{
+		"id":            types.StringType,
+		"type":          types.StringType,
+		"summary":       types.StringType,
+		"created_at":    types.StringType,
+		"channel":       types.StringType,
+		"agent_id":      types.StringType,
+		"agent_type":    types.StringType,
+		"agent_summary": types.StringType,
+		"incident_id":   types.StringType,
+		"service_id":    types.StringType,
+	},
+}
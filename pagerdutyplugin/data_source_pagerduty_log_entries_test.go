@@ -0,0 +1,94 @@
+package pagerduty
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func TestAccDataSourcePagerDutyLogEntries_Basic(t *testing.T) {
+	team := fmt.Sprintf("tf-%s", acctest.RandString(5))
+	since := testAccTimeNow().Add(-7 * 24 * time.Hour).Format(time.RFC3339)
+	until := testAccTimeNow().Format(time.RFC3339)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourcePagerDutyLogEntriesConfig(team, since, until),
+				Check: resource.ComposeTestCheckFunc(
+					testAccDataSourcePagerDutyLogEntries("data.pagerduty_log_entries.all"),
+					resource.TestCheckResourceAttr("data.pagerduty_log_entries.team", "log_entries.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDataSourcePagerDutyLogEntries_IncidentConflictsWithTeams(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: `
+data "pagerduty_log_entries" "foo" {
+  incident_id = "PINCIDENT"
+  team_ids    = ["PTEAM"]
+}
+`,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`Attribute "incident_id" cannot be specified when "team_ids" is specified`),
+			},
+		},
+	})
+}
+
+func testAccDataSourcePagerDutyLogEntries(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		r := s.RootModule().Resources[n]
+		a := r.Primary.Attributes
+
+		if _, ok := a["log_entries.#"]; !ok {
+			return fmt.Errorf("Expected %s to have a list of log entries", n)
+		}
+		if a["log_entries.#"] == "0" {
+			return nil
+		}
+
+		testAtts := []string{"id", "type", "summary", "created_at", "channel", "agent_type"}
+		for _, att := range testAtts {
+			if _, ok := a[fmt.Sprintf("log_entries.0.%s", att)]; !ok {
+				return fmt.Errorf("Expected the required attribute log_entries.0.%s to exist", att)
+			}
+		}
+
+		return nil
+	}
+}
+
+func testAccDataSourcePagerDutyLogEntriesConfig(team, since, until string) string {
+	return fmt.Sprintf(`
+resource "pagerduty_team" "foo" {
+  name = "%s"
+}
+
+data "pagerduty_log_entries" "all" {
+  since       = "%[2]s"
+  until       = "%[3]s"
+  is_overview = true
+}
+
+data "pagerduty_log_entries" "team" {
+  team_ids = [pagerduty_team.foo.id]
+  since    = "%[2]s"
+  until    = "%[3]s"
+}
+`, team, since, until)
+}
@@ -0,0 +1,162 @@
+package pagerduty
+
+import (
+	"context"
+	"log"
+
+	"github.com/PagerDuty/go-pagerduty"
+	"github.com/PagerDuty/terraform-provider-pagerduty/util/apiutil"
+	"github.com/PagerDuty/terraform-provider-pagerduty/util/validate"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/id"
+)
+
+type dataSourceOnCalls struct{ client *pagerduty.Client }
+
+var _ datasource.DataSourceWithConfigure = (*dataSourceOnCalls)(nil)
+
+func (*dataSourceOnCalls) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = "pagerduty_oncalls"
+}
+
+func (*dataSourceOnCalls) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{Computed: true},
+			"escalation_policy_ids": schema.ListAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			"schedule_ids": schema.ListAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			"user_ids": schema.ListAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			"since": schema.StringAttribute{
+				Optional: true,
+				Validators: []validator.String{
+					validate.IsRFC3339(),
+					stringvalidator.AlsoRequires(path.MatchRoot("until")),
+				},
+			},
+			"until": schema.StringAttribute{
+				Optional: true,
+				Validators: []validator.String{
+					validate.IsRFC3339(),
+					stringvalidator.AlsoRequires(path.MatchRoot("since")),
+				},
+			},
+			"earliest": schema.BoolAttribute{Optional: true},
+			"oncalls": schema.ListAttribute{
+				Computed:    true,
+				ElementType: onCallObjectType,
+			},
+		},
+	}
+}
+
+func (d *dataSourceOnCalls) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	resp.Diagnostics.Append(ConfigurePagerdutyClient(&d.client, req.ProviderData)...)
+}
+
+func (d *dataSourceOnCalls) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var model dataSourceOnCallsModel
+	log.Println("[INFO] Reading PagerDuty on-calls")
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	opts := pagerduty.ListOnCallOptions{
+		Since:    model.Since.ValueString(),
+		Until:    model.Until.ValueString(),
+		Earliest: model.Earliest.ValueBool(),
+		Includes: []string{"users", "escalation_policies", "schedules"},
+	}
+	resp.Diagnostics.Append(model.EscalationPolicyIDs.ElementsAs(ctx, &opts.EscalationPolicyIDs, true)...)
+	resp.Diagnostics.Append(model.ScheduleIDs.ElementsAs(ctx, &opts.ScheduleIDs, true)...)
+	resp.Diagnostics.Append(model.UserIDs.ElementsAs(ctx, &opts.UserIDs, true)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var onCalls []pagerduty.OnCall
+	err := apiutil.All(ctx, func(offset int) (bool, error) {
+		opts.Limit = apiutil.Limit
+		opts.Offset = uint(offset)
+		list, err := d.client.ListOnCallsWithContext(ctx, opts)
+		if err != nil {
+			return false, err
+		}
+		onCalls = append(onCalls, list.OnCalls...)
+		return list.More, nil
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading PagerDuty on-calls",
+			err.Error(),
+		)
+		return
+	}
+
+	elements := make([]attr.Value, 0, len(onCalls))
+	for _, oc := range onCalls {
+		e, diags := types.ObjectValue(onCallObjectType.AttrTypes, map[string]attr.Value{
+			"escalation_level":       types.Int64Value(int64(oc.EscalationLevel)),
+			"start":                  types.StringValue(oc.Start),
+			"end":                    types.StringValue(oc.End),
+			"user_id":                types.StringValue(oc.User.ID),
+			"user_name":              types.StringValue(oc.User.Name),
+			"user_email":             types.StringValue(oc.User.Email),
+			"escalation_policy_id":   types.StringValue(oc.EscalationPolicy.ID),
+			"escalation_policy_name": types.StringValue(oc.EscalationPolicy.Name),
+			"schedule_id":            types.StringValue(oc.Schedule.ID),
+			"schedule_name":          types.StringValue(oc.Schedule.Name),
+		})
+		resp.Diagnostics.Append(diags...)
+		if diags.HasError() {
+			continue
+		}
+		elements = append(elements, e)
+	}
+
+	model.ID = types.StringValue(id.UniqueId())
+	model.OnCalls = types.ListValueMust(onCallObjectType, elements)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
+
+type dataSourceOnCallsModel struct {
+	ID                  types.String `tfsdk:"id"`
+	EscalationPolicyIDs types.List   `tfsdk:"escalation_policy_ids"`
+	ScheduleIDs         types.List   `tfsdk:"schedule_ids"`
+	UserIDs             types.List   `tfsdk:"user_ids"`
+	Since               types.String `tfsdk:"since"`
+	Until               types.String `tfsdk:"until"`
+	Earliest            types.Bool   `tfsdk:"earliest"`
+	OnCalls             types.List   `tfsdk:"oncalls"`
+}
+
+var onCallObjectType = types.ObjectType{
+	AttrTypes: map[string]attr.Type{
+		"escalation_level":       types.Int64Type,
+		"start":                  types.StringType,
+		"end":                    types.StringType,
+		"user_id":                types.StringType,
+		"user_name":              types.StringType,
+		"user_email":             types.StringType,
+		"escalation_policy_id":   types.StringType,
+		"escalation_policy_name": types.StringType,
+		"schedule_id":            types.StringType,
+		"schedule_name":          types.StringType,
+	},
+}
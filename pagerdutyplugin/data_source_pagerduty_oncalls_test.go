@@ -0,0 +1,136 @@
+package pagerduty
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func TestAccDataSourcePagerDutyOnCalls_Basic(t *testing.T) {
+	username := fmt.Sprintf("tf-%s", acctest.RandString(5))
+	email := fmt.Sprintf("%s@foo.test", username)
+	escalationPolicy := fmt.Sprintf("tf-%s", acctest.RandString(5))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourcePagerDutyOnCallsConfig(username, email, escalationPolicy),
+				Check: resource.ComposeTestCheckFunc(
+					testAccDataSourcePagerDutyOnCalls("data.pagerduty_oncalls.foo"),
+					resource.TestCheckResourceAttr("data.pagerduty_oncalls.foo", "oncalls.0.escalation_level", "1"),
+					resource.TestCheckResourceAttrPair("data.pagerduty_oncalls.foo", "oncalls.0.user_id", "pagerduty_user.foo", "id"),
+					resource.TestCheckResourceAttrPair("data.pagerduty_oncalls.foo", "oncalls.0.escalation_policy_id", "pagerduty_escalation_policy.foo", "id"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDataSourcePagerDutyOnCalls_TimeRange(t *testing.T) {
+	username := fmt.Sprintf("tf-%s", acctest.RandString(5))
+	email := fmt.Sprintf("%s@foo.test", username)
+	escalationPolicy := fmt.Sprintf("tf-%s", acctest.RandString(5))
+	since := testAccTimeNow().Add(24 * time.Hour).Round(time.Hour).Format(time.RFC3339)
+	until := testAccTimeNow().Add(48 * time.Hour).Round(time.Hour).Format(time.RFC3339)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourcePagerDutyOnCallsTimeRangeConfig(username, email, escalationPolicy, since, until),
+				Check: resource.ComposeTestCheckFunc(
+					testAccDataSourcePagerDutyOnCalls("data.pagerduty_oncalls.foo"),
+					resource.TestCheckResourceAttrPair("data.pagerduty_oncalls.foo", "oncalls.0.user_id", "pagerduty_user.foo", "id"),
+				),
+			},
+			{
+				Config:      testAccDataSourcePagerDutyOnCallsSinceOnlyConfig(since),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`Attribute "until" must be specified when "since" is specified`),
+			},
+		},
+	})
+}
+
+func testAccDataSourcePagerDutyOnCalls(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		r := s.RootModule().Resources[n]
+		a := r.Primary.Attributes
+
+		if val, ok := a["oncalls.#"]; !ok || val == "0" {
+			return fmt.Errorf("Expected %s to have at least 1 on-call entry", n)
+		}
+
+		testAtts := []string{"escalation_level", "start", "end", "user_id", "user_name", "escalation_policy_id"}
+		for _, att := range testAtts {
+			if _, ok := a[fmt.Sprintf("oncalls.0.%s", att)]; !ok {
+				return fmt.Errorf("Expected the required attribute oncalls.0.%s to exist", att)
+			}
+		}
+
+		return nil
+	}
+}
+
+func testAccDataSourcePagerDutyOnCallsEscalationPolicyConfig(username, email, escalationPolicy string) string {
+	return fmt.Sprintf(`
+resource "pagerduty_user" "foo" {
+  name  = "%s"
+  email = "%s"
+}
+
+resource "pagerduty_escalation_policy" "foo" {
+  name      = "%s"
+  num_loops = 1
+
+  rule {
+    escalation_delay_in_minutes = 10
+
+    target {
+      type = "user_reference"
+      id   = pagerduty_user.foo.id
+    }
+  }
+}
+`, username, email, escalationPolicy)
+}
+
+func testAccDataSourcePagerDutyOnCallsConfig(username, email, escalationPolicy string) string {
+	return fmt.Sprintf(`
+%s
+
+data "pagerduty_oncalls" "foo" {
+  escalation_policy_ids = [pagerduty_escalation_policy.foo.id]
+}
+`, testAccDataSourcePagerDutyOnCallsEscalationPolicyConfig(username, email, escalationPolicy))
+}
+
+func testAccDataSourcePagerDutyOnCallsTimeRangeConfig(username, email, escalationPolicy, since, until string) string {
+	return fmt.Sprintf(`
+%s
+
+data "pagerduty_oncalls" "foo" {
+  escalation_policy_ids = [pagerduty_escalation_policy.foo.id]
+  user_ids              = [pagerduty_user.foo.id]
+  since                 = "%s"
+  until                 = "%s"
+  earliest              = true
+}
+`, testAccDataSourcePagerDutyOnCallsEscalationPolicyConfig(username, email, escalationPolicy), since, until)
+}
+
+func testAccDataSourcePagerDutyOnCallsSinceOnlyConfig(since string) string {
+	return fmt.Sprintf(`
+data "pagerduty_oncalls" "foo" {
+  since = "%s"
+}
+`, since)
+}
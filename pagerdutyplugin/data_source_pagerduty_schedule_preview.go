@@ -0,0 +1,372 @@
+package pagerduty
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"regexp"
+	"sync"
+	"time"
+
+	"github.com/PagerDuty/go-pagerduty"
+	"github.com/PagerDuty/terraform-provider-pagerduty/util"
+	"github.com/PagerDuty/terraform-provider-pagerduty/util/validate"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/id"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
+)
+
+type dataSourceSchedulePreview struct{ client *pagerduty.Client }
+
+var _ datasource.DataSourceWithConfigure = (*dataSourceSchedulePreview)(nil)
+
+func (*dataSourceSchedulePreview) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = "pagerduty_schedule_preview"
+}
+
+func (*dataSourceSchedulePreview) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{Computed: true},
+			"time_zone": schema.StringAttribute{
+				Required:   true,
+				Validators: []validator.String{validate.IsTimeZone()},
+			},
+			"since": schema.StringAttribute{
+				Required:   true,
+				Validators: []validator.String{validate.IsRFC3339()},
+			},
+			"until": schema.StringAttribute{
+				Required:   true,
+				Validators: []validator.String{validate.IsRFC3339()},
+			},
+			"rendered_coverage_percentage": schema.StringAttribute{Computed: true},
+			"rendered_entries": schema.ListAttribute{
+				Computed:    true,
+				ElementType: schedulePreviewEntryObjectType,
+			},
+			"uncovered_intervals": schema.ListAttribute{
+				Computed:    true,
+				ElementType: schedulePreviewIntervalObjectType,
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"layer": schema.ListNestedBlock{
+				Validators: []validator.List{listvalidator.SizeAtLeast(1)},
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{Optional: true},
+						"start": schema.StringAttribute{
+							Required:   true,
+							Validators: []validator.String{validate.IsRFC3339()},
+						},
+						"end": schema.StringAttribute{
+							Optional:   true,
+							Validators: []validator.String{validate.IsRFC3339()},
+						},
+						"rotation_virtual_start": schema.StringAttribute{
+							Required:   true,
+							Validators: []validator.String{validate.IsRFC3339()},
+						},
+						"rotation_turn_length_seconds": schema.Int64Attribute{
+							Required:   true,
+							Validators: []validator.Int64{int64validator.Between(3600, 365*24*3600)},
+						},
+						"users": schema.ListAttribute{
+							Required:    true,
+							ElementType: types.StringType,
+							Validators:  []validator.List{listvalidator.SizeAtLeast(1)},
+						},
+						"rendered_coverage_percentage": schema.StringAttribute{Computed: true},
+					},
+					Blocks: map[string]schema.Block{
+						"restriction": schema.ListNestedBlock{
+							NestedObject: schema.NestedBlockObject{
+								Attributes: map[string]schema.Attribute{
+									"type": schema.StringAttribute{
+										Required: true,
+										Validators: []validator.String{
+											stringvalidator.OneOf("daily_restriction", "weekly_restriction"),
+										},
+									},
+									"start_time_of_day": schema.StringAttribute{
+										Required: true,
+										Validators: []validator.String{
+											stringvalidator.RegexMatches(regexp.MustCompile(`^([0-1][0-9]|2[0-3]):[0-5][0-9]:[0-5][0-9]$`), "must be of 00:00:00 format"),
+										},
+									},
+									"start_day_of_week": schema.Int64Attribute{
+										Optional:   true,
+										Validators: []validator.Int64{int64validator.Between(1, 7)},
+									},
+									"duration_seconds": schema.Int64Attribute{
+										Required:   true,
+										Validators: []validator.Int64{int64validator.Between(1, 7*24*3600-1)},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *dataSourceSchedulePreview) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	resp.Diagnostics.Append(ConfigurePagerdutyClient(&d.client, req.ProviderData)...)
+}
+
+func (d *dataSourceSchedulePreview) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var model dataSourceSchedulePreviewModel
+	log.Println("[INFO] Reading PagerDuty schedule preview")
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	window, err := parseTimeInterval(model.Since.ValueString(), model.Until.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("until"), "Invalid preview window", err.Error())
+		return
+	}
+
+	schedule := buildSchedulePreview(&model)
+	opts := pagerduty.PreviewScheduleOptions{
+		Since: model.Since.ValueString(),
+		Until: model.Until.ValueString(),
+	}
+
+	var preview *pagerduty.Schedule
+	err = retry.RetryContext(ctx, 2*time.Minute, func() *retry.RetryError {
+		var err error
+		preview, err = requestPreviewSchedule(ctx, d.client, schedule, opts)
+		if err != nil {
+			if util.IsBadRequestError(err) {
+				return retry.NonRetryableError(err)
+			}
+			return retry.RetryableError(err)
+		}
+		return nil
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading PagerDuty schedule preview",
+			err.Error(),
+		)
+		return
+	}
+
+	model.ID = types.StringValue(id.UniqueId())
+	resp.Diagnostics.Append(flattenSchedulePreview(preview, window, &model)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
+
+type dataSourceSchedulePreviewModel struct {
+	ID                         types.String                          `tfsdk:"id"`
+	TimeZone                   types.String                          `tfsdk:"time_zone"`
+	Since                      types.String                          `tfsdk:"since"`
+	Until                      types.String                          `tfsdk:"until"`
+	Layer                      []dataSourceSchedulePreviewLayerModel `tfsdk:"layer"`
+	RenderedCoveragePercentage types.String                          `tfsdk:"rendered_coverage_percentage"`
+	RenderedEntries            types.List                            `tfsdk:"rendered_entries"`
+	UncoveredIntervals         types.List                            `tfsdk:"uncovered_intervals"`
+}
+
+type dataSourceSchedulePreviewLayerModel struct {
+	Name                       types.String                                `tfsdk:"name"`
+	Start                      types.String                                `tfsdk:"start"`
+	End                        types.String                                `tfsdk:"end"`
+	RotationVirtualStart       types.String                                `tfsdk:"rotation_virtual_start"`
+	RotationTurnLengthSeconds  types.Int64                                 `tfsdk:"rotation_turn_length_seconds"`
+	Users                      []types.String                              `tfsdk:"users"`
+	Restriction                []dataSourceSchedulePreviewRestrictionModel `tfsdk:"restriction"`
+	RenderedCoveragePercentage types.String                                `tfsdk:"rendered_coverage_percentage"`
+}
+
+type dataSourceSchedulePreviewRestrictionModel struct {
+	Type            types.String `tfsdk:"type"`
+	StartTimeOfDay  types.String `tfsdk:"start_time_of_day"`
+	StartDayOfWeek  types.Int64  `tfsdk:"start_day_of_week"`
+	DurationSeconds types.Int64  `tfsdk:"duration_seconds"`
+}
+
+var schedulePreviewEntryObjectType = types.ObjectType{
+	AttrTypes: map[string]attr.Type{
+		"start":     types.StringType,
+		"end":       types.StringType,
+		"user_id":   types.StringType,
+		"user_name": types.StringType,
+	},
+}
+
+var schedulePreviewIntervalObjectType = types.ObjectType{
+	AttrTypes: map[string]attr.Type{
+		"start":            types.StringType,
+		"end":              types.StringType,
+		"duration_seconds": types.Int64Type,
+	},
+}
+
+func buildSchedulePreview(model *dataSourceSchedulePreviewModel) pagerduty.Schedule {
+	schedule := pagerduty.Schedule{
+		TimeZone: model.TimeZone.ValueString(),
+	}
+
+	for _, l := range model.Layer {
+		layer := pagerduty.ScheduleLayer{
+			Name:                      l.Name.ValueString(),
+			Start:                     l.Start.ValueString(),
+			End:                       l.End.ValueString(),
+			RotationVirtualStart:      l.RotationVirtualStart.ValueString(),
+			RotationTurnLengthSeconds: uint(l.RotationTurnLengthSeconds.ValueInt64()),
+		}
+		for _, u := range l.Users {
+			layer.Users = append(layer.Users, pagerduty.UserReference{
+				User: pagerduty.APIObject{ID: u.ValueString(), Type: "user_reference"},
+			})
+		}
+		for _, r := range l.Restriction {
+			layer.Restrictions = append(layer.Restrictions, pagerduty.Restriction{
+				Type:            r.Type.ValueString(),
+				StartTimeOfDay:  r.StartTimeOfDay.ValueString(),
+				StartDayOfWeek:  uint(r.StartDayOfWeek.ValueInt64()),
+				DurationSeconds: uint(r.DurationSeconds.ValueInt64()),
+			})
+		}
+		schedule.ScheduleLayers = append(schedule.ScheduleLayers, layer)
+	}
+
+	return schedule
+}
+
+func flattenSchedulePreview(preview *pagerduty.Schedule, window util.TimeInterval, model *dataSourceSchedulePreviewModel) (diags diag.Diagnostics) {
+	model.RenderedCoveragePercentage = types.StringValue(util.RenderRoundedPercentage(preview.FinalSchedule.RenderedCoveragePercentage))
+
+	// Layers are rendered in the same order they were sent
+	for i := range model.Layer {
+		percentage := ""
+		if i < len(preview.ScheduleLayers) {
+			percentage = util.RenderRoundedPercentage(preview.ScheduleLayers[i].RenderedCoveragePercentage)
+		}
+		model.Layer[i].RenderedCoveragePercentage = types.StringValue(percentage)
+	}
+
+	entries := make([]attr.Value, 0, len(preview.FinalSchedule.RenderedScheduleEntries))
+	covered := make([]util.TimeInterval, 0, len(preview.FinalSchedule.RenderedScheduleEntries))
+	for _, e := range preview.FinalSchedule.RenderedScheduleEntries {
+		obj, d := types.ObjectValue(schedulePreviewEntryObjectType.AttrTypes, map[string]attr.Value{
+			"start":     types.StringValue(e.Start),
+			"end":       types.StringValue(e.End),
+			"user_id":   types.StringValue(e.User.ID),
+			"user_name": types.StringValue(e.User.Summary),
+		})
+		diags.Append(d...)
+		entries = append(entries, obj)
+
+		interval, err := parseTimeInterval(e.Start, e.End)
+		if err != nil {
+			diags.AddError("Error parsing rendered schedule entry", err.Error())
+			continue
+		}
+		covered = append(covered, interval)
+	}
+	model.RenderedEntries = types.ListValueMust(schedulePreviewEntryObjectType, entries)
+
+	gaps := util.UncoveredTimeIntervals(window, covered)
+	intervals := make([]attr.Value, 0, len(gaps))
+	for _, g := range gaps {
+		obj, d := types.ObjectValue(schedulePreviewIntervalObjectType.AttrTypes, map[string]attr.Value{
+			"start":            types.StringValue(g.Start.Format(time.RFC3339)),
+			"end":              types.StringValue(g.End.Format(time.RFC3339)),
+			"duration_seconds": types.Int64Value(int64(g.Duration().Seconds())),
+		})
+		diags.Append(d...)
+		intervals = append(intervals, obj)
+	}
+	model.UncoveredIntervals = types.ListValueMust(schedulePreviewIntervalObjectType, intervals)
+
+	return diags
+}
+
+func parseTimeInterval(start, end string) (util.TimeInterval, error) {
+	startT, endT, err := util.ParseRFC3339Time("interval", start, end)
+	if err != nil {
+		return util.TimeInterval{}, err
+	}
+	if !endT.After(startT) {
+		return util.TimeInterval{}, fmt.Errorf("end %q must be after start %q", end, start)
+	}
+	return util.TimeInterval{Start: startT, End: endT}, nil
+}
+
+// requestPreviewSchedule renders a schedule without saving it. The client's
+// PreviewScheduleWithContext only reports whether the schedule is valid and
+// discards the rendered schedule sent back by the API, so the response is
+// captured from a copy of the client wrapping its HTTP client.
+func requestPreviewSchedule(ctx context.Context, client *pagerduty.Client, schedule pagerduty.Schedule, opts pagerduty.PreviewScheduleOptions) (*pagerduty.Schedule, error) {
+	recorder := &responseBodyRecorder{HTTPClient: client.HTTPClient}
+	c := *client
+	c.HTTPClient = recorder
+
+	if err := c.PreviewScheduleWithContext(ctx, schedule, opts); err != nil {
+		return nil, err
+	}
+
+	var target struct {
+		Schedule pagerduty.Schedule `json:"schedule"`
+	}
+	if err := json.Unmarshal(recorder.Body(), &target); err != nil {
+		return nil, fmt.Errorf("Could not decode JSON response: %v", err)
+	}
+	return &target.Schedule, nil
+}
+
+// responseBodyRecorder is a pagerduty.HTTPClient keeping a copy of the body of
+// the last response received.
+type responseBodyRecorder struct {
+	pagerduty.HTTPClient
+
+	mu   sync.Mutex
+	body []byte
+}
+
+func (r *responseBodyRecorder) Do(req *http.Request) (*http.Response, error) {
+	resp, err := r.HTTPClient.Do(req)
+	if err != nil || resp.Body == nil {
+		return resp, err
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.body = body
+
+	return resp, nil
+}
+
+func (r *responseBodyRecorder) Body() []byte {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.body
+}
@@ -0,0 +1,118 @@
+package pagerduty
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/PagerDuty/go-pagerduty"
+	"github.com/PagerDuty/terraform-provider-pagerduty/util"
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccDataSourcePagerDutySchedulePreview_Basic(t *testing.T) {
+	username := fmt.Sprintf("tf-%s", acctest.RandString(5))
+	email := fmt.Sprintf("%s@foo.test", username)
+	since := testAccTimeNow().Add(24 * time.Hour).Truncate(24 * time.Hour)
+	until := since.Add(7 * 24 * time.Hour)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourcePagerDutySchedulePreviewConfig(username, email, since.Format(time.RFC3339), until.Format(time.RFC3339)),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.pagerduty_schedule_preview.foo", "rendered_entries.0.user_id"),
+					resource.TestCheckResourceAttrSet("data.pagerduty_schedule_preview.foo", "rendered_coverage_percentage"),
+					resource.TestCheckResourceAttrSet("data.pagerduty_schedule_preview.foo", "layer.0.rendered_coverage_percentage"),
+					// Working hours only, nights and weekends are not covered
+					resource.TestCheckResourceAttrSet("data.pagerduty_schedule_preview.foo", "uncovered_intervals.0.start"),
+				),
+			},
+		},
+	})
+}
+
+func TestRequestPreviewSchedule(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/schedules/preview" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"schedule": map[string]interface{}{
+				"final_schedule": map[string]interface{}{
+					"rendered_coverage_percentage": 0.5,
+					"rendered_schedule_entries": []map[string]interface{}{
+						{"start": "2025-01-06T00:00:00Z", "end": "2025-01-06T12:00:00Z", "user": map[string]string{"id": "PUSER01", "summary": "Alice"}},
+					},
+				},
+			},
+		})
+	}))
+	defer server.Close()
+
+	client := pagerduty.NewClient("foo", pagerduty.WithAPIEndpoint(server.URL))
+	preview, err := requestPreviewSchedule(context.Background(), client, pagerduty.Schedule{}, pagerduty.PreviewScheduleOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	window := util.TimeInterval{
+		Start: time.Date(2025, 1, 6, 0, 0, 0, 0, time.UTC),
+		End:   time.Date(2025, 1, 7, 0, 0, 0, 0, time.UTC),
+	}
+	var model dataSourceSchedulePreviewModel
+	if diags := flattenSchedulePreview(preview, window, &model); diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	if got := model.RenderedCoveragePercentage.ValueString(); got != "50.00" {
+		t.Errorf("rendered_coverage_percentage: want 50.00, got %s", got)
+	}
+	if got := len(model.RenderedEntries.Elements()); got != 1 {
+		t.Errorf("rendered_entries: want 1 entry, got %d", got)
+	}
+	gaps := model.UncoveredIntervals.Elements()
+	if len(gaps) != 1 {
+		t.Fatalf("uncovered_intervals: want 1 interval, got %d", len(gaps))
+	}
+	if got := gaps[0].String(); got != `{"duration_seconds":43200,"end":"2025-01-07T00:00:00Z","start":"2025-01-06T12:00:00Z"}` {
+		t.Errorf("uncovered_intervals: got %s", got)
+	}
+}
+
+func testAccDataSourcePagerDutySchedulePreviewConfig(username, email, since, until string) string {
+	return fmt.Sprintf(`
+resource "pagerduty_user" "foo" {
+  name  = "%s"
+  email = "%s"
+}
+
+data "pagerduty_schedule_preview" "foo" {
+  time_zone = "Europe/Dublin"
+  since     = "%[3]s"
+  until     = "%[4]s"
+
+  layer {
+    name                         = "working hours"
+    start                        = "%[3]s"
+    rotation_virtual_start       = "%[3]s"
+    rotation_turn_length_seconds = 86400
+    users                        = [pagerduty_user.foo.id]
+
+    restriction {
+      type              = "daily_restriction"
+      start_time_of_day = "09:00:00"
+      duration_seconds  = 28800
+    }
+  }
+}
+`, username, email, since, until)
+}
@@ -0,0 +1,48 @@
+package pagerduty
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func TestAccPagerDutyScheduleOverride_import(t *testing.T) {
+	username := fmt.Sprintf("tf-%s", acctest.RandString(5))
+	email := fmt.Sprintf("%s@foo.test", username)
+	schedule := fmt.Sprintf("tf-%s", acctest.RandString(5))
+	start := testAccTimeNow().Add(24 * time.Hour).Round(1 * time.Hour).Format(time.RFC3339)
+	end := testAccTimeNow().Add(48 * time.Hour).Round(1 * time.Hour).Format(time.RFC3339)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories(),
+		CheckDestroy:             testAccCheckPagerDutyScheduleOverrideDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckPagerDutyScheduleOverrideConfig(username, email, schedule, start, end),
+			},
+			{
+				ResourceName:            "pagerduty_schedule_override.foo",
+				ImportStateIdFunc:       testAccCheckPagerDutyScheduleOverrideID,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"start", "end"},
+			},
+			{
+				ResourceName:  "pagerduty_schedule_override.foo",
+				ImportStateId: "wrongFormatID",
+				ImportState:   true,
+				ExpectError:   regexp.MustCompile(`Expecting an importation ID formed as`),
+			},
+		},
+	})
+}
+
+func testAccCheckPagerDutyScheduleOverrideID(s *terraform.State) (string, error) {
+	return fmt.Sprintf("%v:%v", s.RootModule().Resources["pagerduty_schedule.foo"].Primary.ID, s.RootModule().Resources["pagerduty_schedule_override.foo"].Primary.ID), nil
+}
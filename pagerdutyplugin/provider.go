@@ -6,14 +6,23 @@ import (
 	"log"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/PagerDuty/go-pagerduty"
+	"github.com/PagerDuty/terraform-provider-pagerduty/util/apiutil"
+	"github.com/PagerDuty/terraform-provider-pagerduty/util/cache"
+	"github.com/PagerDuty/terraform-provider-pagerduty/util/httpclient"
+	"github.com/PagerDuty/terraform-provider-pagerduty/util/oauthtoken"
+	"github.com/PagerDuty/terraform-provider-pagerduty/util/profile"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
@@ -33,6 +42,32 @@ func (p *Provider) Schema(_ context.Context, _ provider.SchemaRequest, resp *pro
 				"pd_client_id":     schema.StringAttribute{Optional: true},
 				"pd_client_secret": schema.StringAttribute{Optional: true},
 				"pd_subdomain":     schema.StringAttribute{Optional: true},
+				"scopes": schema.ListAttribute{
+					ElementType: types.StringType,
+					Optional:    true,
+				},
+				"token_cache_dir": schema.StringAttribute{Optional: true},
+			},
+		},
+	}
+	cacheBlock := schema.ListNestedBlock{
+		NestedObject: schema.NestedBlockObject{
+			Attributes: map[string]schema.Attribute{
+				"type": schema.StringAttribute{
+					Required:   true,
+					Validators: []validator.String{stringvalidator.OneOf(cache.Types()...)},
+				},
+				"max_age": schema.StringAttribute{Optional: true},
+				"prefill": schema.BoolAttribute{Optional: true},
+				"path":    schema.StringAttribute{Optional: true},
+				"url": schema.StringAttribute{
+					Optional:  true,
+					Sensitive: true,
+				},
+				"failure_mode": schema.StringAttribute{
+					Optional:   true,
+					Validators: []validator.String{stringvalidator.OneOf(cache.FailureModes()...)},
+				},
 			},
 		},
 	}
@@ -44,9 +79,32 @@ func (p *Provider) Schema(_ context.Context, _ provider.SchemaRequest, resp *pro
 			"token":                       schema.StringAttribute{Optional: true},
 			"user_token":                  schema.StringAttribute{Optional: true},
 			"insecure_tls":                schema.BoolAttribute{Optional: true},
+			"profile":                     schema.StringAttribute{Optional: true},
+			"bulk_refresh":                schema.BoolAttribute{Optional: true},
+			"max_retries": schema.Int64Attribute{
+				Optional:   true,
+				Validators: []validator.Int64{int64validator.AtLeast(0)},
+			},
+			"request_timeout": schema.Int64Attribute{
+				Optional:   true,
+				Validators: []validator.Int64{int64validator.AtLeast(1)},
+			},
+			"max_requests_per_minute": schema.Int64Attribute{
+				Optional:   true,
+				Validators: []validator.Int64{int64validator.AtLeast(0)},
+			},
+			"default_teams": schema.ListAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"default_tags": schema.ListAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+			},
 		},
 		Blocks: map[string]schema.Block{
 			"use_app_oauth_scoped_token": useAppOauthScopedTokenBlock,
+			"cache":                      cacheBlock,
 		},
 	}
 }
@@ -54,15 +112,20 @@ func (p *Provider) Schema(_ context.Context, _ provider.SchemaRequest, resp *pro
 func (p *Provider) DataSources(_ context.Context) [](func() datasource.DataSource) {
 	return [](func() datasource.DataSource){
 		func() datasource.DataSource { return &dataSourceAlertGroupingSetting{} },
+		func() datasource.DataSource { return &dataSourceAuditRecords{} },
 		func() datasource.DataSource { return &dataSourceBusinessService{} },
 		func() datasource.DataSource { return &dataSourceExtensionSchema{} },
 		func() datasource.DataSource { return &dataSourceIncidentTypeCustomField{} },
 		func() datasource.DataSource { return &dataSourceIncidentType{} },
+		func() datasource.DataSource { return &dataSourceIncidentTypes{} },
 		func() datasource.DataSource { return &dataSourceIntegration{} },
 		func() datasource.DataSource { return &dataSourceJiraCloudAccountMapping{} },
 		func() datasource.DataSource { return &dataSourceLicenses{} },
 		func() datasource.DataSource { return &dataSourceLicense{} },
+		func() datasource.DataSource { return &dataSourceLogEntries{} },
+		func() datasource.DataSource { return &dataSourceOnCalls{} },
 		func() datasource.DataSource { return &dataSourcePriority{} },
+		func() datasource.DataSource { return &dataSourceSchedulePreview{} },
 		func() datasource.DataSource { return &dataSourceService{} },
 		func() datasource.DataSource { return &dataSourceStandardsResourceScores{} },
 		func() datasource.DataSource { return &dataSourceStandardsResourcesScores{} },
@@ -81,6 +144,7 @@ func (p *Provider) Resources(_ context.Context) [](func() resource.Resource) {
 		func() resource.Resource { return &resourceIncidentTypeCustomField{} },
 		func() resource.Resource { return &resourceIncidentType{} },
 		func() resource.Resource { return &resourceJiraCloudAccountMappingRule{} },
+		func() resource.Resource { return &resourceScheduleOverride{} },
 		func() resource.Resource { return &resourceServiceDependency{} },
 		func() resource.Resource { return &resourceTagAssignment{} },
 		func() resource.Resource { return &resourceTag{} },
@@ -100,10 +164,25 @@ func (p *Provider) Configure(ctx context.Context, req provider.ConfigureRequest,
 		return
 	}
 
+	var prof *profile.Profile
+	profileName := args.Profile.ValueString()
+	if profileName == "" {
+		profileName = os.Getenv("PAGERDUTY_PROFILE")
+	}
+	if profileName != "" {
+		var err error
+		if prof, err = profile.Load(profileName); err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("profile"), "Cannot load profile", err.Error())
+			return
+		}
+	}
+
 	serviceRegion := args.ServiceRegion.ValueString()
 	if serviceRegion == "" {
 		if v, ok := os.LookupEnv("PAGERDUTY_SERVICE_REGION"); ok && v != "" {
 			serviceRegion = v
+		} else if prof != nil && prof.ServiceRegion != "" {
+			serviceRegion = strings.ToLower(prof.ServiceRegion)
 		} else {
 			serviceRegion = "us"
 		}
@@ -118,15 +197,47 @@ func (p *Provider) Configure(ctx context.Context, req provider.ConfigureRequest,
 	insecureTls := args.InsecureTls.Equal(types.BoolValue(true))
 
 	config := Config{
-		APIURL:              "https://api." + regionAPIURL + "pagerduty.com",
-		AppURL:              "https://app." + regionAPIURL + "pagerduty.com",
-		SkipCredsValidation: skipCredentialsValidation,
-		Token:               args.Token.ValueString(),
-		UserToken:           args.UserToken.ValueString(),
-		TerraformVersion:    req.TerraformVersion,
-		APIURLOverride:      args.APIURLOverride.ValueString(),
-		ServiceRegion:       serviceRegion,
-		InsecureTls:         insecureTls,
+		APIURL:               "https://api." + regionAPIURL + "pagerduty.com",
+		AppURL:               "https://app." + regionAPIURL + "pagerduty.com",
+		SkipCredsValidation:  skipCredentialsValidation,
+		Token:                args.Token.ValueString(),
+		UserToken:            args.UserToken.ValueString(),
+		TerraformVersion:     req.TerraformVersion,
+		APIURLOverride:       args.APIURLOverride.ValueString(),
+		ServiceRegion:        serviceRegion,
+		InsecureTls:          insecureTls,
+		MaxRetries:           httpclient.DefaultMaxRetries,
+		RequestTimeout:       httpclient.DefaultRequestTimeout * time.Second,
+		MaxRequestsPerMinute: httpclient.DefaultMaxRequestsPerMinute,
+	}
+
+	if !args.MaxRetries.IsNull() {
+		config.MaxRetries = int(args.MaxRetries.ValueInt64())
+	}
+	if !args.RequestTimeout.IsNull() {
+		config.RequestTimeout = time.Duration(args.RequestTimeout.ValueInt64()) * time.Second
+	}
+	if !args.MaxRequestsPerMinute.IsNull() {
+		config.MaxRequestsPerMinute = int(args.MaxRequestsPerMinute.ValueInt64())
+	}
+
+	if !args.Cache.IsNull() {
+		blockList := []cacheArguments{}
+		resp.Diagnostics.Append(args.Cache.ElementsAs(ctx, &blockList, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		c, err := blockList[0].open(ctx)
+		if err != nil {
+			if blockList[0].FailureMode.ValueString() != cache.FailureModeDisable {
+				resp.Diagnostics.AddAttributeError(path.Root("cache"), "Cannot open cache", err.Error())
+				return
+			}
+			// Reported to the user by the SDKv2 half of the provider.
+			log.Printf("[WARN] %s, carrying on without caching", err)
+		}
+		config.Cache = c
+		config.CachePrefill = c != nil && blockList[0].Prefill.ValueBool()
 	}
 
 	if config.APIURLOverride == "" && p.apiURLOverride != "" {
@@ -139,10 +250,22 @@ func (p *Provider) Configure(ctx context.Context, req provider.ConfigureRequest,
 		if resp.Diagnostics.HasError() {
 			return
 		}
+		var configuredScopes []string
+		resp.Diagnostics.Append(blockList[0].Scopes.ElementsAs(ctx, &configuredScopes, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		scopes, err := oauthtoken.Scopes(configuredScopes)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("use_app_oauth_scoped_token").AtListIndex(0).AtName("scopes"), "Invalid scopes", err.Error())
+			return
+		}
 		config.AppOauthScopedToken = &AppOauthScopedToken{
-			ClientID:     blockList[0].PdClientID.ValueString(),
-			ClientSecret: blockList[0].PdClientSecret.ValueString(),
-			Subdomain:    blockList[0].PdSubdomain.ValueString(),
+			ClientID:      blockList[0].PdClientID.ValueString(),
+			ClientSecret:  blockList[0].PdClientSecret.ValueString(),
+			Subdomain:     blockList[0].PdSubdomain.ValueString(),
+			Scopes:        scopes,
+			TokenCacheDir: blockList[0].TokenCacheDir.ValueString(),
 		}
 	}
 
@@ -153,7 +276,22 @@ func (p *Provider) Configure(ctx context.Context, req provider.ConfigureRequest,
 		if config.UserToken == "" {
 			config.UserToken = os.Getenv("PAGERDUTY_USER_TOKEN")
 		}
-	} else {
+		if prof != nil {
+			if config.Token == "" {
+				config.Token = prof.Token
+			}
+			if config.UserToken == "" {
+				config.UserToken = prof.UserToken
+			}
+			// A profile with the credentials of a Scoped OAuth client, and
+			// no API token, authenticates with an App OAuth token.
+			if config.Token == "" && prof.HasAppOauthCredentials() {
+				config.AppOauthScopedToken = &AppOauthScopedToken{Scopes: oauthtoken.AvailableScopes()}
+			}
+		}
+	}
+
+	if config.AppOauthScopedToken != nil {
 		if config.AppOauthScopedToken.ClientID == "" {
 			config.AppOauthScopedToken.ClientID = os.Getenv("PAGERDUTY_CLIENT_ID")
 		}
@@ -163,6 +301,20 @@ func (p *Provider) Configure(ctx context.Context, req provider.ConfigureRequest,
 		if config.AppOauthScopedToken.Subdomain == "" {
 			config.AppOauthScopedToken.Subdomain = os.Getenv("PAGERDUTY_SUBDOMAIN")
 		}
+		if config.AppOauthScopedToken.TokenCacheDir == "" {
+			config.AppOauthScopedToken.TokenCacheDir = os.Getenv("PAGERDUTY_TOKEN_CACHE_DIR")
+		}
+		if prof != nil {
+			if config.AppOauthScopedToken.ClientID == "" {
+				config.AppOauthScopedToken.ClientID = prof.ClientID
+			}
+			if config.AppOauthScopedToken.ClientSecret == "" {
+				config.AppOauthScopedToken.ClientSecret = prof.ClientSecret
+			}
+			if config.AppOauthScopedToken.Subdomain == "" {
+				config.AppOauthScopedToken.Subdomain = prof.Subdomain
+			}
+		}
 	}
 
 	if config.AppOauthScopedToken != nil {
@@ -196,15 +348,51 @@ func (p *Provider) Configure(ctx context.Context, req provider.ConfigureRequest,
 	if err != nil {
 		resp.Diagnostics.AddError("Cannot obtain plugin client", err.Error())
 	}
+	var defaultTeams []string
+	resp.Diagnostics.Append(args.DefaultTeams.ElementsAs(ctx, &defaultTeams, false)...)
+
 	p.client = client
 	resp.DataSourceData = client
-	resp.ResourceData = client
+	data := &providerResourceData{client: client, defaultTeams: defaultTeams}
+	if args.BulkRefresh.ValueBool() {
+		data.snapshots = &apiutil.Snapshots{}
+	}
+	resp.ResourceData = data
 }
 
 type UseAppOauthScopedToken struct {
 	PdClientID     types.String `tfsdk:"pd_client_id"`
 	PdClientSecret types.String `tfsdk:"pd_client_secret"`
 	PdSubdomain    types.String `tfsdk:"pd_subdomain"`
+	Scopes         types.List   `tfsdk:"scopes"`
+	TokenCacheDir  types.String `tfsdk:"token_cache_dir"`
+}
+
+type cacheArguments struct {
+	Type        types.String `tfsdk:"type"`
+	MaxAge      types.String `tfsdk:"max_age"`
+	Prefill     types.Bool   `tfsdk:"prefill"`
+	Path        types.String `tfsdk:"path"`
+	URL         types.String `tfsdk:"url"`
+	FailureMode types.String `tfsdk:"failure_mode"`
+}
+
+// open returns the cache configured by the `cache` block, shared with the
+// SDKv2 half of the provider.
+func (a cacheArguments) open(ctx context.Context) (*cache.Cache, error) {
+	opts := cache.Options{
+		Type: a.Type.ValueString(),
+		Path: a.Path.ValueString(),
+		URL:  a.URL.ValueString(),
+	}
+	if v := a.MaxAge.ValueString(); v != "" {
+		maxAge, err := time.ParseDuration(v)
+		if err != nil || maxAge <= 0 {
+			return nil, fmt.Errorf("cache max_age must be a positive duration, such as 30s or 10m, got %q", v)
+		}
+		opts.MaxAge = maxAge
+	}
+	return cache.Shared(ctx, opts)
 }
 
 type providerArguments struct {
@@ -215,6 +403,14 @@ type providerArguments struct {
 	APIURLOverride            types.String `tfsdk:"api_url_override"`
 	UseAppOauthScopedToken    types.List   `tfsdk:"use_app_oauth_scoped_token"`
 	InsecureTls               types.Bool   `tfsdk:"insecure_tls"`
+	MaxRetries                types.Int64  `tfsdk:"max_retries"`
+	RequestTimeout            types.Int64  `tfsdk:"request_timeout"`
+	MaxRequestsPerMinute      types.Int64  `tfsdk:"max_requests_per_minute"`
+	Profile                   types.String `tfsdk:"profile"`
+	DefaultTeams              types.List   `tfsdk:"default_teams"`
+	DefaultTags               types.List   `tfsdk:"default_tags"`
+	Cache                     types.List   `tfsdk:"cache"`
+	BulkRefresh               types.Bool   `tfsdk:"bulk_refresh"`
 }
 
 type SchemaGetter interface {
@@ -8,15 +8,26 @@ import (
 
 	pd "github.com/PagerDuty/terraform-provider-pagerduty/pagerduty"
 	"github.com/PagerDuty/terraform-provider-pagerduty/util"
+	"github.com/PagerDuty/terraform-provider-pagerduty/util/mockapi"
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
 	"github.com/hashicorp/terraform-plugin-mux/tf5muxserver"
+	sdkdiag "github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
 )
 
 var testAccProvider = New()
 
+// testAccAPIURLOverride is the URL of the mock API acceptance tests run
+// against when PAGERDUTY_ACC_MOCK_API is set.
+var testAccAPIURLOverride = mockapi.StartForAcceptanceTests()
+
+func init() {
+	testAccProvider.apiURLOverride = testAccAPIURLOverride
+}
+
 func testAccPreCheck(t *testing.T) {
 	if v := os.Getenv("PAGERDUTY_PARALLEL"); v != "" {
 		t.Parallel()
@@ -57,7 +68,7 @@ func testAccProtoV5ProviderFactories() map[string]func() (tfprotov5.ProviderServ
 		"pagerduty": func() (tfprotov5.ProviderServer, error) {
 			ctx := context.Background()
 			providers := []func() tfprotov5.ProviderServer{
-				pd.Provider(pd.IsMuxed).GRPCProvider,
+				testAccSDKv2ProviderWithAPIURLOverride().GRPCProvider,
 				providerserver.NewProtocol5(testAccProvider),
 			}
 
@@ -71,6 +82,26 @@ func testAccProtoV5ProviderFactories() map[string]func() (tfprotov5.ProviderServ
 	}
 }
 
+// testAccSDKv2ProviderWithAPIURLOverride returns the SDKv2 half of the
+// provider, which uses the mock API when acceptance tests run against it
+// unless `api_url_override` is configured.
+func testAccSDKv2ProviderWithAPIURLOverride() *schema.Provider {
+	p := pd.Provider(pd.IsMuxed)
+	if testAccAPIURLOverride == "" {
+		return p
+	}
+
+	configure := p.ConfigureContextFunc
+	p.ConfigureContextFunc = func(ctx context.Context, d *schema.ResourceData) (interface{}, sdkdiag.Diagnostics) {
+		meta, diags := configure(ctx, d)
+		if config, ok := meta.(*pd.Config); ok && config.ApiUrlOverride == "" {
+			config.ApiUrlOverride = testAccAPIURLOverride
+		}
+		return meta, diags
+	}
+	return p
+}
+
 // testAccTimeNow returns the current time in the given location. The location
 // defaults to Europe/Dublin but can be controlled by the PAGERDUTY_TIME_ZONE
 // environment variable. The location must match the PagerDuty account time
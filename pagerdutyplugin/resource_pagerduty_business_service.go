@@ -22,12 +22,14 @@ import (
 )
 
 type resourceBusinessService struct {
-	client *pagerduty.Client
+	client       *pagerduty.Client
+	defaultTeams []string
 }
 
 var (
 	_ resource.ResourceWithConfigure   = (*resourceBusinessService)(nil)
 	_ resource.ResourceWithImportState = (*resourceBusinessService)(nil)
+	_ resource.ResourceWithModifyPlan  = (*resourceBusinessService)(nil)
 )
 
 func (r *resourceBusinessService) Metadata(_ context.Context, _ resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -60,7 +62,12 @@ func (r *resourceBusinessService) Schema(_ context.Context, _ resource.SchemaReq
 				Computed:      true,
 				PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
 			},
-			"team": schema.StringAttribute{Optional: true},
+			"team": schema.StringAttribute{
+				Optional: true,
+				// Planned as the first default team of the provider when not
+				// configured.
+				Computed: true,
+			},
 			"type": schema.StringAttribute{
 				Optional:           true,
 				Computed:           true,
@@ -175,8 +182,27 @@ func (r *resourceBusinessService) Delete(ctx context.Context, req resource.Delet
 	resp.State.RemoveResource(ctx)
 }
 
+func (r *resourceBusinessService) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var team types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("team"), &team)...)
+	if resp.Diagnostics.HasError() || !team.IsNull() {
+		return
+	}
+
+	planned := types.StringNull()
+	if len(r.defaultTeams) > 0 {
+		planned = types.StringValue(r.defaultTeams[0])
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("team"), planned)...)
+}
+
 func (r *resourceBusinessService) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	resp.Diagnostics.Append(ConfigurePagerdutyClient(&r.client, req.ProviderData)...)
+	ConfigureDefaultTeams(&r.defaultTeams, req.ProviderData)
 }
 
 func (r *resourceBusinessService) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
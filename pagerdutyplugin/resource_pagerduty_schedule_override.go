@@ -0,0 +1,381 @@
+package pagerduty
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/PagerDuty/go-pagerduty"
+	"github.com/PagerDuty/terraform-provider-pagerduty/util"
+	"github.com/PagerDuty/terraform-provider-pagerduty/util/validate"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
+)
+
+type resourceScheduleOverride struct{ client *pagerduty.Client }
+
+var (
+	_ resource.ResourceWithConfigure        = (*resourceScheduleOverride)(nil)
+	_ resource.ResourceWithImportState      = (*resourceScheduleOverride)(nil)
+	_ resource.ResourceWithConfigValidators = (*resourceScheduleOverride)(nil)
+)
+
+// The overrides API only lists overrides overlapping a requested time range,
+// so on import the override is searched within this window around now.
+const (
+	scheduleOverrideImportLookback  = 365 * 24 * time.Hour
+	scheduleOverrideImportLookahead = 2 * 365 * 24 * time.Hour
+)
+
+func (r *resourceScheduleOverride) Metadata(_ context.Context, _ resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = "pagerduty_schedule_override"
+}
+
+func (r *resourceScheduleOverride) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:      true,
+				PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+			"schedule": schema.StringAttribute{
+				Required:      true,
+				PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			},
+			"user": schema.StringAttribute{
+				Required:      true,
+				PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			},
+			"start": schema.StringAttribute{
+				Required:      true,
+				Validators:    []validator.String{validate.IsRFC3339()},
+				PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			},
+			"end": schema.StringAttribute{
+				Required:      true,
+				Validators:    []validator.String{validate.IsRFC3339()},
+				PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			},
+		},
+	}
+}
+
+func (r *resourceScheduleOverride) ConfigValidators(_ context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{scheduleOverrideTimeRangeValidator{}}
+}
+
+func (r *resourceScheduleOverride) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var model resourceScheduleOverrideModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	scheduleID := model.Schedule.ValueString()
+	plan := buildPagerdutyScheduleOverride(&model)
+	log.Printf("[INFO] Creating PagerDuty schedule override for user %s in schedule %s", plan.User.ID, scheduleID)
+
+	var id string
+	err := retry.RetryContext(ctx, 2*time.Minute, func() *retry.RetryError {
+		response, err := r.client.CreateOverrideWithContext(ctx, scheduleID, plan)
+		if err != nil {
+			if util.IsBadRequestError(err) {
+				return retry.NonRetryableError(err)
+			}
+			return retry.RetryableError(err)
+		}
+		id = response.ID
+		return nil
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			fmt.Sprintf("Error creating PagerDuty schedule override for schedule %s", scheduleID),
+			err.Error(),
+		)
+		return
+	}
+
+	override, err := requestGetScheduleOverride(ctx, r.client, scheduleID, id, plan.Start, plan.End, true)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			fmt.Sprintf("Error reading PagerDuty schedule override %s", id),
+			err.Error(),
+		)
+		return
+	}
+
+	model = flattenScheduleOverride(scheduleID, override, model)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
+
+func (r *resourceScheduleOverride) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state resourceScheduleOverrideModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	log.Printf("[INFO] Reading PagerDuty schedule override %s", state.ID)
+
+	scheduleID := state.Schedule.ValueString()
+	override, err := requestGetScheduleOverride(ctx, r.client, scheduleID, state.ID.ValueString(), state.Start.ValueString(), state.End.ValueString(), false)
+	if err != nil {
+		if util.IsNotFoundError(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError(
+			fmt.Sprintf("Error reading PagerDuty schedule override %s", state.ID),
+			err.Error(),
+		)
+		return
+	}
+
+	if override == nil {
+		if isScheduleOverrideEnded(state.End.ValueString()) {
+			// Overrides that already ended are eventually dropped by the API,
+			// keeping the last known state avoids planning to re-create an
+			// override in the past.
+			log.Printf("[INFO] PagerDuty schedule override %s has already ended, keeping last known state", state.ID)
+			return
+		}
+		log.Printf("[WARN] Removing %s because it's gone", state.ID)
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	state = flattenScheduleOverride(scheduleID, override, state)
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+func (r *resourceScheduleOverride) Update(_ context.Context, _ resource.UpdateRequest, _ *resource.UpdateResponse) {
+}
+
+func (r *resourceScheduleOverride) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state resourceScheduleOverrideModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if isScheduleOverrideEnded(state.End.ValueString()) {
+		log.Printf("[INFO] PagerDuty schedule override %s has already ended, nothing to delete", state.ID)
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	scheduleID, id := state.Schedule.ValueString(), state.ID.ValueString()
+	log.Printf("[INFO] Deleting PagerDuty schedule override %s from schedule %s", id, scheduleID)
+
+	err := retry.RetryContext(ctx, 2*time.Minute, func() *retry.RetryError {
+		err := r.client.DeleteOverrideWithContext(ctx, scheduleID, id)
+		if err != nil {
+			if util.IsBadRequestError(err) {
+				return retry.NonRetryableError(err)
+			}
+			if util.IsNotFoundError(err) {
+				return nil
+			}
+			return retry.RetryableError(err)
+		}
+		return nil
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			fmt.Sprintf("Error deleting PagerDuty schedule override %s", id),
+			err.Error(),
+		)
+		return
+	}
+	resp.State.RemoveResource(ctx)
+}
+
+func (r *resourceScheduleOverride) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	resp.Diagnostics.Append(ConfigurePagerdutyClient(&r.client, req.ProviderData)...)
+}
+
+func (r *resourceScheduleOverride) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	scheduleID, id, err := util.ResourcePagerDutyParseColonCompoundID(req.ID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error importing pagerduty_schedule_override",
+			"Expecting an importation ID formed as '<schedule_id>:<override_id>'",
+		)
+		return
+	}
+
+	now := time.Now().UTC()
+	since := now.Add(-scheduleOverrideImportLookback).Format(time.RFC3339)
+	until := now.Add(scheduleOverrideImportLookahead).Format(time.RFC3339)
+
+	override, err := requestGetScheduleOverride(ctx, r.client, scheduleID, id, since, until, false)
+	if err != nil {
+		resp.Diagnostics.AddError("Error importing pagerduty_schedule_override", err.Error())
+		return
+	}
+	if override == nil {
+		resp.Diagnostics.AddError(
+			"Error importing pagerduty_schedule_override",
+			fmt.Sprintf("Override %s not found in schedule %s", id, scheduleID),
+		)
+		return
+	}
+
+	state := flattenScheduleOverride(scheduleID, override, resourceScheduleOverrideModel{})
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+type resourceScheduleOverrideModel struct {
+	ID       types.String `tfsdk:"id"`
+	Schedule types.String `tfsdk:"schedule"`
+	User     types.String `tfsdk:"user"`
+	Start    types.String `tfsdk:"start"`
+	End      types.String `tfsdk:"end"`
+}
+
+// requestGetScheduleOverride looks for an override within the overrides of a
+// schedule listed between `since` and `until`. It returns a nil override when
+// it isn't listed anymore.
+func requestGetScheduleOverride(ctx context.Context, client *pagerduty.Client, scheduleID, id, since, until string, retryNotFound bool) (*pagerduty.Override, error) {
+	var found *pagerduty.Override
+
+	opts := pagerduty.ListOverridesOptions{
+		Since:    since,
+		Until:    until,
+		Overflow: true,
+	}
+
+	err := retry.RetryContext(ctx, 2*time.Minute, func() *retry.RetryError {
+		response, err := client.ListOverridesWithContext(ctx, scheduleID, opts)
+		if err != nil {
+			if util.IsBadRequestError(err) {
+				return retry.NonRetryableError(err)
+			}
+			if !retryNotFound && util.IsNotFoundError(err) {
+				return retry.NonRetryableError(err)
+			}
+			return retry.RetryableError(err)
+		}
+
+		for _, o := range response.Overrides {
+			if o.ID == id {
+				found = &o
+				return nil
+			}
+		}
+
+		if retryNotFound {
+			return retry.RetryableError(fmt.Errorf("override %s not found in schedule %s", id, scheduleID))
+		}
+		return nil
+	})
+
+	return found, err
+}
+
+func buildPagerdutyScheduleOverride(model *resourceScheduleOverrideModel) pagerduty.Override {
+	return pagerduty.Override{
+		Start: model.Start.ValueString(),
+		End:   model.End.ValueString(),
+		User: pagerduty.APIObject{
+			ID:   model.User.ValueString(),
+			Type: "user_reference",
+		},
+	}
+}
+
+// flattenScheduleOverride builds the state of an override from the API
+// response, preserving the timestamps of `prev` when they represent the same
+// moment in time, as the API renders them using the schedule's time zone.
+func flattenScheduleOverride(scheduleID string, override *pagerduty.Override, prev resourceScheduleOverrideModel) resourceScheduleOverrideModel {
+	return resourceScheduleOverrideModel{
+		ID:       types.StringValue(override.ID),
+		Schedule: types.StringValue(scheduleID),
+		User:     types.StringValue(override.User.ID),
+		Start:    types.StringValue(scheduleOverrideStartValue(prev.Start.ValueString(), override.Start)),
+		End:      types.StringValue(scheduleOverrideTimeValue(prev.End.ValueString(), override.End)),
+	}
+}
+
+func scheduleOverrideTimeValue(prev, received string) string {
+	prevT, receivedT, err := util.ParseRFC3339Time("time", prev, received)
+	if err != nil || !prevT.Equal(receivedT) {
+		return received
+	}
+	return prev
+}
+
+// scheduleOverrideStartValue behaves as scheduleOverrideTimeValue, but it also
+// keeps the configured start when it was in the past, because PagerDuty moves
+// the start of those overrides to the moment they were created.
+func scheduleOverrideStartValue(prev, received string) string {
+	prevT, receivedT, err := util.ParseRFC3339Time("start", prev, received)
+	if err != nil {
+		return received
+	}
+	if prevT.Equal(receivedT) || (prevT.Before(receivedT) && receivedT.Before(time.Now())) {
+		return prev
+	}
+	return received
+}
+
+func isScheduleOverrideEnded(end string) bool {
+	t, err := time.Parse(time.RFC3339, end)
+	if err != nil {
+		return false
+	}
+	return !t.After(time.Now())
+}
+
+type scheduleOverrideTimeRangeValidator struct{}
+
+var _ resource.ConfigValidator = (*scheduleOverrideTimeRangeValidator)(nil)
+
+func (v scheduleOverrideTimeRangeValidator) Description(context.Context) string {
+	return "Validates the end of the override is after its start"
+}
+
+func (v scheduleOverrideTimeRangeValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v scheduleOverrideTimeRangeValidator) ValidateResource(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var start, end types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("start"), &start)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("end"), &end)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(validateScheduleOverrideTimeRange(start, end)...)
+}
+
+func validateScheduleOverrideTimeRange(start, end types.String) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if start.IsNull() || start.IsUnknown() || end.IsNull() || end.IsUnknown() {
+		return diags
+	}
+
+	startT, endT, err := util.ParseRFC3339Time("start", start.ValueString(), end.ValueString())
+	if err != nil {
+		// Format errors are reported by the attribute validators
+		return diags
+	}
+	if !endT.After(startT) {
+		diags.AddAttributeError(
+			path.Root("end"),
+			"Invalid Value",
+			fmt.Sprintf("end %q must be after start %q", end.ValueString(), start.ValueString()),
+		)
+	}
+	return diags
+}
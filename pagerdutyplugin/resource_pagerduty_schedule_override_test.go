@@ -0,0 +1,177 @@
+package pagerduty
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/PagerDuty/go-pagerduty"
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func TestAccPagerDutyScheduleOverride_Basic(t *testing.T) {
+	username := fmt.Sprintf("tf-%s", acctest.RandString(5))
+	email := fmt.Sprintf("%s@foo.test", username)
+	schedule := fmt.Sprintf("tf-%s", acctest.RandString(5))
+	start := testAccTimeNow().Add(24 * time.Hour).Round(1 * time.Hour).Format(time.RFC3339)
+	end := testAccTimeNow().Add(48 * time.Hour).Round(1 * time.Hour).Format(time.RFC3339)
+	endUpdated := testAccTimeNow().Add(72 * time.Hour).Round(1 * time.Hour).Format(time.RFC3339)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories(),
+		CheckDestroy:             testAccCheckPagerDutyScheduleOverrideDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckPagerDutyScheduleOverrideConfig(username, email, schedule, start, end),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPagerDutyScheduleOverrideExists("pagerduty_schedule_override.foo"),
+					resource.TestCheckResourceAttr(
+						"pagerduty_schedule_override.foo", "start", start),
+					resource.TestCheckResourceAttr(
+						"pagerduty_schedule_override.foo", "end", end),
+					resource.TestCheckResourceAttrPair(
+						"pagerduty_schedule_override.foo", "user", "pagerduty_user.foo", "id"),
+					resource.TestCheckResourceAttrPair(
+						"pagerduty_schedule_override.foo", "schedule", "pagerduty_schedule.foo", "id"),
+				),
+			},
+			{
+				Config: testAccCheckPagerDutyScheduleOverrideConfig(username, email, schedule, start, endUpdated),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPagerDutyScheduleOverrideExists("pagerduty_schedule_override.foo"),
+					resource.TestCheckResourceAttr(
+						"pagerduty_schedule_override.foo", "end", endUpdated),
+				),
+			},
+			{
+				Config:      testAccCheckPagerDutyScheduleOverrideConfig(username, email, schedule, end, start),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("must be after start"),
+			},
+		},
+	})
+}
+
+func TestScheduleOverrideStartValue(t *testing.T) {
+	past := time.Now().Add(-2 * time.Hour).UTC().Truncate(time.Minute)
+	future := time.Now().Add(2 * time.Hour).UTC().Truncate(time.Minute)
+
+	cases := []struct {
+		name, prev, received, want string
+	}{
+		{
+			name:     "same moment in a different time zone",
+			prev:     future.Format(time.RFC3339),
+			received: future.In(time.FixedZone("", 3600)).Format(time.RFC3339),
+			want:     future.Format(time.RFC3339),
+		},
+		{
+			name:     "start in the past moved to creation time",
+			prev:     past.Format(time.RFC3339),
+			received: past.Add(time.Hour).Format(time.RFC3339),
+			want:     past.Format(time.RFC3339),
+		},
+		{
+			name:     "start changed outside of terraform",
+			prev:     future.Format(time.RFC3339),
+			received: future.Add(time.Hour).Format(time.RFC3339),
+			want:     future.Add(time.Hour).Format(time.RFC3339),
+		},
+		{
+			name:     "imported without previous state",
+			prev:     "",
+			received: future.Format(time.RFC3339),
+			want:     future.Format(time.RFC3339),
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := scheduleOverrideStartValue(c.prev, c.received); got != c.want {
+				t.Errorf("got %q, want %q", got, c.want)
+			}
+		})
+	}
+}
+
+func testAccCheckPagerDutyScheduleOverrideDestroy(s *terraform.State) error {
+	for _, r := range s.RootModule().Resources {
+		if r.Type != "pagerduty_schedule_override" {
+			continue
+		}
+
+		ctx := context.Background()
+		opts := pagerduty.ListOverridesOptions{
+			Since: r.Primary.Attributes["start"],
+			Until: r.Primary.Attributes["end"],
+		}
+		response, err := testAccProvider.client.ListOverridesWithContext(ctx, r.Primary.Attributes["schedule"], opts)
+		if err != nil {
+			// The schedule is destroyed along with its overrides
+			continue
+		}
+		for _, o := range response.Overrides {
+			if o.ID == r.Primary.ID {
+				return fmt.Errorf("Schedule override still exists")
+			}
+		}
+	}
+	return nil
+}
+
+func testAccCheckPagerDutyScheduleOverrideExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No Schedule Override ID is set")
+		}
+
+		ctx := context.Background()
+		found, err := requestGetScheduleOverride(ctx, testAccProvider.client, rs.Primary.Attributes["schedule"], rs.Primary.ID, rs.Primary.Attributes["start"], rs.Primary.Attributes["end"], false)
+		if err != nil {
+			return err
+		}
+		if found == nil {
+			return fmt.Errorf("Schedule Override not found: %v", rs.Primary.ID)
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckPagerDutyScheduleOverrideConfig(username, email, schedule, start, end string) string {
+	return fmt.Sprintf(`
+resource "pagerduty_user" "foo" {
+  name  = "%s"
+  email = "%s"
+}
+
+resource "pagerduty_schedule" "foo" {
+  name      = "%s"
+  time_zone = "Europe/Dublin"
+
+  layer {
+    name                         = "foo"
+    start                        = "%[4]s"
+    rotation_virtual_start       = "%[4]s"
+    rotation_turn_length_seconds = 86400
+    users                        = [pagerduty_user.foo.id]
+  }
+}
+
+resource "pagerduty_schedule_override" "foo" {
+  schedule = pagerduty_schedule.foo.id
+  user     = pagerduty_user.foo.id
+  start    = "%[4]s"
+  end      = "%[5]s"
+}
+`, username, email, schedule, start, end)
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/PagerDuty/go-pagerduty"
 	"github.com/PagerDuty/terraform-provider-pagerduty/util"
+	"github.com/PagerDuty/terraform-provider-pagerduty/util/apiutil"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -18,7 +19,10 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
 )
 
-type resourceTeam struct{ client *pagerduty.Client }
+type resourceTeam struct {
+	client    *pagerduty.Client
+	snapshots *apiutil.Snapshots
+}
 
 var (
 	_ resource.ResourceWithConfigure   = (*resourceTeam)(nil)
@@ -109,6 +113,15 @@ func (r *resourceTeam) Read(ctx context.Context, req resource.ReadRequest, resp
 
 	plan := buildPagerdutyTeam(&state)
 
+	if r.snapshots != nil {
+		if team, ok := r.snapshots.Get("teams").Take(plan.ID, func() (map[string]interface{}, error) {
+			return listTeamsSnapshot(ctx, r.client)
+		}); ok {
+			resp.Diagnostics.Append(resp.State.Set(ctx, flattenTeam(team.(*pagerduty.Team), plan))...)
+			return
+		}
+	}
+
 	retryNotFound := false
 	state, err := requestGetTeam(ctx, r.client, plan, retryNotFound)
 	if err != nil {
@@ -208,6 +221,7 @@ func (r *resourceTeam) Delete(ctx context.Context, req resource.DeleteRequest, r
 
 func (r *resourceTeam) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	resp.Diagnostics.Append(ConfigurePagerdutyClient(&r.client, req.ProviderData)...)
+	ConfigureSnapshots(&r.snapshots, req.ProviderData)
 }
 
 func (r *resourceTeam) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
@@ -244,6 +258,29 @@ func requestGetTeam(ctx context.Context, client *pagerduty.Client, plan *pagerdu
 	return model, err
 }
 
+func listTeamsSnapshot(ctx context.Context, client *pagerduty.Client) (map[string]interface{}, error) {
+	log.Printf("[INFO] Listing every PagerDuty team for bulk refresh")
+	teams := map[string]interface{}{}
+	err := apiutil.All(ctx, func(offset int) (bool, error) {
+		resp, err := client.ListTeamsWithContext(ctx, pagerduty.ListTeamOptions{
+			Limit:  apiutil.Limit,
+			Offset: uint(offset),
+		})
+		if err != nil {
+			return false, err
+		}
+		for i := range resp.Teams {
+			// Teams listed without their default role are read on their
+			// own, rather than planning a change of it.
+			if resp.Teams[i].DefaultRole != "" {
+				teams[resp.Teams[i].ID] = &resp.Teams[i]
+			}
+		}
+		return resp.More, nil
+	})
+	return teams, err
+}
+
 func buildPagerdutyTeam(model *resourceTeamModel) *pagerduty.Team {
 	var parent *pagerduty.APIObject
 	if !model.Parent.IsNull() && !model.Parent.IsUnknown() {
@@ -0,0 +1,74 @@
+package apiutil
+
+import (
+	"log"
+	"sync"
+)
+
+// SnapshotListFunc lists every object of a collection by their ID.
+type SnapshotListFunc = func() (map[string]interface{}, error)
+
+// Snapshot holds every object of a collection, listed at once on the first
+// read of one of them, so that refreshing a large number of resources doesn't
+// need a request for each. Every object is only handed out once, so that
+// reading it again, such as after it's updated, requests it from the API.
+type Snapshot struct {
+	once    sync.Once
+	mu      sync.Mutex
+	objects map[string]interface{}
+}
+
+// Take returns the object `id`, listing the collection with `list` on the
+// first call. It returns false when the object isn't part of the snapshot,
+// was already taken, or the collection couldn't be listed, in which case the
+// object should be requested on its own.
+func (s *Snapshot) Take(id string, list SnapshotListFunc) (interface{}, bool) {
+	s.once.Do(func() {
+		objects, err := list()
+		if err != nil {
+			log.Printf("[WARN] Failed to list the objects of the snapshot, reading them one by one: %s", err)
+			return
+		}
+		s.objects = objects
+	})
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	o, ok := s.objects[id]
+	if ok {
+		delete(s.objects, id)
+	}
+	return o, ok
+}
+
+// Forget drops the object `id` from the snapshot, so that it's requested
+// from the API once modified.
+func (s *Snapshot) Forget(id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.objects, id)
+}
+
+// Snapshots holds the snapshot of every collection. Its zero value is ready
+// to use.
+type Snapshots struct {
+	mu        sync.Mutex
+	snapshots map[string]*Snapshot
+}
+
+// Get returns the snapshot of `collection`, creating it the first time.
+func (s *Snapshots) Get(collection string) *Snapshot {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.snapshots == nil {
+		s.snapshots = map[string]*Snapshot{}
+	}
+	snapshot, ok := s.snapshots[collection]
+	if !ok {
+		snapshot = &Snapshot{}
+		s.snapshots[collection] = snapshot
+	}
+	return snapshot
+}
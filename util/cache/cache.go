@@ -0,0 +1,323 @@
+// Package cache caches the responses of PagerDuty's API to the reads of the
+// objects managed in large numbers, so that refreshing thousands of them
+// doesn't hit the API's rate limit. It's configured by the `cache` block of
+// the provider and plugged into the HTTP client shared by the SDKv2 and the
+// plugin framework halves of the provider, hence caching their requests alike.
+package cache
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Types of cache.
+const (
+	TypeNone   = "none"
+	TypeMemory = "memory"
+	TypeFile   = "file"
+	TypeMongo  = "mongo"
+)
+
+// Failure modes, deciding what happens when the cache can't be opened.
+const (
+	// FailureModeError fails the configuration of the provider.
+	FailureModeError = "error"
+
+	// FailureModeDisable carries on without caching.
+	FailureModeDisable = "disable"
+)
+
+// DefaultMaxAge is the time responses are served from the cache when the
+// provider doesn't configure it.
+const DefaultMaxAge = 5 * time.Minute
+
+// Collections are the collections of objects whose reads are cached.
+var Collections = []string{"escalation_policies", "schedules", "services", "teams", "users"}
+
+// statsInterval is the number of lookups after which the hit and miss counts
+// are logged.
+const statsInterval = 100
+
+// Types returns the types of cache.
+func Types() []string {
+	return []string{TypeNone, TypeMemory, TypeFile, TypeMongo}
+}
+
+// FailureModes returns the failure modes of the cache.
+func FailureModes() []string {
+	return []string{FailureModeError, FailureModeDisable}
+}
+
+// Options configures the cache returned by `Open` and `Shared`.
+type Options struct {
+	// Type of the cache, `TypeNone` disabling it.
+	Type string
+
+	// MaxAge is the time a response is served from the cache,
+	// `DefaultMaxAge` when zero.
+	MaxAge time.Duration
+
+	// Path is the directory of a file cache, `DefaultDir` when empty.
+	Path string
+
+	// URL is the connection string of a MongoDB cache.
+	URL string
+}
+
+// Cache stores the responses to the reads of the objects of `Collections`
+// until they are older than its max age or the objects are modified.
+type Cache struct {
+	store  store
+	maxAge time.Duration
+
+	lookups   uint64
+	mu        sync.Mutex
+	stats     map[string]*Stats
+	prefilled map[string]bool
+}
+
+// Stats are the hit and miss counts of a collection.
+type Stats struct {
+	Hits, Misses uint64
+}
+
+var (
+	sharedMu     sync.Mutex
+	sharedCaches = map[Options]*Cache{}
+)
+
+// Shared returns the cache configured with `opts`, opening it the first time
+// it's requested. It returns nil when `opts.Type` is `TypeNone`.
+func Shared(ctx context.Context, opts Options) (*Cache, error) {
+	sharedMu.Lock()
+	defer sharedMu.Unlock()
+
+	if c, ok := sharedCaches[opts]; ok {
+		return c, nil
+	}
+	c, err := Open(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	sharedCaches[opts] = c
+	return c, nil
+}
+
+// Open opens the cache configured with `opts`, failing when its store can't
+// be reached. It returns nil when `opts.Type` is `TypeNone`.
+func Open(ctx context.Context, opts Options) (*Cache, error) {
+	var s store
+	var err error
+	switch opts.Type {
+	case TypeNone, "":
+		return nil, nil
+	case TypeMemory:
+		s = newMemoryStore()
+	case TypeFile:
+		s, err = newFileStore(opts.Path)
+	case TypeMongo:
+		s, err = newMongoStore(ctx, opts.URL)
+	default:
+		return nil, fmt.Errorf("unknown cache type %q, expected one of %s", opts.Type, strings.Join(Types(), ", "))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open the %s cache: %w", opts.Type, err)
+	}
+
+	maxAge := opts.MaxAge
+	if maxAge <= 0 {
+		maxAge = DefaultMaxAge
+	}
+	log.Printf("[INFO] Caching PagerDuty API responses in %s for %s", opts.Type, maxAge)
+
+	return &Cache{store: s, maxAge: maxAge, stats: map[string]*Stats{}, prefilled: map[string]bool{}}, nil
+}
+
+// Stats returns the hit and miss counts of every collection looked up.
+func (c *Cache) Stats() map[string]Stats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	stats := make(map[string]Stats, len(c.stats))
+	for collection, s := range c.stats {
+		stats[collection] = *s
+	}
+	return stats
+}
+
+func (c *Cache) count(collection string, hit bool) {
+	c.mu.Lock()
+	s, ok := c.stats[collection]
+	if !ok {
+		s = &Stats{}
+		c.stats[collection] = s
+	}
+	if hit {
+		s.Hits++
+	} else {
+		s.Misses++
+	}
+	c.mu.Unlock()
+
+	if atomic.AddUint64(&c.lookups, 1)%statsInterval == 0 {
+		c.LogStats()
+	}
+}
+
+// LogStats logs the hit and miss counts of every collection looked up.
+func (c *Cache) LogStats() {
+	stats := c.Stats()
+	collections := make([]string, 0, len(stats))
+	for collection := range stats {
+		collections = append(collections, collection)
+	}
+	sort.Strings(collections)
+
+	counts := make([]string, 0, len(collections))
+	for _, collection := range collections {
+		s := stats[collection]
+		counts = append(counts, fmt.Sprintf("%s %d hits/%d misses", collection, s.Hits, s.Misses))
+	}
+	log.Printf("[INFO] PagerDuty cache: %s", strings.Join(counts, ", "))
+}
+
+// Transport returns a RoundTripper serving the cached reads from the cache
+// and sending the other requests to `next`. Responses to reads are stored,
+// and the objects targeted by other requests are evicted.
+func (c *Cache) Transport(next http.RoundTripper) http.RoundTripper {
+	return &transport{cache: c, next: next}
+}
+
+type transport struct {
+	cache *Cache
+	next  http.RoundTripper
+}
+
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method != http.MethodGet {
+		resp, err := t.next.RoundTrip(req)
+		t.cache.invalidate(req.Context(), req.URL)
+		return resp, err
+	}
+
+	collection, object, variant, ok := requestKey(req)
+	if !ok {
+		return t.next.RoundTrip(req)
+	}
+
+	if body, ok := t.cache.get(req.Context(), object, variant); ok {
+		log.Printf("[DEBUG] PagerDuty cache hit for GET %s", req.URL)
+		t.cache.count(collection, true)
+		return cachedResponse(req, body), nil
+	}
+	log.Printf("[DEBUG] PagerDuty cache miss for GET %s", req.URL)
+	t.cache.count(collection, false)
+
+	resp, err := t.next.RoundTrip(req)
+	if err != nil || resp.StatusCode != http.StatusOK {
+		return resp, err
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	t.cache.put(req.Context(), object, variant, body)
+	return resp, nil
+}
+
+func (c *Cache) get(ctx context.Context, object, variant string) ([]byte, bool) {
+	e, err := c.store.Get(ctx, object, variant)
+	if err != nil {
+		log.Printf("[WARN] Failed to read %s from the PagerDuty cache: %s", object, err)
+		return nil, false
+	}
+	if e == nil || time.Since(e.StoredAt) > c.maxAge {
+		return nil, false
+	}
+	return e.Body, true
+}
+
+func (c *Cache) put(ctx context.Context, object, variant string, body []byte) {
+	e := &entry{Body: body, StoredAt: time.Now()}
+	if err := c.store.Put(ctx, object, variant, e); err != nil {
+		log.Printf("[WARN] Failed to write %s to the PagerDuty cache: %s", object, err)
+	}
+}
+
+// invalidate evicts every object of `Collections` in the path of `u`, as
+// in `/teams/{id}/users/{user_id}`.
+func (c *Cache) invalidate(ctx context.Context, u *url.URL) {
+	segments := strings.Split(strings.Trim(u.Path, "/"), "/")
+	for i := 0; i+1 < len(segments); i++ {
+		if !isCached(segments[i]) || segments[i+1] == "" {
+			continue
+		}
+		object := objectKey(u.Host, segments[i], segments[i+1])
+		if err := c.store.Invalidate(ctx, object); err != nil {
+			log.Printf("[WARN] Failed to evict %s from the PagerDuty cache: %s", object, err)
+		}
+	}
+}
+
+// requestKey returns the keys a read is cached with: the object it reads, as
+// in `/services/{id}`, and the variant of it, made of the rest of the path,
+// the query and the credentials of the request.
+func requestKey(req *http.Request) (collection, object, variant string, ok bool) {
+	segments := strings.Split(strings.Trim(req.URL.Path, "/"), "/")
+	if len(segments) < 2 || !isCached(segments[0]) || segments[1] == "" {
+		return "", "", "", false
+	}
+
+	collection = segments[0]
+	object = objectKey(req.URL.Host, collection, segments[1])
+	variant = variantKey(strings.Join(segments[2:], "/"), req.URL.Query(), req.Header.Get("Authorization"))
+	return collection, object, variant, true
+}
+
+func objectKey(host, collection, id string) string {
+	return host + "/" + collection + "/" + id
+}
+
+// variantKey only keeps a digest of the credentials, which are never stored.
+func variantKey(subpath string, query url.Values, authorization string) string {
+	sum := sha256.Sum256([]byte(authorization))
+	return subpath + "?" + query.Encode() + "|" + hex.EncodeToString(sum[:8])
+}
+
+func isCached(collection string) bool {
+	for _, c := range Collections {
+		if c == collection {
+			return true
+		}
+	}
+	return false
+}
+
+func cachedResponse(req *http.Request, body []byte) *http.Response {
+	return &http.Response{
+		Status:        "200 OK",
+		StatusCode:    http.StatusOK,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        http.Header{"Content-Type": []string{"application/json"}},
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}
+}
@@ -0,0 +1,197 @@
+package cache
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func newTestServer(t *testing.T) (*httptest.Server, *int32) {
+	var requests int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method != http.MethodGet:
+			w.WriteHeader(http.StatusNoContent)
+		case r.URL.Path == "/services":
+			if r.URL.Query().Get("include[]") != "auto_pause_notifications_parameters" {
+				t.Errorf("expected services to be listed with their expansions, got %s", r.URL.RawQuery)
+			}
+			if r.URL.Query().Get("offset") == "0" {
+				w.Write([]byte(`{"services": [{"id": "PSVC1"}], "more": true}`))
+			} else {
+				w.Write([]byte(`{"services": [{"id": "PSVC2"}], "more": false}`))
+			}
+		case r.URL.Path == "/escalation_policies", r.URL.Path == "/teams", r.URL.Path == "/users":
+			w.Write([]byte(`{"` + strings.TrimPrefix(r.URL.Path, "/") + `": [], "more": false}`))
+		case strings.HasPrefix(r.URL.Path, "/services/PMISSING"):
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"error":{"message":"Not Found","code":2100}}`))
+		default:
+			w.Write([]byte(`{"path": "` + r.URL.Path + `"}`))
+		}
+	}))
+	t.Cleanup(ts.Close)
+	return ts, &requests
+}
+
+func get(t *testing.T, c *http.Client, url, token string) string {
+	t.Helper()
+	req, _ := http.NewRequest(http.MethodGet, url, nil)
+	req.Header.Set("Authorization", "Token token="+token)
+	resp, err := c.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+	return string(body)
+}
+
+func TestTransport(t *testing.T) {
+	for _, opts := range []Options{{Type: TypeMemory}, {Type: TypeFile, Path: t.TempDir()}} {
+		t.Run(opts.Type, func(t *testing.T) {
+			ts, requests := newTestServer(t)
+			c, err := Open(context.Background(), opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+			client := &http.Client{Transport: c.Transport(http.DefaultTransport)}
+
+			first := get(t, client, ts.URL+"/services/PSVC1?include[]=foo", "foo")
+			if got := get(t, client, ts.URL+"/services/PSVC1?include[]=foo", "foo"); got != first {
+				t.Errorf("expected the cached response %q, got %q", first, got)
+			}
+			if n := atomic.LoadInt32(requests); n != 1 {
+				t.Errorf("expected the read to be served from the cache, got %d requests", n)
+			}
+
+			// Other variants, credentials and non cached collections are sent.
+			get(t, client, ts.URL+"/services/PSVC1", "foo")
+			get(t, client, ts.URL+"/services/PSVC1?include[]=foo", "bar")
+			get(t, client, ts.URL+"/tags/PTAG1", "foo")
+			get(t, client, ts.URL+"/tags/PTAG1", "foo")
+			if n := atomic.LoadInt32(requests); n != 5 {
+				t.Errorf("expected 5 requests, got %d", n)
+			}
+
+			// Errors aren't cached.
+			get(t, client, ts.URL+"/services/PMISSING", "foo")
+			get(t, client, ts.URL+"/services/PMISSING", "foo")
+			if n := atomic.LoadInt32(requests); n != 7 {
+				t.Errorf("expected 7 requests, got %d", n)
+			}
+
+			// Modifying an object evicts all of its variants.
+			req, _ := http.NewRequest(http.MethodPut, ts.URL+"/services/PSVC1", nil)
+			resp, err := client.Do(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp.Body.Close()
+			get(t, client, ts.URL+"/services/PSVC1?include[]=foo", "foo")
+			if n := atomic.LoadInt32(requests); n != 9 {
+				t.Errorf("expected the modified object to be read again, got %d requests", n)
+			}
+
+			stats := c.Stats()
+			if s := stats["services"]; s.Hits != 1 || s.Misses != 6 {
+				t.Errorf("expected 1 hit and 6 misses for services, got %+v", s)
+			}
+			if _, ok := stats["tags"]; ok {
+				t.Error("expected tags not to be looked up")
+			}
+		})
+	}
+}
+
+func TestTransportMaxAge(t *testing.T) {
+	ts, requests := newTestServer(t)
+	c, err := Open(context.Background(), Options{Type: TypeMemory, MaxAge: time.Millisecond})
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := &http.Client{Transport: c.Transport(http.DefaultTransport)}
+
+	get(t, client, ts.URL+"/teams/PTEAM1", "foo")
+	time.Sleep(5 * time.Millisecond)
+	get(t, client, ts.URL+"/teams/PTEAM1", "foo")
+	if n := atomic.LoadInt32(requests); n != 2 {
+		t.Errorf("expected the expired response to be read again, got %d requests", n)
+	}
+}
+
+func TestTransportInvalidatesNestedObjects(t *testing.T) {
+	ts, requests := newTestServer(t)
+	c, err := Open(context.Background(), Options{Type: TypeMemory})
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := &http.Client{Transport: c.Transport(http.DefaultTransport)}
+
+	get(t, client, ts.URL+"/teams/PTEAM1", "foo")
+	get(t, client, ts.URL+"/users/PUSER1", "foo")
+
+	req, _ := http.NewRequest(http.MethodPut, ts.URL+"/teams/PTEAM1/users/PUSER1", nil)
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+
+	get(t, client, ts.URL+"/teams/PTEAM1", "foo")
+	get(t, client, ts.URL+"/users/PUSER1", "foo")
+	if n := atomic.LoadInt32(requests); n != 5 {
+		t.Errorf("expected both the team and the user to be read again, got %d requests", n)
+	}
+}
+
+func TestPrefill(t *testing.T) {
+	ts, requests := newTestServer(t)
+	c, err := Open(context.Background(), Options{Type: TypeMemory})
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := &http.Client{Transport: c.Transport(http.DefaultTransport)}
+
+	header := http.Header{"Authorization": []string{"Token token=foo"}}
+	if err := c.Prefill(context.Background(), client, ts.URL, header); err != nil {
+		t.Fatal(err)
+	}
+	// Once for every page of services and every other collection.
+	if n := atomic.LoadInt32(requests); n != 5 {
+		t.Errorf("expected 5 list requests, got %d", n)
+	}
+
+	if got := get(t, client, ts.URL+"/services/PSVC2?include%5B%5D=auto_pause_notifications_parameters", "foo"); got != `{"service":{"id":"PSVC2"}}` {
+		t.Errorf("expected the prefilled service, got %q", got)
+	}
+	if n := atomic.LoadInt32(requests); n != 5 {
+		t.Errorf("expected the prefilled service to be served from the cache, got %d requests", n)
+	}
+
+	if err := c.Prefill(context.Background(), client, ts.URL, header); err != nil {
+		t.Fatal(err)
+	}
+	if n := atomic.LoadInt32(requests); n != 5 {
+		t.Errorf("expected the cache to be prefilled once, got %d requests", n)
+	}
+}
+
+func TestOpen(t *testing.T) {
+	if c, err := Open(context.Background(), Options{Type: TypeNone}); c != nil || err != nil {
+		t.Errorf("expected no cache, got %v, %v", c, err)
+	}
+	if _, err := Open(context.Background(), Options{Type: "redis"}); err == nil {
+		t.Error("expected an error for an unknown type")
+	}
+	if _, err := Open(context.Background(), Options{Type: TypeMongo}); err == nil {
+		t.Error("expected an error for a MongoDB cache without URL")
+	}
+}
@@ -0,0 +1,130 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// prefillPageSize is the number of objects listed by every request of a
+// prefill.
+const prefillPageSize = 100
+
+// prefilled are the collections listed by `Prefill`, with the key of an
+// object in the response to its read and the expansions the provider reads
+// it with. Schedules aren't prefilled, their list omitting their layers.
+var prefilled = []struct {
+	collection, singular, include string
+}{
+	{"escalation_policies", "escalation_policy", "escalation_rule_assignment_strategies"},
+	{"services", "service", "auto_pause_notifications_parameters"},
+	{"teams", "team", ""},
+	{"users", "user", ""},
+}
+
+// Prefill lists every escalation policy, service, team and user of the
+// account, storing each of them as the response to its read. The requests
+// are sent with `client` to `baseURL`, along with the headers `header`. The
+// cache is only prefilled once for every account and credentials.
+func (c *Cache) Prefill(ctx context.Context, client *http.Client, baseURL string, header http.Header) error {
+	base, err := url.Parse(baseURL)
+	if err != nil {
+		return err
+	}
+
+	key := variantKey(base.Host, nil, header.Get("Authorization"))
+	c.mu.Lock()
+	done := c.prefilled[key]
+	c.prefilled[key] = true
+	c.mu.Unlock()
+	if done {
+		return nil
+	}
+
+	for _, p := range prefilled {
+		query := url.Values{}
+		if p.include != "" {
+			query.Set("include[]", p.include)
+		}
+		variant := variantKey("", query, header.Get("Authorization"))
+
+		count := 0
+		for offset, more := 0, true; more; offset += prefillPageSize {
+			var items []json.RawMessage
+			items, more, err = listPage(ctx, client, base, p.collection, query, offset, header)
+			if err != nil {
+				return fmt.Errorf("failed to prefill the cache with %s: %w", p.collection, err)
+			}
+
+			for _, item := range items {
+				var object struct {
+					ID string `json:"id"`
+				}
+				if err := json.Unmarshal(item, &object); err != nil || object.ID == "" {
+					continue
+				}
+				body, err := json.Marshal(map[string]json.RawMessage{p.singular: item})
+				if err != nil {
+					return err
+				}
+				c.put(ctx, objectKey(base.Host, p.collection, object.ID), variant, body)
+				count++
+			}
+		}
+		log.Printf("[INFO] Prefilled the PagerDuty cache with %d %s", count, p.collection)
+	}
+	return nil
+}
+
+func listPage(ctx context.Context, client *http.Client, base *url.URL, collection string, query url.Values, offset int, header http.Header) ([]json.RawMessage, bool, error) {
+	q := url.Values{}
+	for k, v := range query {
+		q[k] = v
+	}
+	q.Set("limit", strconv.Itoa(prefillPageSize))
+	q.Set("offset", strconv.Itoa(offset))
+
+	u := *base
+	u.Path = u.Path + "/" + collection
+	u.RawQuery = q.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, false, err
+	}
+	for k, v := range header {
+		req.Header[k] = v
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, false, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		io.Copy(io.Discard, resp.Body)
+		return nil, false, fmt.Errorf("GET %s: %s", u.Path, resp.Status)
+	}
+
+	var page map[string]json.RawMessage
+	if err := json.NewDecoder(resp.Body).Decode(&page); err != nil {
+		return nil, false, err
+	}
+	var items []json.RawMessage
+	if err := json.Unmarshal(page[collection], &items); err != nil {
+		return nil, false, err
+	}
+	var more bool
+	if raw, ok := page["more"]; ok {
+		if err := json.Unmarshal(raw, &more); err != nil {
+			return nil, false, err
+		}
+	}
+	return items, more && len(items) > 0, nil
+}
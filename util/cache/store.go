@@ -0,0 +1,219 @@
+package cache
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/mongo/readpref"
+)
+
+// mongoTimeout limits the time taken to connect to a MongoDB cache.
+const mongoTimeout = 10 * time.Second
+
+// entry is a response stored in the cache.
+type entry struct {
+	Body     []byte    `json:"body"`
+	StoredAt time.Time `json:"stored_at"`
+}
+
+// store holds the entries of a cache by object and variant, so that all the
+// variants of an object are evicted at once.
+type store interface {
+	// Get returns the entry of the variant of the object, nil when there's
+	// none.
+	Get(ctx context.Context, object, variant string) (*entry, error)
+	Put(ctx context.Context, object, variant string, e *entry) error
+	Invalidate(ctx context.Context, object string) error
+}
+
+type memoryStore struct {
+	mu      sync.RWMutex
+	entries map[string]map[string]*entry
+}
+
+func newMemoryStore() *memoryStore {
+	return &memoryStore{entries: map[string]map[string]*entry{}}
+}
+
+func (s *memoryStore) Get(_ context.Context, object, variant string) (*entry, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.entries[object][variant], nil
+}
+
+func (s *memoryStore) Put(_ context.Context, object, variant string, e *entry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	variants, ok := s.entries[object]
+	if !ok {
+		variants = map[string]*entry{}
+		s.entries[object] = variants
+	}
+	variants[variant] = e
+	return nil
+}
+
+func (s *memoryStore) Invalidate(_ context.Context, object string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.entries, object)
+	return nil
+}
+
+// DefaultDir returns the directory of a file cache when the provider doesn't
+// configure it.
+func DefaultDir() string {
+	dir, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, ".pagerduty", "cache")
+}
+
+// fileStore keeps every variant of an object as a file of the object's
+// directory, both named after the digest of their key.
+type fileStore struct {
+	dir string
+}
+
+func newFileStore(dir string) (*fileStore, error) {
+	if dir == "" {
+		dir = DefaultDir()
+	}
+	if dir == "" {
+		return nil, errors.New("no cache directory configured and no home directory to default to")
+	}
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, err
+	}
+	return &fileStore{dir: dir}, nil
+}
+
+func (s *fileStore) Get(_ context.Context, object, variant string) (*entry, error) {
+	b, err := os.ReadFile(s.path(object, variant))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	e := &entry{}
+	if err := json.Unmarshal(b, e); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+func (s *fileStore) Put(_ context.Context, object, variant string, e *entry) error {
+	b, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+
+	path := s.path(object, variant)
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+	// Written aside first, so that concurrent readers never see a partial
+	// entry.
+	f, err := os.CreateTemp(filepath.Dir(path), ".entry-*")
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(b); err != nil {
+		f.Close()
+		os.Remove(f.Name())
+		return err
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(f.Name())
+		return err
+	}
+	return os.Rename(f.Name(), path)
+}
+
+func (s *fileStore) Invalidate(_ context.Context, object string) error {
+	return os.RemoveAll(filepath.Join(s.dir, digest(object)))
+}
+
+func (s *fileStore) path(object, variant string) string {
+	return filepath.Join(s.dir, digest(object), digest(variant)+".json")
+}
+
+func digest(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:16])
+}
+
+// mongoStore keeps the entries as documents of the `terraform_cache`
+// collection of the `pagerduty` database.
+type mongoStore struct {
+	collection *mongo.Collection
+}
+
+type mongoEntry struct {
+	ID       string    `bson:"_id"`
+	Object   string    `bson:"object"`
+	Variant  string    `bson:"variant"`
+	Body     []byte    `bson:"body"`
+	StoredAt time.Time `bson:"stored_at"`
+}
+
+func newMongoStore(ctx context.Context, uri string) (*mongoStore, error) {
+	if uri == "" {
+		return nil, errors.New("no MongoDB connection string configured")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, mongoTimeout)
+	defer cancel()
+
+	client, err := mongo.Connect(ctx, options.Client().ApplyURI(uri))
+	if err != nil {
+		return nil, err
+	}
+	if err := client.Ping(ctx, readpref.Primary()); err != nil {
+		client.Disconnect(context.Background())
+		return nil, err
+	}
+	return &mongoStore{collection: client.Database("pagerduty").Collection("terraform_cache")}, nil
+}
+
+func (s *mongoStore) Get(ctx context.Context, object, variant string) (*entry, error) {
+	var e mongoEntry
+	err := s.collection.FindOne(ctx, bson.M{"_id": mongoID(object, variant)}).Decode(&e)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &entry{Body: e.Body, StoredAt: e.StoredAt}, nil
+}
+
+func (s *mongoStore) Put(ctx context.Context, object, variant string, e *entry) error {
+	id := mongoID(object, variant)
+	doc := mongoEntry{ID: id, Object: object, Variant: variant, Body: e.Body, StoredAt: e.StoredAt}
+	_, err := s.collection.ReplaceOne(ctx, bson.M{"_id": id}, doc, options.Replace().SetUpsert(true))
+	return err
+}
+
+func (s *mongoStore) Invalidate(ctx context.Context, object string) error {
+	_, err := s.collection.DeleteMany(ctx, bson.M{"object": object})
+	return err
+}
+
+func mongoID(object, variant string) string {
+	return object + "\n" + variant
+}
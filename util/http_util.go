@@ -6,13 +6,20 @@ import (
 	"regexp"
 
 	"github.com/PagerDuty/go-pagerduty"
+	heimweh "github.com/heimweh/go-pagerduty/pagerduty"
 )
 
+// IsBadRequestError reports whether `err` is a 400 response of either
+// PagerDuty client.
 func IsBadRequestError(err error) bool {
 	var apiErr pagerduty.APIError
 	if errors.As(err, &apiErr) {
 		return apiErr.StatusCode == http.StatusBadRequest
 	}
+	var heimwehErr *heimweh.Error
+	if errors.As(err, &heimwehErr) && heimwehErr.ErrorResponse != nil && heimwehErr.ErrorResponse.Response != nil {
+		return heimwehErr.ErrorResponse.Response.StatusCode == http.StatusBadRequest
+	}
 	return false
 }
 
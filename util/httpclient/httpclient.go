@@ -0,0 +1,264 @@
+// Package httpclient builds the HTTP client used to reach PagerDuty's API by
+// both the SDKv2 and the plugin framework halves of the provider, so requests
+// made by either one share a single rate limiter and retry policy.
+package httpclient
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"log"
+	"math"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/PagerDuty/terraform-provider-pagerduty/util/cache"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/logging"
+)
+
+const (
+	// DefaultMaxRetries is the amount of times a request is retried after
+	// being rate limited or failing with a server error.
+	DefaultMaxRetries = 3
+
+	// DefaultRequestTimeout is the time in seconds a single attempt of a
+	// request can take.
+	DefaultRequestTimeout = 30
+
+	// DefaultMaxRequestsPerMinute is kept slightly below the 960 requests per
+	// minute PagerDuty allows for each API token.
+	DefaultMaxRequestsPerMinute = 900
+)
+
+// Options configures the behaviour of the client returned by `New` and
+// `Shared`.
+type Options struct {
+	// MaxRetries is the amount of times a request is retried. Zero disables
+	// retries.
+	MaxRetries int
+
+	// RequestTimeout limits the duration of every attempt of a request,
+	// including reading its response. Zero disables the timeout.
+	RequestTimeout time.Duration
+
+	// MaxRequestsPerMinute limits the requests sent to the API. Zero
+	// disables the limit.
+	MaxRequestsPerMinute int
+
+	// Do not verify TLS certs for HTTPS requests
+	InsecureTLS bool
+
+	// Cache serves the reads of the objects it caches, ahead of the rate
+	// limiter. Nil disables caching.
+	Cache *cache.Cache
+}
+
+var (
+	retryBaseDelay = 1 * time.Second
+	retryMaxDelay  = 30 * time.Second
+
+	sharedMu      sync.Mutex
+	sharedClients = map[Options]*http.Client{}
+)
+
+// Shared returns the client configured with `opts`, creating it the first
+// time it's requested.
+func Shared(opts Options) *http.Client {
+	sharedMu.Lock()
+	defer sharedMu.Unlock()
+
+	if c, ok := sharedClients[opts]; ok {
+		return c
+	}
+	c := New(opts)
+	sharedClients[opts] = c
+	return c
+}
+
+// New returns an HTTP client which throttles its requests to honour
+// `opts.MaxRequestsPerMinute` and retries the ones rejected by PagerDuty's
+// rate limit, waiting for the time signaled by its `ratelimit-reset` header.
+// Requests failing with a network or server error are retried too when they
+// are idempotent. Reads cached by `opts.Cache` are served without being sent.
+func New(opts Options) *http.Client {
+	base := http.DefaultTransport.(*http.Transport).Clone()
+	if opts.InsecureTLS {
+		base.TLSClientConfig = &tls.Config{InsecureSkipVerify: true}
+	}
+
+	t := &transport{
+		next:       logging.NewTransport("PagerDuty", base),
+		maxRetries: opts.MaxRetries,
+		timeout:    opts.RequestTimeout,
+	}
+	if opts.MaxRequestsPerMinute > 0 {
+		t.limiter = newTokenBucket(opts.MaxRequestsPerMinute)
+	}
+
+	if opts.Cache != nil {
+		return &http.Client{Transport: opts.Cache.Transport(t)}
+	}
+	return &http.Client{Transport: t}
+}
+
+type transport struct {
+	next       http.RoundTripper
+	limiter    *tokenBucket
+	maxRetries int
+	timeout    time.Duration
+}
+
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+
+	for attempt := 0; ; attempt++ {
+		r := req
+		if attempt > 0 {
+			r = req.Clone(ctx)
+			if req.GetBody != nil {
+				body, err := req.GetBody()
+				if err != nil {
+					return nil, err
+				}
+				r.Body = body
+			}
+		}
+
+		if t.limiter != nil {
+			if err := t.limiter.Wait(ctx); err != nil {
+				return nil, err
+			}
+		}
+
+		resp, err := t.roundTrip(r)
+
+		wait, retry, err := t.retryDelay(req, resp, err, attempt)
+		if !retry {
+			return resp, err
+		}
+
+		reason := "failed"
+		if resp != nil {
+			reason = resp.Status
+			io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+		log.Printf("[INFO] Request %s %s %s, retrying in %s (%d/%d)", req.Method, req.URL, reason, wait.Round(100*time.Millisecond), attempt+1, t.maxRetries)
+
+		if err := sleep(ctx, wait); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// roundTrip performs a single attempt of the request, limited by the
+// transport's timeout.
+func (t *transport) roundTrip(req *http.Request) (*http.Response, error) {
+	if t.timeout <= 0 {
+		return t.next.RoundTrip(req)
+	}
+
+	ctx, cancel := context.WithTimeout(req.Context(), t.timeout)
+	resp, err := t.next.RoundTrip(req.WithContext(ctx))
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	resp.Body = &cancelOnCloseBody{ReadCloser: resp.Body, cancel: cancel}
+	return resp, nil
+}
+
+// retryDelay decides whether a request should be retried after its attempt
+// number `attempt` and how long to wait before doing so. It also returns the
+// error to report when the request isn't retried.
+func (t *transport) retryDelay(req *http.Request, resp *http.Response, err error, attempt int) (time.Duration, bool, error) {
+	if req.Context().Err() != nil {
+		return 0, false, err
+	}
+
+	canRetry := attempt < t.maxRetries && (req.Body == nil || req.Body == http.NoBody || req.GetBody != nil)
+
+	if err != nil {
+		if canRetry && isIdempotent(req.Method) {
+			return backoff(attempt), true, nil
+		}
+		return 0, false, err
+	}
+
+	switch {
+	case resp.StatusCode == http.StatusTooManyRequests:
+		wait := backoff(attempt)
+		if reset, err := strconv.Atoi(resp.Header.Get("ratelimit-reset")); err == nil {
+			wait = time.Duration(reset)*time.Second + jitter(500*time.Millisecond)
+		}
+		if t.limiter != nil {
+			t.limiter.pause(time.Now().Add(wait))
+		}
+		if canRetry {
+			return wait, true, nil
+		}
+
+		// Rate limited responses are retried indefinitely by some API
+		// clients, so an error is returned instead to keep requests bounded.
+		io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+		return 0, false, fmt.Errorf("rate limit of PagerDuty's API exceeded, giving up after %d retries", t.maxRetries)
+
+	case resp.StatusCode >= 500 && canRetry && isIdempotent(req.Method):
+		return backoff(attempt), true, nil
+	}
+
+	return 0, false, nil
+}
+
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// backoff is a binary exponential backoff with jitter.
+func backoff(attempt int) time.Duration {
+	delay := time.Duration(math.Exp2(float64(attempt))) * retryBaseDelay
+	if delay > retryMaxDelay {
+		delay = retryMaxDelay
+	}
+	return delay + jitter(delay/4)
+}
+
+func jitter(max time.Duration) time.Duration {
+	if max <= 0 {
+		return 0
+	}
+	return time.Duration(rand.Int63n(int64(max)))
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// cancelOnCloseBody releases the context of a request attempt once its
+// response has been read.
+type cancelOnCloseBody struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *cancelOnCloseBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
@@ -0,0 +1,149 @@
+package httpclient
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func init() {
+	retryBaseDelay = time.Millisecond
+}
+
+func newTestServer(t *testing.T, handler func(attempt int32, w http.ResponseWriter, r *http.Request)) (*httptest.Server, *int32) {
+	var attempts int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		handler(atomic.AddInt32(&attempts, 1), w, r)
+	}))
+	t.Cleanup(ts.Close)
+	return ts, &attempts
+}
+
+func TestClientRetriesRateLimitedRequests(t *testing.T) {
+	ts, attempts := newTestServer(t, func(attempt int32, w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if string(body) != `{"foo":"bar"}` {
+			t.Errorf("attempt %d: unexpected body %q", attempt, body)
+		}
+		if attempt < 3 {
+			w.Header().Set("ratelimit-reset", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+	})
+
+	c := New(Options{MaxRetries: 3})
+	resp, err := c.Post(ts.URL, "application/json", strings.NewReader(`{"foo":"bar"}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated {
+		t.Errorf("want status %d; got %d", http.StatusCreated, resp.StatusCode)
+	}
+	if got := atomic.LoadInt32(attempts); got != 3 {
+		t.Errorf("want 3 attempts; got %d", got)
+	}
+}
+
+func TestClientGivesUpWhenRateLimited(t *testing.T) {
+	ts, attempts := newTestServer(t, func(_ int32, w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("ratelimit-reset", "0")
+		w.WriteHeader(http.StatusTooManyRequests)
+	})
+
+	c := New(Options{MaxRetries: 2})
+	_, err := c.Get(ts.URL)
+	if err == nil || !strings.Contains(err.Error(), "giving up after 2 retries") {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if got := atomic.LoadInt32(attempts); got != 3 {
+		t.Errorf("want 3 attempts; got %d", got)
+	}
+}
+
+func TestClientRetriesServerErrorsOfIdempotentRequests(t *testing.T) {
+	ts, attempts := newTestServer(t, func(attempt int32, w http.ResponseWriter, _ *http.Request) {
+		if attempt == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+
+	c := New(Options{MaxRetries: 3})
+	resp, err := c.Get(ts.URL)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("want status %d; got %d", http.StatusOK, resp.StatusCode)
+	}
+	if got := atomic.LoadInt32(attempts); got != 2 {
+		t.Errorf("want 2 attempts; got %d", got)
+	}
+
+	atomic.StoreInt32(attempts, 0)
+	resp, err = c.Post(ts.URL, "application/json", strings.NewReader("{}"))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("want status %d; got %d", http.StatusServiceUnavailable, resp.StatusCode)
+	}
+	if got := atomic.LoadInt32(attempts); got != 1 {
+		t.Errorf("want 1 attempt; got %d", got)
+	}
+}
+
+func TestClientRequestTimeout(t *testing.T) {
+	ts, attempts := newTestServer(t, func(attempt int32, w http.ResponseWriter, r *http.Request) {
+		if attempt == 1 {
+			select {
+			case <-r.Context().Done():
+			case <-time.After(time.Second):
+			}
+			return
+		}
+		io.WriteString(w, "ok")
+	})
+
+	c := New(Options{MaxRetries: 1, RequestTimeout: 50 * time.Millisecond})
+	resp, err := c.Get(ts.URL)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("unexpected error reading body: %s", err)
+	}
+	if string(body) != "ok" {
+		t.Errorf("want body %q; got %q", "ok", body)
+	}
+	if got := atomic.LoadInt32(attempts); got != 2 {
+		t.Errorf("want 2 attempts; got %d", got)
+	}
+}
+
+func TestShared(t *testing.T) {
+	a := Shared(Options{MaxRetries: 1, MaxRequestsPerMinute: 60})
+	b := Shared(Options{MaxRetries: 1, MaxRequestsPerMinute: 60})
+	c := Shared(Options{MaxRetries: 2, MaxRequestsPerMinute: 60})
+
+	if a != b {
+		t.Errorf("expected the same client for the same options")
+	}
+	if a == c {
+		t.Errorf("expected different clients for different options")
+	}
+}
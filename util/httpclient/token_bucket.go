@@ -0,0 +1,92 @@
+package httpclient
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+)
+
+// tokenBucket is a rate limiter allowing bursts of up to `burst` requests and
+// refilling at a constant `rate` of requests per second.
+type tokenBucket struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	// last is the moment tokens were last refilled. It is moved to the future
+	// to pause the requests until a rate limit is reset.
+	last time.Time
+	now  func() time.Time
+}
+
+func newTokenBucket(requestsPerMinute int) *tokenBucket {
+	// Allowing to spend the whole minute budget at once would make it easy to
+	// cross PagerDuty's limit, which is computed over a sliding window, so
+	// bursts are limited to the requests of a few seconds.
+	burst := math.Max(1, float64(requestsPerMinute)/12)
+	return &tokenBucket{
+		rate:   float64(requestsPerMinute) / 60,
+		burst:  burst,
+		tokens: burst,
+		last:   time.Now(),
+		now:    time.Now,
+	}
+}
+
+// reserve takes a token from the bucket and returns how long the caller must
+// wait before making its request.
+func (b *tokenBucket) reserve() time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := b.now()
+	if now.After(b.last) {
+		b.tokens = math.Min(b.burst, b.tokens+now.Sub(b.last).Seconds()*b.rate)
+		b.last = now
+	}
+	b.tokens--
+
+	wait := b.last.Sub(now)
+	if b.tokens < 0 {
+		wait += time.Duration(-b.tokens / b.rate * float64(time.Second))
+	}
+	return wait
+}
+
+// cancel gives back a token which was reserved but not used.
+func (b *tokenBucket) cancel() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.tokens = math.Min(b.burst, b.tokens+1)
+}
+
+// pause stops handing out tokens until `until`, used when PagerDuty reports
+// the rate limit was reached anyway.
+func (b *tokenBucket) pause(until time.Time) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if until.After(b.last) {
+		b.last = until
+		b.tokens = math.Min(b.tokens, 0)
+	}
+}
+
+// Wait blocks until a request can be made without exceeding the rate limit or
+// the context is done.
+func (b *tokenBucket) Wait(ctx context.Context) error {
+	wait := b.reserve()
+	if wait <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		b.cancel()
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
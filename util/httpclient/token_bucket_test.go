@@ -0,0 +1,36 @@
+package httpclient
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTokenBucket(t *testing.T) {
+	now := time.Date(2025, 1, 6, 0, 0, 0, 0, time.UTC)
+	b := newTokenBucket(120)
+	b.now = func() time.Time { return now }
+	b.last = now
+
+	// 120 requests per minute allow a burst of 10 requests
+	for i := 0; i < 10; i++ {
+		if wait := b.reserve(); wait != 0 {
+			t.Fatalf("request %d: want no wait; got %s", i, wait)
+		}
+	}
+	if wait := b.reserve(); wait != 500*time.Millisecond {
+		t.Errorf("want a wait of 500ms; got %s", wait)
+	}
+	if wait := b.reserve(); wait != time.Second {
+		t.Errorf("want a wait of 1s; got %s", wait)
+	}
+
+	now = now.Add(10 * time.Second)
+	if wait := b.reserve(); wait != 0 {
+		t.Errorf("want no wait after refill; got %s", wait)
+	}
+
+	b.pause(now.Add(5 * time.Second))
+	if wait := b.reserve(); wait != 5*time.Second+500*time.Millisecond {
+		t.Errorf("want a wait of 5.5s while paused; got %s", wait)
+	}
+}
@@ -0,0 +1,158 @@
+package mockapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+func (s *Server) prepareEventOrchestration(item object) {
+	setDefault(item, "description", "")
+	setDefault(item, "routes", 0)
+	if _, ok := item["integrations"]; !ok {
+		id := s.newID()
+		item["integrations"] = []interface{}{object{
+			"id":    id,
+			"label": "Default Integration",
+			"parameters": object{
+				"routing_key": "R0" + id,
+				"type":        "global",
+			},
+		}}
+	}
+}
+
+// serveOrchestrationPath handles the paths of event orchestrations, under
+// `/event_orchestrations/<id>/<type>` and
+// `/event_orchestrations/services/<service id>[/active]`, and their cache
+// variables.
+func (s *Server) serveOrchestrationPath(w http.ResponseWriter, r *http.Request, segments []string) {
+	var parentName, parentID, pathType string
+	switch {
+	case segments[0] == "services" && len(segments) >= 3 && len(segments) <= 4 && segments[2] == "cache_variables":
+		s.serveCacheVariables(w, r, "services", segments[1], segments[3:])
+		return
+	case segments[0] != "services" && len(segments) <= 3 && segments[1] == "cache_variables":
+		s.serveCacheVariables(w, r, "event_orchestrations", segments[0], segments[2:])
+		return
+	case segments[0] == "services" && len(segments) == 2:
+		parentName, parentID, pathType = "services", segments[1], "service"
+	case segments[0] == "services" && len(segments) == 3 && segments[2] == "active":
+		s.serveServiceOrchestrationActive(w, r, segments[1])
+		return
+	case len(segments) == 2 && (segments[1] == "router" || segments[1] == "unrouted" || segments[1] == "global"):
+		parentName, parentID, pathType = "event_orchestrations", segments[0], segments[1]
+	default:
+		writeError(w, http.StatusNotFound, "Not Found", 2100)
+		return
+	}
+
+	if _, ok := s.collections[parentName].items[parentID]; !ok {
+		writeError(w, http.StatusNotFound, "Not Found", 2100)
+		return
+	}
+
+	path := s.orchestrationPath(parentName, parentID, pathType)
+	switch r.Method {
+	case http.MethodGet:
+	case http.MethodPut:
+		changes, ok := readPayload(w, r, "orchestration_path")
+		if !ok {
+			return
+		}
+		for _, k := range []string{"sets", "catch_all"} {
+			if v, ok := changes[k]; ok {
+				path[k] = v
+			}
+		}
+		for _, set := range objects(path["sets"]) {
+			for _, rule := range objects(set["rules"]) {
+				if id, _ := rule["id"].(string); id == "" {
+					s.lastID++
+					rule["id"] = fmt.Sprintf("%08x", s.lastID)
+				}
+			}
+		}
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "Method Not Allowed", 2100)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, object{"orchestration_path": path, "warnings": nil})
+}
+
+// orchestrationPath returns the path `pathType` of the object `parentID`,
+// creating the one PagerDuty sets up by default if it doesn't exist yet.
+func (s *Server) orchestrationPath(parentName, parentID, pathType string) object {
+	key := fmt.Sprintf("%s/%s", parentID, pathType)
+	if path, ok := s.orchestrationPaths[key]; ok {
+		return path
+	}
+
+	parent := s.reference(parentName, parentID)
+	if parentName == "event_orchestrations" {
+		parent["type"] = "event_orchestration_reference"
+	}
+	catchAll := object{"actions": object{}}
+	if pathType == "router" {
+		catchAll = object{"actions": object{"route_to": "unrouted"}}
+	}
+
+	path := object{
+		"type":      pathType,
+		"parent":    parent,
+		"self":      fmt.Sprintf("%s/event_orchestrations/%s", s.URL, key),
+		"sets":      []interface{}{object{"id": "start", "rules": []interface{}{}}},
+		"catch_all": catchAll,
+	}
+	if pathType == "service" {
+		path["self"] = fmt.Sprintf("%s/event_orchestrations/services/%s", s.URL, parentID)
+	}
+	s.orchestrationPaths[key] = path
+	return path
+}
+
+// serveCacheVariables handles the cache variables of the object `parentID`,
+// kept in a collection of their own.
+func (s *Server) serveCacheVariables(w http.ResponseWriter, r *http.Request, parentName, parentID string, segments []string) {
+	if _, ok := s.collections[parentName].items[parentID]; !ok {
+		writeError(w, http.StatusNotFound, "Not Found", 2100)
+		return
+	}
+
+	name := fmt.Sprintf("event_orchestrations/%s/cache_variables", parentID)
+	if parentName == "services" {
+		name = fmt.Sprintf("event_orchestrations/services/%s/cache_variables", parentID)
+	}
+	c, ok := s.cacheVariables[name]
+	if !ok {
+		c = &collection{name: name, singular: "cache_variable", plural: "cache_variables", items: map[string]object{}}
+		s.cacheVariables[name] = c
+	}
+	s.serveCollection(w, r, c, segments)
+}
+
+func (s *Server) serveServiceOrchestrationActive(w http.ResponseWriter, r *http.Request, serviceID string) {
+	if _, ok := s.collections["services"].items[serviceID]; !ok {
+		writeError(w, http.StatusNotFound, "Not Found", 2100)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+	case http.MethodPut:
+		var payload struct {
+			Active bool `json:"active"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			writeError(w, http.StatusBadRequest, "Invalid Input Provided", 2001, err.Error())
+			return
+		}
+		s.serviceActive[serviceID] = payload.Active
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "Method Not Allowed", 2100)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, object{"active": s.serviceActive[serviceID]})
+}
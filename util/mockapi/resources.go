@@ -0,0 +1,378 @@
+package mockapi
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+)
+
+func prepareService(item object) {
+	setDefault(item, "status", "active")
+	setDefault(item, "alert_creation", "create_alerts_and_incidents")
+	setDefault(item, "incident_urgency_rule", object{"type": "constant", "urgency": "high"})
+	setDefault(item, "created_at", time.Now().UTC().Format(time.RFC3339))
+	setDefault(item, "last_incident_timestamp", nil)
+	setDefault(item, "teams", []interface{}{})
+}
+
+func prepareUser(item object) {
+	setDefault(item, "role", "user")
+	setDefault(item, "time_zone", "Etc/UTC")
+	setDefault(item, "color", "green")
+	setDefault(item, "description", "")
+	setDefault(item, "job_title", "")
+	setDefault(item, "invitation_sent", false)
+	setDefault(item, "contact_methods", []interface{}{})
+	setDefault(item, "notification_rules", []interface{}{})
+}
+
+func (s *Server) viewUser(item object) {
+	teams := []interface{}{}
+	for _, teamID := range sortedKeys(s.memberships) {
+		if _, ok := s.memberships[teamID][item["id"].(string)]; ok {
+			teams = append(teams, s.reference("teams", teamID))
+		}
+	}
+	item["teams"] = teams
+}
+
+// serveUserLicense handles `/users/<id>/license`. Every user gets the same
+// license.
+func (s *Server) serveUserLicense(w http.ResponseWriter, r *http.Request, userID string) {
+	if _, ok := s.collections["users"].items[userID]; !ok {
+		writeError(w, http.StatusNotFound, "Not Found", 2100)
+		return
+	}
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, "Method Not Allowed", 2100)
+		return
+	}
+	writeJSON(w, http.StatusOK, object{"license": object{
+		"id":                    "PLICENSE",
+		"type":                  "license",
+		"name":                  "Full User",
+		"summary":               "Full User",
+		"role_group":            "FullUser",
+		"valid_roles":           []string{"owner", "admin", "user", "limited_user", "observer", "restricted_access"},
+		"current_value":         1,
+		"allocations_available": 100,
+	}})
+}
+
+func (s *Server) onDeleteUser(item object) {
+	for _, members := range s.memberships {
+		delete(members, item["id"].(string))
+	}
+}
+
+func (s *Server) onDeleteTeam(item object) {
+	delete(s.memberships, item["id"].(string))
+}
+
+func (s *Server) prepareEscalationPolicy(item object) {
+	setDefault(item, "num_loops", 0)
+	setDefault(item, "teams", []interface{}{})
+	for _, rule := range objects(item["escalation_rules"]) {
+		if id, _ := rule["id"].(string); id == "" {
+			rule["id"] = s.newID()
+		}
+	}
+}
+
+func (s *Server) viewEscalationPolicy(item object) {
+	services := []interface{}{}
+	for _, id := range s.collections["services"].order {
+		service := s.collections["services"].items[id]
+		if ep, ok := service["escalation_policy"].(object); ok && ep["id"] == item["id"] {
+			services = append(services, s.reference("services", id))
+		}
+	}
+	item["services"] = services
+}
+
+func prepareSchedule(item object) {
+	setDefault(item, "description", "")
+	setDefault(item, "teams", []interface{}{})
+
+	loc, err := time.LoadLocation(fmt.Sprint(item["time_zone"]))
+	if err != nil {
+		loc = time.UTC
+	}
+
+	for i, layer := range objects(item["schedule_layers"]) {
+		if id, _ := layer["id"].(string); id == "" {
+			layer["id"] = fmt.Sprintf("PL%05d", i+1)
+		}
+		// Like PagerDuty, times are served in the schedule's time zone
+		for _, k := range []string{"start", "end", "rotation_virtual_start"} {
+			if v, ok := layer[k].(string); ok {
+				layer[k] = formatTime(v, loc)
+			}
+		}
+	}
+}
+
+func (s *Server) viewSchedule(item object) {
+	// PagerDuty lists the layers of a schedule from the most recent one.
+	layers := objects(item["schedule_layers"])
+	reversed := make([]interface{}, 0, len(layers))
+	users := []interface{}{}
+	seen := map[string]bool{}
+	for i := len(layers) - 1; i >= 0; i-- {
+		reversed = append(reversed, layers[i])
+		for _, u := range objects(layers[i]["users"]) {
+			user, _ := u["user"].(object)
+			if id, _ := user["id"].(string); id != "" && !seen[id] {
+				seen[id] = true
+				users = append(users, s.reference("users", id))
+			}
+		}
+	}
+	item["schedule_layers"] = reversed
+	item["users"] = users
+	item["final_schedule"] = object{
+		"name":                         "Final Schedule",
+		"rendered_coverage_percentage": 100,
+		"rendered_schedule_entries":    []interface{}{},
+	}
+
+	eps := []interface{}{}
+	for _, id := range s.escalationPoliciesTargeting(item["id"].(string)) {
+		eps = append(eps, s.reference("escalation_policies", id))
+	}
+	item["escalation_policies"] = eps
+}
+
+func (s *Server) beforeDeleteSchedule(item object) string {
+	if len(s.escalationPoliciesTargeting(item["id"].(string))) > 0 {
+		return "Schedule can't be deleted if it's being used by escalation policies"
+	}
+	return ""
+}
+
+// escalationPoliciesTargeting returns the IDs of the escalation policies with
+// a rule targeting the schedule `scheduleID`.
+func (s *Server) escalationPoliciesTargeting(scheduleID string) []string {
+	var ids []string
+	c := s.collections["escalation_policies"]
+	for _, id := range c.order {
+		if isTargetingSchedule(c.items[id], scheduleID) {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+func isTargetingSchedule(ep object, scheduleID string) bool {
+	for _, rule := range objects(ep["escalation_rules"]) {
+		for _, target := range objects(rule["targets"]) {
+			if target["id"] == scheduleID {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// serveTeamAssociations handles the members and escalation policies of a
+// team, under `/teams/<id>/...`.
+func (s *Server) serveTeamAssociations(w http.ResponseWriter, r *http.Request, segments []string) {
+	teamID := segments[0]
+	if _, ok := s.collections["teams"].items[teamID]; !ok {
+		writeError(w, http.StatusNotFound, "Not Found", 2100)
+		return
+	}
+
+	switch {
+	case segments[1] == "members" && len(segments) == 2 && r.Method == http.MethodGet:
+		members := []interface{}{}
+		for _, userID := range sortedKeys(s.memberships[teamID]) {
+			members = append(members, object{
+				"user": s.reference("users", userID),
+				"role": s.memberships[teamID][userID],
+			})
+		}
+		writeJSON(w, http.StatusOK, object{
+			"members": members,
+			"limit":   len(members),
+			"offset":  0,
+			"total":   len(members),
+			"more":    false,
+		})
+
+	case segments[1] == "users" && len(segments) == 3:
+		userID := segments[2]
+		if _, ok := s.collections["users"].items[userID]; !ok {
+			writeError(w, http.StatusNotFound, "Not Found", 2100)
+			return
+		}
+		switch r.Method {
+		case http.MethodPut:
+			var payload struct {
+				Role string `json:"role"`
+			}
+			decodeOptional(r, &payload)
+			if payload.Role == "" {
+				payload.Role = "manager"
+			}
+			if s.memberships[teamID] == nil {
+				s.memberships[teamID] = map[string]string{}
+			}
+			s.memberships[teamID][userID] = payload.Role
+		case http.MethodDelete:
+			delete(s.memberships[teamID], userID)
+		default:
+			writeError(w, http.StatusMethodNotAllowed, "Method Not Allowed", 2100)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+
+	case segments[1] == "escalation_policies" && len(segments) == 3:
+		ep, ok := s.collections["escalation_policies"].items[segments[2]]
+		if !ok {
+			writeError(w, http.StatusNotFound, "Not Found", 2100)
+			return
+		}
+		teams := []interface{}{}
+		for _, t := range objects(ep["teams"]) {
+			if t["id"] != teamID {
+				teams = append(teams, t)
+			}
+		}
+		switch r.Method {
+		case http.MethodPut:
+			teams = append(teams, s.reference("teams", teamID))
+		case http.MethodDelete:
+		default:
+			writeError(w, http.StatusMethodNotAllowed, "Method Not Allowed", 2100)
+			return
+		}
+		ep["teams"] = teams
+		w.WriteHeader(http.StatusNoContent)
+
+	default:
+		writeError(w, http.StatusNotFound, "Not Found", 2100)
+	}
+}
+
+func setDefault(item object, key string, value interface{}) {
+	if _, ok := item[key]; !ok {
+		item[key] = value
+	}
+}
+
+// objects returns the elements of a JSON array which are JSON objects.
+func objects(v interface{}) []object {
+	arr, _ := v.([]interface{})
+	var result []object
+	for _, e := range arr {
+		if o, ok := e.(object); ok {
+			result = append(result, o)
+		}
+	}
+	return result
+}
+
+// formatTime returns `v` formatted as RFC3339 in the location `loc`. Besides
+// RFC3339 it accepts the format of time.Time's String method, which some
+// clients send.
+func formatTime(v string, loc *time.Location) string {
+	for _, layout := range []string{time.RFC3339, "2006-01-02 15:04:05.999999999 -0700 MST"} {
+		if t, err := time.Parse(layout, v); err == nil {
+			return t.In(loc).Format(time.RFC3339)
+		}
+	}
+	return v
+}
+
+// isTaggable returns whether PagerDuty supports assigning tags to the objects
+// of `collection`.
+func isTaggable(collection string) bool {
+	return collection == "users" || collection == "teams" || collection == "escalation_policies"
+}
+
+// serveTagAssignments handles the tags of an object, under
+// `/<collection>/<id>/tags` and `/<collection>/<id>/change_tags`.
+func (s *Server) serveTagAssignments(w http.ResponseWriter, r *http.Request, segments []string) {
+	if _, ok := s.collections[segments[0]].items[segments[1]]; !ok {
+		writeError(w, http.StatusNotFound, "Not Found", 2100)
+		return
+	}
+	key := segments[0] + "/" + segments[1]
+	tags := s.collections["tags"]
+
+	switch {
+	case segments[2] == "tags" && r.Method == http.MethodGet:
+		assigned := []object{}
+		for _, id := range s.tagAssignments[key] {
+			if tag, ok := tags.items[id]; ok {
+				assigned = append(assigned, tag)
+			}
+		}
+		writeJSON(w, http.StatusOK, object{"tags": assigned, "limit": 100, "offset": 0, "total": len(assigned), "more": false})
+	case segments[2] == "change_tags" && r.Method == http.MethodPost:
+		var changes struct {
+			Add, Remove []struct {
+				Type, ID, Label string
+			}
+		}
+		decodeOptional(r, &changes)
+		for _, a := range changes.Add {
+			id := a.ID
+			if a.Type == "tag" {
+				id = s.tagByLabel(a.Label)
+			}
+			if _, ok := tags.items[id]; !ok {
+				writeError(w, http.StatusBadRequest, "Invalid Input Provided", 2001, fmt.Sprintf("Tag %s not found", id))
+				return
+			}
+			if !isTagAssigned(s.tagAssignments[key], id) {
+				s.tagAssignments[key] = append(s.tagAssignments[key], id)
+			}
+		}
+		for _, a := range changes.Remove {
+			ids := s.tagAssignments[key]
+			for i, id := range ids {
+				if id == a.ID {
+					s.tagAssignments[key] = append(ids[:i], ids[i+1:]...)
+					break
+				}
+			}
+		}
+		writeJSON(w, http.StatusOK, object{})
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "Method Not Allowed", 2100)
+	}
+}
+
+func isTagAssigned(ids []string, id string) bool {
+	for _, v := range ids {
+		if v == id {
+			return true
+		}
+	}
+	return false
+}
+
+// tagByLabel returns the ID of the tag `label`, creating it when it doesn't
+// exist like assigning a tag by its label does.
+func (s *Server) tagByLabel(label string) string {
+	c := s.collections["tags"]
+	for _, id := range c.order {
+		if c.items[id]["label"] == label {
+			return id
+		}
+	}
+
+	id := s.newID()
+	c.items[id] = object{
+		"id":       id,
+		"type":     "tag",
+		"label":    label,
+		"summary":  label,
+		"self":     fmt.Sprintf("%s/tags/%s", s.URL, id),
+		"html_url": fmt.Sprintf("https://mock.pagerduty.com/tags/%s", id),
+	}
+	c.order = append(c.order, id)
+	return id
+}
@@ -0,0 +1,420 @@
+// Package mockapi provides an in-memory fake of PagerDuty's REST API, used to
+// run the provider's acceptance tests without a PagerDuty account.
+//
+// The fake keeps the objects it receives and serves them back like the real
+// API does, filling in the fields PagerDuty computes on its side. It supports
+// services, escalation policies, schedules, users (including their license),
+// teams (including their members), tags and their assignments, incidents,
+// maintenance windows, event orchestrations with their paths and cache
+// variables, and abilities.
+package mockapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Server is a fake PagerDuty REST API listening on a local address.
+type Server struct {
+	*httptest.Server
+
+	mu          sync.Mutex
+	lastID      int
+	collections map[string]*collection
+	// memberships maps team IDs to the roles of their members by user ID.
+	memberships map[string]map[string]string
+	// orchestrationPaths holds event orchestration paths by the
+	// `orchestrationPathKey` of their parent and type.
+	orchestrationPaths map[string]object
+	// serviceActive holds whether service orchestrations are active by
+	// service ID.
+	serviceActive map[string]bool
+	// cacheVariables holds the collections of cache variables by their path.
+	cacheVariables map[string]*collection
+	// tagAssignments holds the IDs of the tags assigned to an object by the
+	// collection and ID of the object, as in `teams/<id>`.
+	tagAssignments map[string][]string
+}
+
+type object = map[string]interface{}
+
+// NewServer starts a fake PagerDuty REST API. Its URL is meant to be used as
+// the provider's `api_url_override`. Callers should call Close when done.
+func NewServer() *Server {
+	s := &Server{
+		memberships:        map[string]map[string]string{},
+		orchestrationPaths: map[string]object{},
+		serviceActive:      map[string]bool{},
+		cacheVariables:     map[string]*collection{},
+		tagAssignments:     map[string][]string{},
+	}
+	s.collections = map[string]*collection{
+		"escalation_policies": {
+			name: "escalation_policies", singular: "escalation_policy", itemType: "escalation_policy",
+			prepare: s.prepareEscalationPolicy, view: s.viewEscalationPolicy,
+		},
+		"event_orchestrations": {
+			name: "event_orchestrations", singular: "orchestration", plural: "orchestrations",
+			prepare: s.prepareEventOrchestration,
+		},
+		"incidents": {
+			name: "incidents", singular: "incident", itemType: "incident",
+		},
+		"maintenance_windows": {
+			name: "maintenance_windows", singular: "maintenance_window", itemType: "maintenance_window",
+		},
+		"schedules": {
+			name: "schedules", singular: "schedule", itemType: "schedule",
+			prepare: prepareSchedule, view: s.viewSchedule, beforeDelete: s.beforeDeleteSchedule,
+		},
+		"services": {
+			name: "services", singular: "service", itemType: "service",
+			prepare: prepareService,
+		},
+		"tags": {
+			name: "tags", singular: "tag", itemType: "tag",
+		},
+		"teams": {
+			name: "teams", singular: "team", itemType: "team",
+			onDelete: s.onDeleteTeam,
+		},
+		"users": {
+			name: "users", singular: "user", itemType: "user",
+			prepare: prepareUser, view: s.viewUser, onDelete: s.onDeleteUser,
+		},
+	}
+	for _, c := range s.collections {
+		c.items = map[string]object{}
+	}
+
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	segments := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+
+	switch {
+	case segments[0] == "abilities":
+		s.serveAbilities(w, r, segments[1:])
+	case segments[0] == "users" && len(segments) == 3 && segments[2] == "license":
+		s.serveUserLicense(w, r, segments[1])
+	case len(segments) == 3 && isTaggable(segments[0]) && (segments[2] == "tags" || segments[2] == "change_tags"):
+		s.serveTagAssignments(w, r, segments)
+	case segments[0] == "teams" && len(segments) > 2:
+		s.serveTeamAssociations(w, r, segments[1:])
+	case segments[0] == "event_orchestrations" && (len(segments) > 2 || len(segments) == 2 && segments[1] == "services"):
+		s.serveOrchestrationPath(w, r, segments[1:])
+	case s.collections[segments[0]] != nil && len(segments) <= 2:
+		s.serveCollection(w, r, s.collections[segments[0]], segments[1:])
+	default:
+		writeError(w, http.StatusNotFound, "Not Found", 2100)
+	}
+}
+
+func (s *Server) serveAbilities(w http.ResponseWriter, r *http.Request, segments []string) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, "Method Not Allowed", 2100)
+		return
+	}
+	if len(segments) > 0 {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	writeJSON(w, http.StatusOK, object{"abilities": []string{
+		"teams", "read_only_users", "service_support_hours", "urgencies",
+		"event_rules", "coordinated_responding", "preview_incident_alert_grouping",
+	}})
+}
+
+func (s *Server) serveCollection(w http.ResponseWriter, r *http.Request, c *collection, segments []string) {
+	if len(segments) == 0 {
+		switch r.Method {
+		case http.MethodGet:
+			s.list(w, r, c)
+		case http.MethodPost:
+			s.create(w, r, c)
+		default:
+			writeError(w, http.StatusMethodNotAllowed, "Method Not Allowed", 2100)
+		}
+		return
+	}
+
+	id := segments[0]
+	item, ok := c.items[id]
+	if !ok {
+		writeError(w, http.StatusNotFound, "Not Found", 2100)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, object{c.singular: c.render(item)})
+	case http.MethodPut:
+		s.update(w, r, c, item)
+	case http.MethodDelete:
+		if c.beforeDelete != nil {
+			if msg := c.beforeDelete(item); msg != "" {
+				writeError(w, http.StatusBadRequest, "Invalid Input Provided", 2001, msg)
+				return
+			}
+		}
+		delete(c.items, id)
+		c.remove(id)
+		if c.onDelete != nil {
+			c.onDelete(item)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "Method Not Allowed", 2100)
+	}
+}
+
+func (s *Server) list(w http.ResponseWriter, r *http.Request, c *collection) {
+	query := strings.ToLower(r.URL.Query().Get("query"))
+
+	var items []object
+	for _, id := range c.order {
+		item := c.items[id]
+		if query != "" && !matchesQuery(item, query) {
+			continue
+		}
+		items = append(items, c.render(item))
+	}
+
+	offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+	if limit <= 0 {
+		limit = 25
+	}
+	total := len(items)
+	if offset > total {
+		offset = total
+	}
+	end := offset + limit
+	if end > total {
+		end = total
+	}
+	page := items[offset:end]
+	if page == nil {
+		page = []object{}
+	}
+
+	writeJSON(w, http.StatusOK, object{
+		c.listKey(): page,
+		"limit":     limit,
+		"offset":    offset,
+		"total":     total,
+		"more":      end < total,
+	})
+}
+
+func (s *Server) create(w http.ResponseWriter, r *http.Request, c *collection) {
+	item, ok := readPayload(w, r, c.singular)
+	if !ok {
+		return
+	}
+
+	id := s.newID()
+	item["id"] = id
+	if c.itemType != "" {
+		item["type"] = c.itemType
+	}
+	item["self"] = fmt.Sprintf("%s/%s/%s", s.URL, c.name, id)
+	item["html_url"] = fmt.Sprintf("https://mock.pagerduty.com/%s/%s", c.name, id)
+	if c.prepare != nil {
+		c.prepare(item)
+	}
+	if name, ok := item["name"].(string); ok {
+		item["summary"] = name
+	}
+
+	c.items[id] = item
+	c.order = append(c.order, id)
+	writeJSON(w, http.StatusCreated, object{c.singular: c.render(item)})
+}
+
+func (s *Server) update(w http.ResponseWriter, r *http.Request, c *collection, item object) {
+	changes, ok := readPayload(w, r, c.singular)
+	if !ok {
+		return
+	}
+
+	for k, v := range changes {
+		switch k {
+		case "id", "type", "self", "html_url":
+			continue
+		}
+		item[k] = v
+	}
+	if c.prepare != nil {
+		c.prepare(item)
+	}
+	if name, ok := item["name"].(string); ok {
+		item["summary"] = name
+	}
+
+	writeJSON(w, http.StatusOK, object{c.singular: c.render(item)})
+}
+
+func (s *Server) newID() string {
+	s.lastID++
+	return fmt.Sprintf("P%06X", s.lastID)
+}
+
+// reference returns a reference to the object `id` of the collection `name`,
+// as PagerDuty embeds them in other objects.
+func (s *Server) reference(name, id string) object {
+	c := s.collections[name]
+	ref := object{"id": id, "type": c.itemType + "_reference"}
+	if item, ok := c.items[id]; ok {
+		ref["summary"] = item["summary"]
+		ref["self"] = item["self"]
+		ref["html_url"] = item["html_url"]
+	}
+	return ref
+}
+
+// collection is a list of objects of the same kind, served under the path
+// `/<name>` and wrapped in the key `singular` when requested individually.
+type collection struct {
+	name     string
+	singular string
+	// plural is the key of listings, `name` when empty.
+	plural   string
+	itemType string
+
+	// prepare fills in the fields computed by the API after an object is
+	// created or updated.
+	prepare func(object)
+	// view adds to a copy of an object the fields which depend on other
+	// objects.
+	view func(object)
+	// beforeDelete returns the reason why an object can't be deleted, if any.
+	beforeDelete func(object) string
+	// onDelete cleans up the references to an object once it's deleted.
+	onDelete func(object)
+
+	items map[string]object
+	order []string
+}
+
+func (c *collection) listKey() string {
+	if c.plural != "" {
+		return c.plural
+	}
+	return c.name
+}
+
+func (c *collection) render(item object) object {
+	if c.view == nil {
+		return item
+	}
+	v := make(object, len(item))
+	for k, val := range item {
+		v[k] = val
+	}
+	c.view(v)
+	return v
+}
+
+func (c *collection) remove(id string) {
+	for i, v := range c.order {
+		if v == id {
+			c.order = append(c.order[:i], c.order[i+1:]...)
+			return
+		}
+	}
+}
+
+func matchesQuery(item object, query string) bool {
+	for _, k := range []string{"name", "email"} {
+		if v, ok := item[k].(string); ok && strings.Contains(strings.ToLower(v), query) {
+			return true
+		}
+	}
+	return false
+}
+
+// readPayload decodes the object wrapped in `key` of the request body. Like
+// PagerDuty, it accepts the object not being wrapped, which some clients do.
+func readPayload(w http.ResponseWriter, r *http.Request, key string) (object, bool) {
+	var payload object
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid Input Provided", 2001, err.Error())
+		return nil, false
+	}
+	if payload == nil {
+		writeError(w, http.StatusBadRequest, "Invalid Input Provided", 2001, fmt.Sprintf("%s is missing", key))
+		return nil, false
+	}
+	if item, ok := payload[key].(object); ok {
+		return item, true
+	}
+	return payload, true
+}
+
+// decodeOptional decodes the JSON body of `r` into `v` when there is one.
+func decodeOptional(r *http.Request, v interface{}) {
+	if r.Body != nil {
+		json.NewDecoder(r.Body).Decode(v)
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, message string, code int, errors ...string) {
+	if errors == nil {
+		errors = []string{}
+	}
+	writeJSON(w, status, object{"error": object{
+		"message": message,
+		"code":    code,
+		"errors":  errors,
+	}})
+}
+
+// sortedKeys returns the keys of `m` in a stable order.
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// EnvVar is the environment variable which makes acceptance tests run against
+// a Server instead of PagerDuty when set.
+const EnvVar = "PAGERDUTY_ACC_MOCK_API"
+
+// StartForAcceptanceTests starts a Server when EnvVar is set and returns its
+// URL, or an empty string otherwise. It also provides fake credentials for
+// the checks done by acceptance tests before running. The server lives until
+// the tests' process ends.
+func StartForAcceptanceTests() string {
+	if os.Getenv(EnvVar) == "" {
+		return ""
+	}
+
+	for _, k := range []string{"PAGERDUTY_TOKEN", "PAGERDUTY_USER_TOKEN"} {
+		if os.Getenv(k) == "" {
+			os.Setenv(k, "mock")
+		}
+	}
+	return NewServer().URL
+}
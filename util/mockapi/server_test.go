@@ -0,0 +1,198 @@
+package mockapi
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	gopd "github.com/PagerDuty/go-pagerduty"
+	"github.com/heimweh/go-pagerduty/pagerduty"
+)
+
+func newTestClient(t *testing.T) (*Server, *pagerduty.Client) {
+	s := NewServer()
+	t.Cleanup(s.Close)
+
+	client, err := pagerduty.NewClient(&pagerduty.Config{BaseURL: s.URL, Token: "foo"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s, client
+}
+
+func TestServerSchedulesUsedByEscalationPolicies(t *testing.T) {
+	_, client := newTestClient(t)
+
+	user, _, err := client.Users.Create(&pagerduty.User{Name: "foo", Email: "foo@foo.test"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := client.ValidateAuth(); err != nil {
+		t.Fatal(err)
+	}
+
+	users := []*pagerduty.UserReferenceWrapper{{User: &pagerduty.UserReference{ID: user.ID, Type: "user_reference"}}}
+	end := "2030-02-01T00:00:00Z"
+	schedule, _, err := client.Schedules.Create(&pagerduty.Schedule{
+		Name:     "foo",
+		TimeZone: "America/New_York",
+		ScheduleLayers: []*pagerduty.ScheduleLayer{
+			{Name: "first", Start: "2030-01-01T00:00:00Z", End: &end, RotationVirtualStart: "2030-01-01 00:00:00 +0000 UTC", RotationTurnLengthSeconds: 86400, Users: users},
+			{Name: "second", Start: "2030-01-01T00:00:00Z", RotationVirtualStart: "2030-01-01T00:00:00Z", RotationTurnLengthSeconds: 86400, Users: users},
+		},
+	}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ep, _, err := client.EscalationPolicies.Create(&pagerduty.EscalationPolicy{
+		Name: "foo",
+		EscalationRules: []*pagerduty.EscalationRule{{
+			EscalationDelayInMinutes: 10,
+			Targets:                  []*pagerduty.EscalationTargetReference{{ID: schedule.ID, Type: "schedule_reference"}},
+		}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ep.EscalationRules[0].ID == "" {
+		t.Errorf("expected an ID for the escalation rule")
+	}
+
+	schedule, _, err = client.Schedules.Get(schedule.ID, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := schedule.ScheduleLayers[0].Name; got != "second" {
+		t.Errorf("expected the most recent layer first; got %q", got)
+	}
+	if got := schedule.ScheduleLayers[1].RotationVirtualStart; got != "2029-12-31T19:00:00-05:00" {
+		t.Errorf("expected rotation_virtual_start in the schedule's time zone; got %q", got)
+	}
+	if len(schedule.EscalationPolicies) != 1 || schedule.EscalationPolicies[0].ID != ep.ID {
+		t.Errorf("expected the schedule to list escalation policy %s; got %v", ep.ID, schedule.EscalationPolicies)
+	}
+
+	_, err = client.Schedules.Delete(schedule.ID)
+	if err == nil || !strings.Contains(err.Error(), "being used by escalation policies") {
+		t.Errorf("expected the deletion of a schedule in use to fail; got %v", err)
+	}
+
+	if _, err := client.EscalationPolicies.Delete(ep.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.Schedules.Delete(schedule.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	_, _, err = client.Schedules.Get(schedule.ID, nil)
+	if perr, ok := err.(*pagerduty.Error); !ok || perr.ErrorResponse.Response.StatusCode != http.StatusNotFound {
+		t.Errorf("expected a not found error; got %v", err)
+	}
+}
+
+func TestServerListing(t *testing.T) {
+	_, client := newTestClient(t)
+
+	for _, name := range []string{"foo", "bar", "foobar"} {
+		if _, _, err := client.Teams.Create(&pagerduty.Team{Name: name}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	resp, _, err := client.Teams.List(&pagerduty.ListTeamsOptions{Query: "foo", Limit: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.Teams) != 1 || resp.Teams[0].Name != "foo" || !resp.More {
+		t.Errorf("unexpected first page: %+v", resp)
+	}
+
+	resp, _, err = client.Teams.List(&pagerduty.ListTeamsOptions{Query: "foo", Limit: 1, Offset: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.Teams) != 1 || resp.Teams[0].Name != "foobar" || resp.More {
+		t.Errorf("unexpected second page: %+v", resp)
+	}
+}
+
+func TestServerTeamMembers(t *testing.T) {
+	s, client := newTestClient(t)
+	ctx := context.Background()
+
+	user, _, err := client.Users.Create(&pagerduty.User{Name: "foo", Email: "foo@foo.test"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	plugin := gopd.NewClient("foo", gopd.WithAPIEndpoint(s.URL))
+	team, err := plugin.CreateTeamWithContext(ctx, &gopd.Team{Name: "foo"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := plugin.AddUserToTeamWithContext(ctx, gopd.AddUserToTeamOptions{TeamID: team.ID, UserID: user.ID, Role: "responder"}); err != nil {
+		t.Fatal(err)
+	}
+
+	members, err := plugin.ListTeamMembers(ctx, team.ID, gopd.ListTeamMembersOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(members.Members) != 1 || members.Members[0].User.ID != user.ID || members.Members[0].Role != "responder" {
+		t.Errorf("unexpected members: %+v", members.Members)
+	}
+
+	user, _, err = client.Users.Get(user.ID, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(user.Teams) != 1 || user.Teams[0].ID != team.ID {
+		t.Errorf("expected the user to list team %s; got %v", team.ID, user.Teams)
+	}
+
+	if err := plugin.DeleteTeamWithContext(ctx, team.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := plugin.GetTeamWithContext(ctx, team.ID); err == nil {
+		t.Errorf("expected an error getting a deleted team")
+	}
+}
+
+func TestServerEventOrchestrationPaths(t *testing.T) {
+	_, client := newTestClient(t)
+
+	orchestration, _, err := client.EventOrchestrations.Create(&pagerduty.EventOrchestration{Name: "foo"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(orchestration.Integrations) != 1 {
+		t.Errorf("expected a default integration; got %v", orchestration.Integrations)
+	}
+
+	router, _, err := client.EventOrchestrationPaths.Get(orchestration.ID, pagerduty.PathTypeRouter)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if router.CatchAll.Actions.RouteTo != "unrouted" {
+		t.Errorf("expected the router to route to unrouted by default; got %q", router.CatchAll.Actions.RouteTo)
+	}
+
+	router.Sets[0].Rules = []*pagerduty.EventOrchestrationPathRule{{
+		Label:   "foo",
+		Actions: &pagerduty.EventOrchestrationPathRuleActions{RouteTo: "PSERVICE"},
+	}}
+	updated, _, err := client.EventOrchestrationPaths.Update(orchestration.ID, pagerduty.PathTypeRouter, router)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rules := updated.OrchestrationPath.Sets[0].Rules
+	if len(rules) != 1 || rules[0].ID == "" || rules[0].Actions.RouteTo != "PSERVICE" {
+		t.Errorf("unexpected rules: %+v", rules)
+	}
+
+	if _, _, err := client.EventOrchestrationPaths.Get("PMISSING", pagerduty.PathTypeRouter); err == nil {
+		t.Errorf("expected an error getting the router of a missing orchestration")
+	}
+}
@@ -0,0 +1,402 @@
+// Package oauthtoken obtains the App OAuth scoped tokens used by both the
+// SDKv2 and the plugin framework halves of the provider. Tokens are cached in
+// files keyed by the client, the account and the scopes they were requested
+// with, so that concurrent runs with different credentials or scopes don't
+// overwrite each other's token, and runs sharing them reuse it.
+package oauthtoken
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/clientcredentials"
+)
+
+const (
+	// DefaultTokenURL is the endpoint tokens are requested from.
+	DefaultTokenURL = "https://identity.pagerduty.com/oauth/token"
+
+	// ReadOnlyPreset stands for every read scope in a list of scopes.
+	ReadOnlyPreset = "read_only"
+
+	// expiryMargin is how long before its expiry a cached token is
+	// replaced, so that it doesn't expire while being used.
+	expiryMargin = 5 * time.Minute
+
+	lockTimeout      = 30 * time.Second
+	lockPollInterval = 100 * time.Millisecond
+	// staleLockAge is the age after which a lock file is considered left
+	// behind by a process which didn't release it.
+	staleLockAge = 2 * time.Minute
+)
+
+var scopePattern = regexp.MustCompile(`^[a-z_:]+\.(read|write)$`)
+
+// AvailableScopes returns every scope a token can be requested with.
+func AvailableScopes() []string {
+	return []string{
+		"abilities.read",
+		"addons.read",
+		"addons.write",
+		"analytics.read",
+		"audit_records.read",
+		"change_events.read",
+		"change_events.write",
+		"custom_fields.read",
+		"custom_fields.write",
+		"escalation_policies.read",
+		"escalation_policies.write",
+		"event_orchestrations.read",
+		"event_orchestrations.write",
+		"event_rules.read",
+		"event_rules.write",
+		"extension_schemas.read",
+		"extensions.read",
+		"extensions.write",
+		"incident_workflows.read",
+		"incident_workflows.write",
+		"incident_workflows:instances.write",
+		"incidents.read",
+		"incidents.write",
+		"licenses.read",
+		"notifications.read",
+		"oncalls.read",
+		"priorities.read",
+		"response_plays.read",
+		"response_plays.write",
+		"schedules.read",
+		"schedules.write",
+		"services.read",
+		"services.write",
+		"standards.read",
+		"standards.write",
+		"status_dashboards.read",
+		"status_pages.read",
+		"status_pages.write",
+		"subscribers.read",
+		"subscribers.write",
+		"tags.read",
+		"tags.write",
+		"teams.read",
+		"teams.write",
+		"templates.read",
+		"templates.write",
+		"users.read",
+		"users.write",
+		"users:contact_methods.read",
+		"users:contact_methods.write",
+		"users:sessions.read",
+		"users:sessions.write",
+		"vendors.read",
+	}
+}
+
+// ReadOnlyScopes returns the read scopes of AvailableScopes.
+func ReadOnlyScopes() []string {
+	var scopes []string
+	for _, s := range AvailableScopes() {
+		if strings.HasSuffix(s, ".read") {
+			scopes = append(scopes, s)
+		}
+	}
+	return scopes
+}
+
+// Scopes returns the sorted scopes to request a token with, given the
+// configured ones, which can include the ReadOnlyPreset. Every available
+// scope is returned when none is configured.
+func Scopes(configured []string) ([]string, error) {
+	if len(configured) == 0 {
+		return AvailableScopes(), nil
+	}
+
+	seen := map[string]bool{}
+	var scopes []string
+	add := func(s string) {
+		if !seen[s] {
+			seen[s] = true
+			scopes = append(scopes, s)
+		}
+	}
+	for _, s := range configured {
+		switch {
+		case s == ReadOnlyPreset:
+			for _, r := range ReadOnlyScopes() {
+				add(r)
+			}
+		case scopePattern.MatchString(s):
+			add(s)
+		default:
+			return nil, fmt.Errorf("invalid scope %q, expected %q or a scope such as \"services.read\"", s, ReadOnlyPreset)
+		}
+	}
+	sort.Strings(scopes)
+	return scopes, nil
+}
+
+// Options configures the token source returned by NewTokenSource.
+type Options struct {
+	ClientID     string
+	ClientSecret string
+	// Region of the account, `us` when empty.
+	Region    string
+	Subdomain string
+	// Scopes as returned by Scopes, AvailableScopes when empty.
+	Scopes []string
+	// CacheDir is the directory the token is cached in, DefaultCacheDir
+	// when empty.
+	CacheDir string
+	// TokenURL defaults to DefaultTokenURL.
+	TokenURL string
+}
+
+func (o Options) account() string {
+	region := o.Region
+	if region == "" {
+		region = "us"
+	}
+	return fmt.Sprintf("as_account-%s.%s", region, o.Subdomain)
+}
+
+func (o Options) scopes() []string {
+	if len(o.Scopes) == 0 {
+		return AvailableScopes()
+	}
+	return o.Scopes
+}
+
+// DefaultCacheDir returns the `.pagerduty` directory of the home directory of
+// the user, or the working directory if the user has no home directory.
+func DefaultCacheDir() string {
+	dir, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, ".pagerduty")
+}
+
+// CachePath returns the path of the file caching the tokens of `o`.
+func CachePath(o Options) string {
+	dir := o.CacheDir
+	if dir == "" {
+		dir = DefaultCacheDir()
+	}
+	key := sha256.Sum256([]byte(strings.Join([]string{o.ClientID, o.account(), strings.Join(o.scopes(), " ")}, "\n")))
+	return filepath.Join(dir, fmt.Sprintf("token-%s.json", hex.EncodeToString(key[:8])))
+}
+
+// NewTokenSource returns a token source requesting tokens for the account
+// and scopes of `o`, and caching them in the file at CachePath. Access to
+// the file is locked, so that concurrent runs request a single token.
+func NewTokenSource(o Options) oauth2.TokenSource {
+	tokenURL := o.TokenURL
+	if tokenURL == "" {
+		tokenURL = DefaultTokenURL
+	}
+	config := clientcredentials.Config{
+		ClientID:     o.ClientID,
+		ClientSecret: o.ClientSecret,
+		Scopes:       append([]string{o.account()}, o.scopes()...),
+		AuthStyle:    oauth2.AuthStyleInParams,
+		TokenURL:     tokenURL,
+	}
+
+	return &tokenSource{file: &fileTokenSource{
+		base:     &clientCredentialsSource{config: config},
+		path:     CachePath(o),
+		clientID: o.ClientID,
+		scopes:   strings.Join(config.Scopes, " "),
+	}}
+}
+
+// tokenSource keeps the token of its file token source in memory until it
+// expires or is rejected by the API.
+type tokenSource struct {
+	mu    sync.Mutex
+	token *oauth2.Token
+	file  *fileTokenSource
+}
+
+func (s *tokenSource) Token() (*oauth2.Token, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.token.Valid() {
+		return s.token, nil
+	}
+	t, err := s.file.Token()
+	if err != nil {
+		return nil, err
+	}
+	s.token = t
+	return t, nil
+}
+
+// invalidate drops the token `accessToken`, so that the next call to Token
+// requests a new one.
+func (s *tokenSource) invalidate(accessToken string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.token != nil && s.token.AccessToken == accessToken {
+		s.token = nil
+	}
+	s.file.invalidate(accessToken)
+}
+
+// clientCredentialsSource requests a new token on every call, unlike the
+// token source of clientcredentials.Config which keeps it until it expires.
+type clientCredentialsSource struct {
+	config clientcredentials.Config
+}
+
+func (s *clientCredentialsSource) Token() (*oauth2.Token, error) {
+	// The provider's context ends once it's configured, while tokens can be
+	// requested later on.
+	return s.config.Token(context.Background())
+}
+
+type fileTokenSource struct {
+	base     oauth2.TokenSource
+	path     string
+	clientID string
+	scopes   string
+}
+
+type cachedToken struct {
+	*oauth2.Token
+	ClientID string `json:"clientId"`
+	Scopes   string `json:"scopes"`
+}
+
+func (s *fileTokenSource) Token() (*oauth2.Token, error) {
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create the directory of the token cache %s: %w", s.path, err)
+	}
+
+	unlock, err := lock(s.path + ".lock")
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	if t := s.load(); t != nil {
+		log.Printf("[DEBUG] Using the OAuth token cached in %s", s.path)
+		return t, nil
+	}
+
+	t, err := s.base.Token()
+	if err != nil {
+		return nil, err
+	}
+	if err := s.save(t); err != nil {
+		log.Printf("[WARN] Failed to cache the OAuth token in %s: %s", s.path, err)
+	}
+	return t, nil
+}
+
+// invalidate removes the cached token when it's `accessToken`, leaving a
+// token cached by another run in place.
+func (s *fileTokenSource) invalidate(accessToken string) {
+	unlock, err := lock(s.path + ".lock")
+	if err != nil {
+		log.Printf("[WARN] Failed to remove the rejected OAuth token from %s: %s", s.path, err)
+		return
+	}
+	defer unlock()
+
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		return
+	}
+	var c cachedToken
+	if err := json.Unmarshal(data, &c); err == nil && c.Token != nil && c.Token.AccessToken != accessToken {
+		return
+	}
+	os.Remove(s.path)
+}
+
+// load returns the cached token, unless it's missing, about to expire, or
+// was requested by another client or with other scopes.
+func (s *fileTokenSource) load() *oauth2.Token {
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		return nil
+	}
+	var c cachedToken
+	if err := json.Unmarshal(data, &c); err != nil || c.Token == nil {
+		return nil
+	}
+	if c.ClientID != s.clientID || c.Scopes != s.scopes || !c.Token.Valid() {
+		return nil
+	}
+	if !c.Token.Expiry.IsZero() && time.Until(c.Token.Expiry) < expiryMargin {
+		return nil
+	}
+	return c.Token
+}
+
+// save writes the token to a temporary file renamed over the cache, so
+// that the cache is never partially written.
+func (s *fileTokenSource) save(t *oauth2.Token) error {
+	data, err := json.Marshal(cachedToken{Token: t, ClientID: s.clientID, Scopes: s.scopes})
+	if err != nil {
+		return err
+	}
+
+	f, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(f.Name(), 0o600); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), s.path)
+}
+
+// lock creates the lock file at `path`, waiting for other processes to
+// remove it, and returns the function removing it.
+func lock(path string) (func(), error) {
+	deadline := time.Now().Add(lockTimeout)
+	for {
+		f, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o600)
+		if err == nil {
+			f.Close()
+			return func() { os.Remove(path) }, nil
+		}
+		if !os.IsExist(err) {
+			return nil, fmt.Errorf("failed to lock the token cache: %w", err)
+		}
+
+		if info, err := os.Stat(path); err == nil && time.Since(info.ModTime()) > staleLockAge {
+			log.Printf("[WARN] Removing the stale lock %s", path)
+			os.Remove(path)
+			continue
+		}
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("timed out waiting for the lock %s of the token cache", path)
+		}
+		time.Sleep(lockPollInterval)
+	}
+}
@@ -0,0 +1,177 @@
+package oauthtoken
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"reflect"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestScopes(t *testing.T) {
+	scopes, err := Scopes(nil)
+	if err != nil || !reflect.DeepEqual(scopes, AvailableScopes()) {
+		t.Errorf("expected every scope without configured scopes, got %v, %v", scopes, err)
+	}
+
+	scopes, err = Scopes([]string{ReadOnlyPreset})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, s := range scopes {
+		if !strings.HasSuffix(s, ".read") {
+			t.Errorf("expected only read scopes with the %s preset, got %s", ReadOnlyPreset, s)
+		}
+	}
+	if len(scopes) != len(ReadOnlyScopes()) {
+		t.Errorf("expected %d scopes, got %d", len(ReadOnlyScopes()), len(scopes))
+	}
+
+	scopes, err = Scopes([]string{"services.write", "services.read", "services.write"})
+	if err != nil || !reflect.DeepEqual(scopes, []string{"services.read", "services.write"}) {
+		t.Errorf("expected sorted unique scopes, got %v, %v", scopes, err)
+	}
+
+	if _, err := Scopes([]string{"services"}); err == nil {
+		t.Error("expected an error for an invalid scope")
+	}
+}
+
+func TestCachePath(t *testing.T) {
+	o := Options{ClientID: "client", Subdomain: "acme", Scopes: []string{"services.read"}, CacheDir: "/tmp/pd"}
+
+	if !strings.HasPrefix(CachePath(o), "/tmp/pd/token-") {
+		t.Errorf("expected the cache to be in the cache directory, got %s", CachePath(o))
+	}
+
+	other := []Options{o, o, o, o}
+	other[0].ClientID = "other"
+	other[1].Subdomain = "other"
+	other[2].Scopes = []string{"services.read", "services.write"}
+	other[3].Region = "eu"
+	for _, p := range other {
+		if CachePath(p) == CachePath(o) {
+			t.Errorf("expected %+v and %+v to be cached in different files", p, o)
+		}
+	}
+
+	same := o
+	same.Region = "us"
+	same.ClientSecret = "secret"
+	if CachePath(same) != CachePath(o) {
+		t.Errorf("expected %+v and %+v to be cached in the same file", same, o)
+	}
+}
+
+func TestNewTokenSource(t *testing.T) {
+	var requests int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&requests, 1)
+		r.ParseForm()
+		if r.Form.Get("client_id") != "client" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		// Gives concurrent token sources a chance to request a token too.
+		time.Sleep(50 * time.Millisecond)
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"access_token": "token-%d", "token_type": "bearer", "expires_in": 3600, "scope": %q}`, n, r.Form.Get("scope"))
+	}))
+	t.Cleanup(ts.Close)
+
+	o := Options{
+		ClientID:     "client",
+		ClientSecret: "secret",
+		Subdomain:    "acme",
+		Scopes:       []string{"services.read"},
+		CacheDir:     t.TempDir(),
+		TokenURL:     ts.URL,
+	}
+
+	// Token sources of concurrent runs share a single token.
+	var wg sync.WaitGroup
+	tokens := make([]string, 5)
+	for i := range tokens {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			tok, err := NewTokenSource(o).Token()
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			tokens[i] = tok.AccessToken
+		}(i)
+	}
+	wg.Wait()
+	for _, tok := range tokens {
+		if tok != "token-1" {
+			t.Errorf("expected every run to use token-1, got %v", tokens)
+			break
+		}
+	}
+	if requests != 1 {
+		t.Errorf("expected a single token request, got %d", requests)
+	}
+	if _, err := os.Stat(CachePath(o) + ".lock"); !os.IsNotExist(err) {
+		t.Errorf("expected the lock to be released, got %v", err)
+	}
+
+	// Other scopes are cached separately.
+	readWrite := o
+	readWrite.Scopes = []string{"services.read", "services.write"}
+	tok, err := NewTokenSource(readWrite).Token()
+	if err != nil || tok.AccessToken != "token-2" {
+		t.Errorf("expected a new token for other scopes, got %v, %v", tok, err)
+	}
+	tok, err = NewTokenSource(o).Token()
+	if err != nil || tok.AccessToken != "token-1" {
+		t.Errorf("expected the cached token, got %v, %v", tok, err)
+	}
+
+	// Tokens about to expire are replaced.
+	data, err := os.ReadFile(CachePath(o))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var cached cachedToken
+	if err := json.Unmarshal(data, &cached); err != nil {
+		t.Fatal(err)
+	}
+	cached.Expiry = time.Now().Add(time.Minute)
+	if data, err = json.Marshal(cached); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(CachePath(o), data, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	tok, err = NewTokenSource(o).Token()
+	if err != nil || tok.AccessToken != "token-3" {
+		t.Errorf("expected a new token replacing the expiring one, got %v, %v", tok, err)
+	}
+
+	// A stale lock doesn't block.
+	lockPath := CachePath(o) + ".lock"
+	if err := os.WriteFile(lockPath, nil, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	old := time.Now().Add(-2 * staleLockAge)
+	if err := os.Chtimes(lockPath, old, old); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NewTokenSource(o).Token(); err != nil {
+		t.Errorf("expected the stale lock to be removed, got %v", err)
+	}
+
+	bad := o
+	bad.ClientID = "bad"
+	if _, err := NewTokenSource(bad).Token(); err == nil {
+		t.Error("expected an error for rejected credentials")
+	}
+}
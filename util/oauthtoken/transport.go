@@ -0,0 +1,103 @@
+package oauthtoken
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+
+	"golang.org/x/oauth2"
+)
+
+// Transport returns a RoundTripper authenticating the requests sent to `next`
+// with the tokens of `source`. A request rejected as unauthorized, as when
+// its token expired or was revoked, is sent again once with a new token when
+// `source` was returned by NewTokenSource. The message of a request rejected
+// for lack of a scope names the missing scope.
+func Transport(source oauth2.TokenSource, next http.RoundTripper) http.RoundTripper {
+	return &transport{source: source, next: next}
+}
+
+type transport struct {
+	source oauth2.TokenSource
+	next   http.RoundTripper
+}
+
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	token, err := t.source.Token()
+	if err != nil {
+		return nil, fmt.Errorf("failed to obtain an App OAuth scoped token: %w", err)
+	}
+
+	resp, err := t.send(req, token)
+	if err != nil {
+		return nil, err
+	}
+
+	refreshable, ok := t.source.(*tokenSource)
+	if resp.StatusCode == http.StatusUnauthorized && ok && (req.Body == nil || req.GetBody != nil) {
+		log.Printf("[INFO] The App OAuth scoped token was rejected, requesting a new one")
+		resp.Body.Close()
+		refreshable.invalidate(token.AccessToken)
+
+		if token, err = t.source.Token(); err != nil {
+			return nil, fmt.Errorf("failed to obtain a new App OAuth scoped token: %w", err)
+		}
+		if resp, err = t.send(req, token); err != nil {
+			return nil, err
+		}
+	}
+
+	if resp.StatusCode == http.StatusForbidden {
+		return describeMissingScope(resp)
+	}
+	return resp, nil
+}
+
+func (t *transport) send(req *http.Request, token *oauth2.Token) (*http.Response, error) {
+	r := req.Clone(req.Context())
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		r.Body = body
+	}
+	r.Header.Set("Authorization", "Bearer "+token.AccessToken)
+	return t.next.RoundTrip(r)
+}
+
+// describeMissingScope adds the scope required by a request to the message of
+// its error, which PagerDuty returns aside.
+func describeMissingScope(resp *http.Response) (*http.Response, error) {
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	var payload map[string]map[string]interface{}
+	if err := json.Unmarshal(body, &payload); err != nil || payload["error"] == nil {
+		return resp, nil
+	}
+	scopes, _ := payload["error"]["required_scopes"].(string)
+	if scopes == "" {
+		return resp, nil
+	}
+	message, _ := payload["error"]["message"].(string)
+	payload["error"]["message"] = fmt.Sprintf("%s: the token lacks the %s scope required by this request", message, scopes)
+
+	if body, err = json.Marshal(payload); err != nil {
+		return resp, nil
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	resp.ContentLength = int64(len(body))
+	if resp.Header != nil {
+		resp.Header.Set("Content-Length", strconv.Itoa(len(body)))
+	}
+	return resp, nil
+}
@@ -0,0 +1,77 @@
+package oauthtoken
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+func TestTransport(t *testing.T) {
+	var tokens int32
+	identity := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&tokens, 1)
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"access_token": "token-%d", "token_type": "bearer", "expires_in": 3600}`, n)
+	}))
+	t.Cleanup(identity.Close)
+
+	var bodies []string
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Header.Get("Authorization") == "Bearer token-1":
+			// Revoked before its expiry.
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"error": {"message": "Unauthorized", "code": 2006}}`))
+		case r.URL.Path == "/schedules":
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte(`{"error": {"message": "Access Denied", "code": 2010, "required_scopes": "schedules.write", "token_scopes": "services.read"}}`))
+		default:
+			body, _ := io.ReadAll(r.Body)
+			bodies = append(bodies, string(body))
+			w.Write([]byte(`{}`))
+		}
+	}))
+	t.Cleanup(api.Close)
+
+	source := NewTokenSource(Options{ClientID: "client", Subdomain: "acme", CacheDir: t.TempDir(), TokenURL: identity.URL})
+	client := &http.Client{Transport: Transport(source, http.DefaultTransport)}
+
+	resp, err := client.Post(api.URL+"/services", "application/json", strings.NewReader(`{"service": {}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("expected the request to be sent again with a new token, got %s", resp.Status)
+	}
+	if len(bodies) != 1 || bodies[0] != `{"service": {}}` {
+		t.Errorf("expected the body to be sent again, got %q", bodies)
+	}
+	if tok, err := source.Token(); err != nil || tok.AccessToken != "token-2" {
+		t.Errorf("expected the new token to be kept, got %v, %v", tok, err)
+	}
+
+	resp, err = client.Post(api.URL+"/schedules", "application/json", strings.NewReader(`{}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	var payload struct {
+		Error struct {
+			Message string `json:"message"`
+			Code    int    `json:"code"`
+		} `json:"error"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&payload); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(payload.Error.Message, "schedules.write scope") || payload.Error.Code != 2010 {
+		t.Errorf("expected the missing scope in the error, got %+v", payload.Error)
+	}
+}
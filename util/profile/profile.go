@@ -0,0 +1,85 @@
+// Package profile reads the credentials of the named profiles of the
+// credentials file shared with other PagerDuty tools, `~/.pagerduty/credentials`.
+// Both the SDKv2 and the plugin framework halves of the provider resolve the
+// `profile` argument with it.
+package profile
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/heimweh/go-pagerduty/persistentconfig"
+	"github.com/spf13/afero"
+	"gopkg.in/ini.v1"
+)
+
+// Keys of the credentials of a profile, named after the provider arguments
+// they stand for.
+const (
+	TokenKey         = "token"
+	UserTokenKey     = "user_token"
+	ServiceRegionKey = "service_region"
+	ClientIDKey      = "pd_client_id"
+	ClientSecretKey  = "pd_client_secret"
+	SubdomainKey     = "pd_subdomain"
+)
+
+// Profile holds the credentials of a profile. Credentials missing from the
+// profile are empty.
+type Profile struct {
+	Name          string
+	Token         string
+	UserToken     string
+	ServiceRegion string
+	ClientID      string
+	ClientSecret  string
+	Subdomain     string
+}
+
+// HasAppOauthCredentials reports whether the profile has the client
+// credentials of a Scoped OAuth client.
+func (p *Profile) HasAppOauthCredentials() bool {
+	return p.ClientID != "" && p.ClientSecret != ""
+}
+
+// Load reads the profile `name` from the credentials file, failing when the
+// file has no such profile.
+func Load(name string) (*Profile, error) {
+	return load(afero.NewOsFs(), name)
+}
+
+// load reads the credentials file directly rather than through
+// persistentconfig, which creates the missing files and rewrites the active
+// profile of the config file, failing with a read-only home directory.
+func load(fs afero.Fs, name string) (*Profile, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return nil, fmt.Errorf("failed to locate the PagerDuty credentials file: %w", err)
+	}
+	path := filepath.Join(home, persistentconfig.DefaultConfigFolder, persistentconfig.DefaultCredentialsFileName)
+	displayPath := filepath.Join("~", persistentconfig.DefaultConfigFolder, persistentconfig.DefaultCredentialsFileName)
+
+	b, err := afero.ReadFile(fs, path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the PagerDuty credentials file %s: %w", displayPath, err)
+	}
+	cfg, err := ini.Load(b)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the PagerDuty credentials file %s: %w", displayPath, err)
+	}
+	section, err := cfg.GetSection(name)
+	if err != nil {
+		return nil, fmt.Errorf("profile %q not found in the PagerDuty credentials file %s", name, displayPath)
+	}
+
+	return &Profile{
+		Name:          name,
+		Token:         section.Key(TokenKey).String(),
+		UserToken:     section.Key(UserTokenKey).String(),
+		ServiceRegion: section.Key(ServiceRegionKey).String(),
+		ClientID:      section.Key(ClientIDKey).String(),
+		ClientSecret:  section.Key(ClientSecretKey).String(),
+		Subdomain:     section.Key(SubdomainKey).String(),
+	}, nil
+}
@@ -0,0 +1,81 @@
+package profile
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/spf13/afero"
+)
+
+func TestLoad(t *testing.T) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		t.Skip("no home directory")
+	}
+
+	base := afero.NewMemMapFs()
+	credentials := `[default]
+token = default-token
+
+[eu]
+token          = eu-token
+user_token     = eu-user-token
+service_region = eu
+
+[oauth]
+pd_client_id     = client
+pd_client_secret = secret
+pd_subdomain     = acme
+`
+	if err := afero.WriteFile(base, filepath.Join(home, ".pagerduty", "credentials"), []byte(credentials), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	// Profiles are read without touching the files, as home directories may
+	// be read-only.
+	fs := afero.NewReadOnlyFs(base)
+
+	cases := map[string]*Profile{
+		"default": {Name: "default", Token: "default-token"},
+		"eu":      {Name: "eu", Token: "eu-token", UserToken: "eu-user-token", ServiceRegion: "eu"},
+		"oauth":   {Name: "oauth", ClientID: "client", ClientSecret: "secret", Subdomain: "acme"},
+	}
+	for name, expected := range cases {
+		p, err := load(fs, name)
+		if err != nil {
+			t.Errorf("%s: %s", name, err)
+			continue
+		}
+		if !reflect.DeepEqual(p, expected) {
+			t.Errorf("%s: expected %+v, got %+v", name, expected, p)
+		}
+	}
+
+	p, _ := load(fs, "oauth")
+	if !p.HasAppOauthCredentials() {
+		t.Error("expected the oauth profile to have App OAuth credentials")
+	}
+	p, _ = load(fs, "eu")
+	if p.HasAppOauthCredentials() {
+		t.Error("expected the eu profile not to have App OAuth credentials")
+	}
+
+	if _, err := load(fs, "missing"); err == nil {
+		t.Error("expected an error for a missing profile")
+	}
+}
+
+func TestLoadWithoutCredentialsFile(t *testing.T) {
+	if _, err := os.UserHomeDir(); err != nil {
+		t.Skip("no home directory")
+	}
+
+	base := afero.NewMemMapFs()
+	if _, err := load(afero.NewReadOnlyFs(base), "default"); err == nil {
+		t.Error("expected an error without a credentials file")
+	}
+	if entries, _ := afero.ReadDir(base, "/"); len(entries) != 0 {
+		t.Errorf("expected no file to be created, got %d entries", len(entries))
+	}
+}
@@ -0,0 +1,68 @@
+package util
+
+import (
+	"sort"
+	"time"
+)
+
+// TimeInterval is a half-open range of time [Start, End).
+type TimeInterval struct {
+	Start time.Time
+	End   time.Time
+}
+
+// Duration returns how long the interval lasts.
+func (i TimeInterval) Duration() time.Duration {
+	return i.End.Sub(i.Start)
+}
+
+// MergeTimeIntervals returns the union of the given intervals sorted by start
+// time, joining the ones that overlap or are contiguous. Empty intervals are
+// discarded.
+func MergeTimeIntervals(intervals []TimeInterval) []TimeInterval {
+	sorted := make([]TimeInterval, 0, len(intervals))
+	for _, i := range intervals {
+		if i.End.After(i.Start) {
+			sorted = append(sorted, i)
+		}
+	}
+	sort.Slice(sorted, func(a, b int) bool {
+		return sorted[a].Start.Before(sorted[b].Start)
+	})
+
+	merged := []TimeInterval{}
+	for _, i := range sorted {
+		last := len(merged) - 1
+		if last >= 0 && !i.Start.After(merged[last].End) {
+			if i.End.After(merged[last].End) {
+				merged[last].End = i.End
+			}
+			continue
+		}
+		merged = append(merged, i)
+	}
+	return merged
+}
+
+// UncoveredTimeIntervals returns the parts of `window` which are not covered by
+// any of the `covered` intervals.
+func UncoveredTimeIntervals(window TimeInterval, covered []TimeInterval) []TimeInterval {
+	gaps := []TimeInterval{}
+	cursor := window.Start
+	for _, i := range MergeTimeIntervals(covered) {
+		if !i.End.After(cursor) {
+			continue
+		}
+		if !i.Start.Before(window.End) {
+			break
+		}
+		if i.Start.After(cursor) {
+			gaps = append(gaps, TimeInterval{Start: cursor, End: i.Start})
+		}
+		cursor = i.End
+	}
+	if cursor.Before(window.End) {
+		gaps = append(gaps, TimeInterval{Start: cursor, End: window.End})
+	}
+	return gaps
+}
@@ -0,0 +1,54 @@
+package util
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestUncoveredTimeIntervals(t *testing.T) {
+	base := time.Date(2025, 1, 6, 0, 0, 0, 0, time.UTC)
+	at := func(h int) time.Time { return base.Add(time.Duration(h) * time.Hour) }
+	window := TimeInterval{Start: at(0), End: at(24)}
+
+	cases := []struct {
+		name    string
+		covered []TimeInterval
+		want    []TimeInterval
+	}{
+		{
+			name:    "nothing covered",
+			covered: nil,
+			want:    []TimeInterval{window},
+		},
+		{
+			name:    "fully covered by contiguous intervals",
+			covered: []TimeInterval{{at(12), at(30)}, {at(-2), at(12)}},
+			want:    []TimeInterval{},
+		},
+		{
+			name:    "overlapping intervals leave a hole",
+			covered: []TimeInterval{{at(0), at(8)}, {at(4), at(10)}, {at(14), at(24)}},
+			want:    []TimeInterval{{at(10), at(14)}},
+		},
+		{
+			name:    "holes at both ends",
+			covered: []TimeInterval{{at(9), at(17)}},
+			want:    []TimeInterval{{at(0), at(9)}, {at(17), at(24)}},
+		},
+		{
+			name:    "intervals outside of the window are ignored",
+			covered: []TimeInterval{{at(-10), at(-5)}, {at(30), at(40)}},
+			want:    []TimeInterval{window},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got := UncoveredTimeIntervals(window, c.covered)
+			if !reflect.DeepEqual(got, c.want) {
+				t.Errorf("want %v; got %v", c.want, got)
+			}
+		})
+	}
+}
@@ -296,14 +296,7 @@ func ValidateTZValueDiagFunc(v interface{}, p cty.Path) diag.Diagnostics {
 	var diags diag.Diagnostics
 
 	value := v.(string)
-	valid := false
-
-	foundAt := sort.SearchStrings(validTZ, value)
-	if foundAt < len(validTZ) && validTZ[foundAt] == value {
-		valid = true
-	}
-
-	if !valid {
+	if !IsValidTZ(value) {
 		diags = append(diags, diag.Diagnostic{
 			Severity:      diag.Error,
 			Summary:       fmt.Sprintf("%q is a not valid input. Please refer to the list of allowed Time Zone values at https://developer.pagerduty.com/docs/1afe25e9c94cb-types#time-zone", value),
@@ -314,6 +307,13 @@ func ValidateTZValueDiagFunc(v interface{}, p cty.Path) diag.Diagnostics {
 	return diags
 }
 
+// IsValidTZ reports whether value is one of the time zones accepted by
+// PagerDuty.
+func IsValidTZ(value string) bool {
+	foundAt := sort.SearchStrings(validTZ, value)
+	return foundAt < len(validTZ) && validTZ[foundAt] == value
+}
+
 // validTZ at the moment there not an API to fetch this values, so hardcoding
 // them here
 var validTZ []string = []string{
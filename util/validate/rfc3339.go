@@ -0,0 +1,50 @@
+package validate
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+type rfc3339 struct{}
+
+var _ validator.String = (*rfc3339)(nil)
+
+func (v *rfc3339) Description(context.Context) string {
+	return "Validates string is a timestamp in RFC3339 format set to a full minute"
+}
+
+func (v *rfc3339) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v *rfc3339) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+	value := req.ConfigValue.ValueString()
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Value",
+			fmt.Sprintf("%s is not a valid format for argument: %s. Expected format: %s (RFC3339)", value, req.Path, time.RFC3339),
+		)
+		return
+	}
+	if t.Second() > 0 {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Value",
+			fmt.Sprintf("please set the time %s to a full minute, e.g. 11:23:00, not 11:23:05", value),
+		)
+	}
+}
+
+// IsRFC3339 validates a string is a timestamp formatted as RFC3339 with no
+// seconds, the same rules `util.ValidateRFC3339` applies to SDKv2 schemas.
+func IsRFC3339() validator.String {
+	return &rfc3339{}
+}
@@ -0,0 +1,41 @@
+package validate
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/PagerDuty/terraform-provider-pagerduty/util"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+type timeZone struct{}
+
+var _ validator.String = (*timeZone)(nil)
+
+func (v *timeZone) Description(context.Context) string {
+	return "Validates string is a time zone accepted by PagerDuty"
+}
+
+func (v *timeZone) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v *timeZone) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+	value := req.ConfigValue.ValueString()
+	if !util.IsValidTZ(value) {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Value",
+			fmt.Sprintf("%q is a not valid input. Please refer to the list of allowed Time Zone values at https://developer.pagerduty.com/docs/1afe25e9c94cb-types#time-zone", value),
+		)
+	}
+}
+
+// IsTimeZone validates a string is one of the time zones allowed by PagerDuty,
+// the framework counterpart of `util.ValidateTZValueDiagFunc`.
+func IsTimeZone() validator.String {
+	return &timeZone{}
+}